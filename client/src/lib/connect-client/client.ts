@@ -1,5 +1,32 @@
+import type { Interceptor } from "@connectrpc/connect";
 import { createConnectTransport } from "@connectrpc/connect-web";
 
+// 서버의 AUTH_COOKIES=true 모드와 맞춰 쓰는 이름입니다.
+const csrfCookie = "csrf_token";
+const csrfHeader = "X-CSRF-Token";
+
+function readCookie(name: string): string | undefined {
+	for (const part of document.cookie.split(";")) {
+		const [key, ...value] = part.trim().split("=");
+		if (key === name) {
+			return decodeURIComponent(value.join("="));
+		}
+	}
+	return undefined;
+}
+
+// 쿠키 세션에서는 GET 이외의 요청에 csrf_token 쿠키 값을 헤더로 되돌려 보내야 합니다.
+const csrf: Interceptor = (next) => async (req) => {
+	const token = readCookie(csrfCookie);
+	if (token && req.requestMethod !== "GET") {
+		req.header.set(csrfHeader, token);
+	}
+	return next(req);
+};
+
 export const transport = createConnectTransport({
 	baseUrl: "http://localhost:8080",
+	// 세션 쿠키를 다른 출처의 API 서버로 보내려면 필요합니다.
+	fetch: (input, init) => fetch(input, { ...init, credentials: "include" }),
+	interceptors: [csrf],
 });
@@ -0,0 +1,67 @@
+// @generated by protoc-gen-es v2.10.1 with parameter "target=ts,import_extension=none"
+// @generated from file apikey/api_key.proto (package apikey, syntax proto3)
+/* eslint-disable */
+
+import type { GenFile, GenMessage } from "@bufbuild/protobuf/codegenv2";
+import { fileDesc, messageDesc } from "@bufbuild/protobuf/codegenv2";
+import type { Timestamp } from "@bufbuild/protobuf/wkt";
+import { file_google_protobuf_timestamp } from "@bufbuild/protobuf/wkt";
+import type { Message } from "@bufbuild/protobuf";
+
+/**
+ * Describes the file apikey/api_key.proto.
+ */
+export const file_apikey_api_key: GenFile = /*@__PURE__*/
+  fileDesc("ChRhcGlrZXkvYXBpX2tleS5wcm90bxIGYXBpa2V5Iv4BCgZBcGlLZXkSCgoCaWQYASABKAkSDAoEbmFtZRgCIAEoCRIOCgZwcmVmaXgYAyABKAkSDgoGc2NvcGVzGAQgAygJEjMKCmV4cGlyZXNfYXQYBSABKAsyGi5nb29nbGUucHJvdG9idWYuVGltZXN0YW1wSACIAQESNQoMbGFzdF91c2VkX2F0GAYgASgLMhouZ29vZ2xlLnByb3RvYnVmLlRpbWVzdGFtcEgBiAEBEi4KCmNyZWF0ZWRfYXQYByABKAsyGi5nb29nbGUucHJvdG9idWYuVGltZXN0YW1wQg0KC19leHBpcmVzX2F0Qg8KDV9sYXN0X3VzZWRfYXRCdQoKY29tLmFwaWtleUILQXBpS2V5UHJvdG9QAVoiZ3JwYy1zZXJ2ZXIvcHJvdG8tZ2VuZXJhdGVkL2FwaWtleaICA0FYWKoCBkFwaWtlecoCBkFwaWtleeICEkFwaWtleVxHUEJNZXRhZGF0YeoCBkFwaWtleWIGcHJvdG8z", [file_google_protobuf_timestamp]);
+
+/**
+ * ApiKey describes a key without its secret, which is only shown once.
+ *
+ * @generated from message apikey.ApiKey
+ */
+export type ApiKey = Message<"apikey.ApiKey"> & {
+  /**
+   * @generated from field: string id = 1;
+   */
+  id: string;
+
+  /**
+   * @generated from field: string name = 2;
+   */
+  name: string;
+
+  /**
+   * prefix is the start of the secret, to tell keys apart.
+   *
+   * @generated from field: string prefix = 3;
+   */
+  prefix: string;
+
+  /**
+   * @generated from field: repeated string scopes = 4;
+   */
+  scopes: string[];
+
+  /**
+   * @generated from field: optional google.protobuf.Timestamp expires_at = 5;
+   */
+  expiresAt?: Timestamp;
+
+  /**
+   * @generated from field: optional google.protobuf.Timestamp last_used_at = 6;
+   */
+  lastUsedAt?: Timestamp;
+
+  /**
+   * @generated from field: google.protobuf.Timestamp created_at = 7;
+   */
+  createdAt?: Timestamp;
+};
+
+/**
+ * Describes the message apikey.ApiKey.
+ * Use `create(ApiKeySchema)` to create a new message.
+ */
+export const ApiKeySchema: GenMessage<ApiKey> = /*@__PURE__*/
+  messageDesc(file_apikey_api_key, 0);
+
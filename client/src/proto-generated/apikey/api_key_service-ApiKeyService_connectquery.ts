@@ -0,0 +1,22 @@
+// @generated by protoc-gen-connect-query v2.2.0 with parameter "target=ts,import_extension=none"
+// @generated from file apikey/api_key_service.proto (package apikey, syntax proto3)
+/* eslint-disable */
+
+import { ApiKeyService } from "./api_key_service_pb";
+
+/**
+ * CreateApiKey returns the new key's secret. It cannot be retrieved later.
+ *
+ * @generated from rpc apikey.ApiKeyService.CreateApiKey
+ */
+export const createApiKey = ApiKeyService.method.createApiKey;
+
+/**
+ * @generated from rpc apikey.ApiKeyService.ListApiKeys
+ */
+export const listApiKeys = ApiKeyService.method.listApiKeys;
+
+/**
+ * @generated from rpc apikey.ApiKeyService.RevokeApiKey
+ */
+export const revokeApiKey = ApiKeyService.method.revokeApiKey;
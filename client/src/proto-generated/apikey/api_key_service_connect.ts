@@ -0,0 +1,49 @@
+// @generated by protoc-gen-connect-es v1.7.0 with parameter "target=ts,import_extension=none"
+// @generated from file apikey/api_key_service.proto (package apikey, syntax proto3)
+/* eslint-disable */
+// @ts-nocheck
+
+import { CreateApiKeyRequest, CreateApiKeyResponse, ListApiKeysRequest, ListApiKeysResponse, RevokeApiKeyRequest, RevokeApiKeyResponse } from "./api_key_service_pb";
+import { MethodKind } from "@bufbuild/protobuf";
+
+/**
+ * ApiKeyService manages the authenticated user's API keys. It can only be
+ * called with a user's access token, not with an API key.
+ *
+ * @generated from service apikey.ApiKeyService
+ */
+export const ApiKeyService = {
+  typeName: "apikey.ApiKeyService",
+  methods: {
+    /**
+     * CreateApiKey returns the new key's secret. It cannot be retrieved later.
+     *
+     * @generated from rpc apikey.ApiKeyService.CreateApiKey
+     */
+    createApiKey: {
+      name: "CreateApiKey",
+      I: CreateApiKeyRequest,
+      O: CreateApiKeyResponse,
+      kind: MethodKind.Unary,
+    },
+    /**
+     * @generated from rpc apikey.ApiKeyService.ListApiKeys
+     */
+    listApiKeys: {
+      name: "ListApiKeys",
+      I: ListApiKeysRequest,
+      O: ListApiKeysResponse,
+      kind: MethodKind.Unary,
+    },
+    /**
+     * @generated from rpc apikey.ApiKeyService.RevokeApiKey
+     */
+    revokeApiKey: {
+      name: "RevokeApiKey",
+      I: RevokeApiKeyRequest,
+      O: RevokeApiKeyResponse,
+      kind: MethodKind.Unary,
+    },
+  }
+} as const;
+
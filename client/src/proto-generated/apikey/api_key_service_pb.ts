@@ -0,0 +1,166 @@
+// @generated by protoc-gen-es v2.10.1 with parameter "target=ts,import_extension=none"
+// @generated from file apikey/api_key_service.proto (package apikey, syntax proto3)
+/* eslint-disable */
+
+import type { GenFile, GenMessage, GenService } from "@bufbuild/protobuf/codegenv2";
+import { fileDesc, messageDesc, serviceDesc } from "@bufbuild/protobuf/codegenv2";
+import type { Timestamp } from "@bufbuild/protobuf/wkt";
+import { file_google_protobuf_timestamp } from "@bufbuild/protobuf/wkt";
+import type { ApiKey } from "./api_key_pb";
+import { file_apikey_api_key } from "./api_key_pb";
+import { file_authz_authz } from "../authz/authz_pb";
+import type { Message } from "@bufbuild/protobuf";
+
+/**
+ * Describes the file apikey/api_key_service.proto.
+ */
+export const file_apikey_api_key_service: GenFile = /*@__PURE__*/
+  fileDesc("ChxhcGlrZXkvYXBpX2tleV9zZXJ2aWNlLnByb3RvEgZhcGlrZXkidwoTQ3JlYXRlQXBpS2V5UmVxdWVzdBIMCgRuYW1lGAEgASgJEg4KBnNjb3BlcxgCIAMoCRIzCgpleHBpcmVzX2F0GAMgASgLMhouZ29vZ2xlLnByb3RvYnVmLlRpbWVzdGFtcEgAiAEBQg0KC19leHBpcmVzX2F0IkcKFENyZWF0ZUFwaUtleVJlc3BvbnNlEh8KB2FwaV9rZXkYASABKAsyDi5hcGlrZXkuQXBpS2V5Eg4KBnNlY3JldBgCIAEoCSIUChJMaXN0QXBpS2V5c1JlcXVlc3QiNwoTTGlzdEFwaUtleXNSZXNwb25zZRIgCghhcGlfa2V5cxgBIAMoCzIOLmFwaWtleS5BcGlLZXkiIQoTUmV2b2tlQXBpS2V5UmVxdWVzdBIKCgJpZBgBIAEoCSIWChRSZXZva2VBcGlLZXlSZXNwb25zZTKJAgoNQXBpS2V5U2VydmljZRJTCgxDcmVhdGVBcGlLZXkSGy5hcGlrZXkuQ3JlYXRlQXBpS2V5UmVxdWVzdBocLmFwaWtleS5DcmVhdGVBcGlLZXlSZXNwb25zZSIIgrUYBAgBIAESTgoLTGlzdEFwaUtleXMSGi5hcGlrZXkuTGlzdEFwaUtleXNSZXF1ZXN0GhsuYXBpa2V5Lkxpc3RBcGlLZXlzUmVzcG9uc2UiBoK1GAIIARJTCgxSZXZva2VBcGlLZXkSGy5hcGlrZXkuUmV2b2tlQXBpS2V5UmVxdWVzdBocLmFwaWtleS5SZXZva2VBcGlLZXlSZXNwb25zZSIIgrUYBAgBIAFCfAoKY29tLmFwaWtleUISQXBpS2V5U2VydmljZVByb3RvUAFaImdycGMtc2VydmVyL3Byb3RvLWdlbmVyYXRlZC9hcGlrZXmiAgNBWFiqAgZBcGlrZXnKAgZBcGlrZXniAhJBcGlrZXlcR1BCTWV0YWRhdGHqAgZBcGlrZXliBnByb3RvMw", [file_google_protobuf_timestamp, file_apikey_api_key, file_authz_authz]);
+
+/**
+ * @generated from message apikey.CreateApiKeyRequest
+ */
+export type CreateApiKeyRequest = Message<"apikey.CreateApiKeyRequest"> & {
+  /**
+   * @generated from field: string name = 1;
+   */
+  name: string;
+
+  /**
+   * scopes limit what the key can do, e.g. "items:read" or "items:write".
+   *
+   * @generated from field: repeated string scopes = 2;
+   */
+  scopes: string[];
+
+  /**
+   * @generated from field: optional google.protobuf.Timestamp expires_at = 3;
+   */
+  expiresAt?: Timestamp;
+};
+
+/**
+ * Describes the message apikey.CreateApiKeyRequest.
+ * Use `create(CreateApiKeyRequestSchema)` to create a new message.
+ */
+export const CreateApiKeyRequestSchema: GenMessage<CreateApiKeyRequest> = /*@__PURE__*/
+  messageDesc(file_apikey_api_key_service, 0);
+
+/**
+ * @generated from message apikey.CreateApiKeyResponse
+ */
+export type CreateApiKeyResponse = Message<"apikey.CreateApiKeyResponse"> & {
+  /**
+   * @generated from field: apikey.ApiKey api_key = 1;
+   */
+  apiKey?: ApiKey;
+
+  /**
+   * @generated from field: string secret = 2;
+   */
+  secret: string;
+};
+
+/**
+ * Describes the message apikey.CreateApiKeyResponse.
+ * Use `create(CreateApiKeyResponseSchema)` to create a new message.
+ */
+export const CreateApiKeyResponseSchema: GenMessage<CreateApiKeyResponse> = /*@__PURE__*/
+  messageDesc(file_apikey_api_key_service, 1);
+
+/**
+ * @generated from message apikey.ListApiKeysRequest
+ */
+export type ListApiKeysRequest = Message<"apikey.ListApiKeysRequest"> & {
+};
+
+/**
+ * Describes the message apikey.ListApiKeysRequest.
+ * Use `create(ListApiKeysRequestSchema)` to create a new message.
+ */
+export const ListApiKeysRequestSchema: GenMessage<ListApiKeysRequest> = /*@__PURE__*/
+  messageDesc(file_apikey_api_key_service, 2);
+
+/**
+ * @generated from message apikey.ListApiKeysResponse
+ */
+export type ListApiKeysResponse = Message<"apikey.ListApiKeysResponse"> & {
+  /**
+   * @generated from field: repeated apikey.ApiKey api_keys = 1;
+   */
+  apiKeys: ApiKey[];
+};
+
+/**
+ * Describes the message apikey.ListApiKeysResponse.
+ * Use `create(ListApiKeysResponseSchema)` to create a new message.
+ */
+export const ListApiKeysResponseSchema: GenMessage<ListApiKeysResponse> = /*@__PURE__*/
+  messageDesc(file_apikey_api_key_service, 3);
+
+/**
+ * @generated from message apikey.RevokeApiKeyRequest
+ */
+export type RevokeApiKeyRequest = Message<"apikey.RevokeApiKeyRequest"> & {
+  /**
+   * @generated from field: string id = 1;
+   */
+  id: string;
+};
+
+/**
+ * Describes the message apikey.RevokeApiKeyRequest.
+ * Use `create(RevokeApiKeyRequestSchema)` to create a new message.
+ */
+export const RevokeApiKeyRequestSchema: GenMessage<RevokeApiKeyRequest> = /*@__PURE__*/
+  messageDesc(file_apikey_api_key_service, 4);
+
+/**
+ * @generated from message apikey.RevokeApiKeyResponse
+ */
+export type RevokeApiKeyResponse = Message<"apikey.RevokeApiKeyResponse"> & {
+};
+
+/**
+ * Describes the message apikey.RevokeApiKeyResponse.
+ * Use `create(RevokeApiKeyResponseSchema)` to create a new message.
+ */
+export const RevokeApiKeyResponseSchema: GenMessage<RevokeApiKeyResponse> = /*@__PURE__*/
+  messageDesc(file_apikey_api_key_service, 5);
+
+/**
+ * ApiKeyService manages the authenticated user's API keys. It can only be
+ * called with a user's access token, not with an API key.
+ *
+ * @generated from service apikey.ApiKeyService
+ */
+export const ApiKeyService: GenService<{
+  /**
+   * CreateApiKey returns the new key's secret. It cannot be retrieved later.
+   *
+   * @generated from rpc apikey.ApiKeyService.CreateApiKey
+   */
+  createApiKey: {
+    methodKind: "unary";
+    input: typeof CreateApiKeyRequestSchema;
+    output: typeof CreateApiKeyResponseSchema;
+  },
+  /**
+   * @generated from rpc apikey.ApiKeyService.ListApiKeys
+   */
+  listApiKeys: {
+    methodKind: "unary";
+    input: typeof ListApiKeysRequestSchema;
+    output: typeof ListApiKeysResponseSchema;
+  },
+  /**
+   * @generated from rpc apikey.ApiKeyService.RevokeApiKey
+   */
+  revokeApiKey: {
+    methodKind: "unary";
+    input: typeof RevokeApiKeyRequestSchema;
+    output: typeof RevokeApiKeyResponseSchema;
+  },
+}> = /*@__PURE__*/
+  serviceDesc(file_apikey_api_key_service, 0);
+
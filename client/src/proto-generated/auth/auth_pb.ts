@@ -2,20 +2,21 @@
 // @generated from file auth/auth.proto (package auth, syntax proto3)
 /* eslint-disable */
 
-import type { GenFile, GenMessage } from "@bufbuild/protobuf/codegenv2";
-import { fileDesc, messageDesc } from "@bufbuild/protobuf/codegenv2";
-import type { Timestamp } from "@bufbuild/protobuf/wkt";
-import { file_google_protobuf_timestamp } from "@bufbuild/protobuf/wkt";
+import type { GenEnum, GenFile, GenMessage } from "@bufbuild/protobuf/codegenv2";
+import { enumDesc, fileDesc, messageDesc } from "@bufbuild/protobuf/codegenv2";
+import type { Duration, Timestamp } from "@bufbuild/protobuf/wkt";
+import { file_google_protobuf_duration, file_google_protobuf_timestamp } from "@bufbuild/protobuf/wkt";
 import type { Message } from "@bufbuild/protobuf";
 
 /**
  * Describes the file auth/auth.proto.
  */
 export const file_auth_auth: GenFile = /*@__PURE__*/
-  fileDesc("Cg9hdXRoL2F1dGgucHJvdG8SBGF1dGgiaAoJVG9rZW5QYWlyEhQKDGFjY2Vzc190b2tlbhgBIAEoCRIVCg1yZWZyZXNoX3Rva2VuGAIgASgJEi4KCmV4cGlyZXNfYXQYAyABKAsyGi5nb29nbGUucHJvdG9idWYuVGltZXN0YW1wQmcKCGNvbS5hdXRoQglBdXRoUHJvdG9QAVogZ3JwYy1zZXJ2ZXIvcHJvdG8tZ2VuZXJhdGVkL2F1dGiiAgNBWFiqAgRBdXRoygIEQXV0aOICEEF1dGhcR1BCTWV0YWRhdGHqAgRBdXRoYgZwcm90bzM", [file_google_protobuf_timestamp]);
+  fileDesc("Cg9hdXRoL2F1dGgucHJvdG8SBGF1dGgiaAoJVG9rZW5QYWlyEhQKDGFjY2Vzc190b2tlbhgBIAEoCRIVCg1yZWZyZXNoX3Rva2VuGAIgASgJEi4KCmV4cGlyZXNfYXQYAyABKAsyGi5nb29nbGUucHJvdG9idWYuVGltZXN0YW1wIjsKCVJldHJ5SW5mbxIuCgtyZXRyeV9kZWxheRgBIAEoCzIZLmdvb2dsZS5wcm90b2J1Zi5EdXJhdGlvbiI8CgpCYWRSZXF1ZXN0Ei4KEGZpZWxkX3Zpb2xhdGlvbnMYASADKAsyFC5hdXRoLkZpZWxkVmlvbGF0aW9uIkQKDkZpZWxkVmlvbGF0aW9uEg0KBWZpZWxkGAEgASgJEg4KBnJlYXNvbhgCIAEoCRITCgtkZXNjcmlwdGlvbhgDIAEoCSKwAQoHU2Vzc2lvbhIKCgJpZBgBIAEoCRISCgp1c2VyX2FnZW50GAIgASgJEhIKCmlwX2FkZHJlc3MYAyABKAkSLgoKY3JlYXRlZF9hdBgEIAEoCzIaLmdvb2dsZS5wcm90b2J1Zi5UaW1lc3RhbXASMAoMbGFzdF9zZWVuX2F0GAUgASgLMhouZ29vZ2xlLnByb3RvYnVmLlRpbWVzdGFtcBIPCgdjdXJyZW50GAYgASgIIt0CCglBdXRoRXZlbnQSCgoCaWQYASABKAkSDAoEdHlwZRgCIAEoCRIRCglwcm9jZWR1cmUYAyABKAkSDwoHdXNlcl9pZBgEIAEoCRINCgVlbWFpbBgFIAEoCRISCgppcF9hZGRyZXNzGAYgASgJEhIKCnVzZXJfYWdlbnQYByABKAkSKAoHb3V0Y29tZRgIIAEoDjIXLmF1dGguQXV0aEV2ZW50Lk91dGNvbWUSDgoGcmVhc29uGAkgASgJEi4KCmNyZWF0ZWRfYXQYCiABKAsyGi5nb29nbGUucHJvdG9idWYuVGltZXN0YW1wEhAKCGFjdG9yX2lkGAsgASgJEhEKCXByaW5jaXBhbBgMIAEoCSJMCgdPdXRjb21lEhcKE09VVENPTUVfVU5TUEVDSUZJRUQQABITCg9PVVRDT01FX1NVQ0NFU1MQARITCg9PVVRDT01FX0ZBSUxVUkUQAkJnCghjb20uYXV0aEIJQXV0aFByb3RvUAFaIGdycGMtc2VydmVyL3Byb3RvLWdlbmVyYXRlZC9hdXRoogIDQVhYqgIEQXV0aMoCBEF1dGjiAhBBdXRoXEdQQk1ldGFkYXRh6gIEQXV0aGIGcHJvdG8z", [file_google_protobuf_duration, file_google_protobuf_timestamp]);
 
 /**
- * TokenPair represents a pair of tokens issued for authentication.
+ * TokenPair represents a pair of tokens issued for authentication. When the
+ * server keeps sessions in cookies, only expires_at is set.
  *
  * @generated from message auth.TokenPair
  */
@@ -43,3 +44,232 @@ export type TokenPair = Message<"auth.TokenPair"> & {
 export const TokenPairSchema: GenMessage<TokenPair> = /*@__PURE__*/
   messageDesc(file_auth_auth, 0);
 
+/**
+ * RetryInfo is attached as an error detail to throttled calls and tells the
+ * client how long to wait before trying again.
+ *
+ * @generated from message auth.RetryInfo
+ */
+export type RetryInfo = Message<"auth.RetryInfo"> & {
+  /**
+   * @generated from field: google.protobuf.Duration retry_delay = 1;
+   */
+  retryDelay?: Duration;
+};
+
+/**
+ * Describes the message auth.RetryInfo.
+ * Use `create(RetryInfoSchema)` to create a new message.
+ */
+export const RetryInfoSchema: GenMessage<RetryInfo> = /*@__PURE__*/
+  messageDesc(file_auth_auth, 1);
+
+/**
+ * BadRequest is attached as an error detail to calls rejected because of
+ * their fields, with one violation per broken rule.
+ *
+ * @generated from message auth.BadRequest
+ */
+export type BadRequest = Message<"auth.BadRequest"> & {
+  /**
+   * @generated from field: repeated auth.FieldViolation field_violations = 1;
+   */
+  fieldViolations: FieldViolation[];
+};
+
+/**
+ * Describes the message auth.BadRequest.
+ * Use `create(BadRequestSchema)` to create a new message.
+ */
+export const BadRequestSchema: GenMessage<BadRequest> = /*@__PURE__*/
+  messageDesc(file_auth_auth, 2);
+
+/**
+ * @generated from message auth.FieldViolation
+ */
+export type FieldViolation = Message<"auth.FieldViolation"> & {
+  /**
+   * field is the request field the violation is about, e.g. "new_password".
+   *
+   * @generated from field: string field = 1;
+   */
+  field: string;
+
+  /**
+   * reason is a stable code clients can match on, e.g. "too_short".
+   *
+   * @generated from field: string reason = 2;
+   */
+  reason: string;
+
+  /**
+   * description explains the violation to the user.
+   *
+   * @generated from field: string description = 3;
+   */
+  description: string;
+};
+
+/**
+ * Describes the message auth.FieldViolation.
+ * Use `create(FieldViolationSchema)` to create a new message.
+ */
+export const FieldViolationSchema: GenMessage<FieldViolation> = /*@__PURE__*/
+  messageDesc(file_auth_auth, 3);
+
+/**
+ * Session is a device the user is signed in on.
+ *
+ * @generated from message auth.Session
+ */
+export type Session = Message<"auth.Session"> & {
+  /**
+   * @generated from field: string id = 1;
+   */
+  id: string;
+
+  /**
+   * @generated from field: string user_agent = 2;
+   */
+  userAgent: string;
+
+  /**
+   * @generated from field: string ip_address = 3;
+   */
+  ipAddress: string;
+
+  /**
+   * @generated from field: google.protobuf.Timestamp created_at = 4;
+   */
+  createdAt?: Timestamp;
+
+  /**
+   * @generated from field: google.protobuf.Timestamp last_seen_at = 5;
+   */
+  lastSeenAt?: Timestamp;
+
+  /**
+   * Set for the session the request was made from.
+   *
+   * @generated from field: bool current = 6;
+   */
+  current: boolean;
+};
+
+/**
+ * Describes the message auth.Session.
+ * Use `create(SessionSchema)` to create a new message.
+ */
+export const SessionSchema: GenMessage<Session> = /*@__PURE__*/
+  messageDesc(file_auth_auth, 4);
+
+/**
+ * AuthEvent is an entry in the authentication audit log.
+ *
+ * @generated from message auth.AuthEvent
+ */
+export type AuthEvent = Message<"auth.AuthEvent"> & {
+  /**
+   * @generated from field: string id = 1;
+   */
+  id: string;
+
+  /**
+   * type is what happened, e.g. "login", "refresh_token" or "authenticate".
+   *
+   * @generated from field: string type = 2;
+   */
+  type: string;
+
+  /**
+   * procedure is the RPC or HTTP path the event was recorded for.
+   *
+   * @generated from field: string procedure = 3;
+   */
+  procedure: string;
+
+  /**
+   * @generated from field: string user_id = 4;
+   */
+  userId: string;
+
+  /**
+   * email is the address a sign-in was attempted with.
+   *
+   * @generated from field: string email = 5;
+   */
+  email: string;
+
+  /**
+   * @generated from field: string ip_address = 6;
+   */
+  ipAddress: string;
+
+  /**
+   * @generated from field: string user_agent = 7;
+   */
+  userAgent: string;
+
+  /**
+   * @generated from field: auth.AuthEvent.Outcome outcome = 8;
+   */
+  outcome: AuthEvent_Outcome;
+
+  /**
+   * @generated from field: string reason = 9;
+   */
+  reason: string;
+
+  /**
+   * @generated from field: google.protobuf.Timestamp created_at = 10;
+   */
+  createdAt?: Timestamp;
+
+  /**
+   * actor_id is the admin who acted as user_id through impersonation.
+   *
+   * @generated from field: string actor_id = 11;
+   */
+  actorId: string;
+
+  /**
+   * principal is the service that made the call over mutual TLS.
+   *
+   * @generated from field: string principal = 12;
+   */
+  principal: string;
+};
+
+/**
+ * Describes the message auth.AuthEvent.
+ * Use `create(AuthEventSchema)` to create a new message.
+ */
+export const AuthEventSchema: GenMessage<AuthEvent> = /*@__PURE__*/
+  messageDesc(file_auth_auth, 5);
+
+/**
+ * @generated from enum auth.AuthEvent.Outcome
+ */
+export enum AuthEvent_Outcome {
+  /**
+   * @generated from enum value: OUTCOME_UNSPECIFIED = 0;
+   */
+  UNSPECIFIED = 0,
+
+  /**
+   * @generated from enum value: OUTCOME_SUCCESS = 1;
+   */
+  SUCCESS = 1,
+
+  /**
+   * @generated from enum value: OUTCOME_FAILURE = 2;
+   */
+  FAILURE = 2,
+}
+
+/**
+ * Describes the enum auth.AuthEvent.Outcome.
+ */
+export const AuthEvent_OutcomeSchema: GenEnum<AuthEvent_Outcome> = /*@__PURE__*/
+  enumDesc(file_auth_auth, 5, 0);
+
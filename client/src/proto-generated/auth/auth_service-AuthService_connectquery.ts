@@ -23,3 +23,122 @@ export const logout = AuthService.method.logout;
  * @generated from rpc auth.AuthService.RefreshToken
  */
 export const refreshToken = AuthService.method.refreshToken;
+
+/**
+ * SendVerificationEmail emails the authenticated user a link to confirm their address.
+ *
+ * @generated from rpc auth.AuthService.SendVerificationEmail
+ */
+export const sendVerificationEmail = AuthService.method.sendVerificationEmail;
+
+/**
+ * VerifyEmail consumes the token from a verification link.
+ *
+ * @generated from rpc auth.AuthService.VerifyEmail
+ */
+export const verifyEmail = AuthService.method.verifyEmail;
+
+/**
+ * RequestPasswordReset emails a reset link if the address is registered.
+ * The response is the same either way.
+ *
+ * @generated from rpc auth.AuthService.RequestPasswordReset
+ */
+export const requestPasswordReset = AuthService.method.requestPasswordReset;
+
+/**
+ * ResetPassword sets a new password and signs the user out everywhere.
+ *
+ * @generated from rpc auth.AuthService.ResetPassword
+ */
+export const resetPassword = AuthService.method.resetPassword;
+
+/**
+ * ChangePassword replaces the authenticated user's password after
+ * re-checking the current one.
+ *
+ * @generated from rpc auth.AuthService.ChangePassword
+ */
+export const changePassword = AuthService.method.changePassword;
+
+/**
+ * EnrollTOTP starts setting up an authenticator app for the authenticated user.
+ *
+ * @generated from rpc auth.AuthService.EnrollTOTP
+ */
+export const enrollTOTP = AuthService.method.enrollTOTP;
+
+/**
+ * ConfirmTOTP enables MFA once the app produces a valid code.
+ *
+ * @generated from rpc auth.AuthService.ConfirmTOTP
+ */
+export const confirmTOTP = AuthService.method.confirmTOTP;
+
+/**
+ * DisableTOTP turns MFA off after checking a TOTP or recovery code.
+ *
+ * @generated from rpc auth.AuthService.DisableTOTP
+ */
+export const disableTOTP = AuthService.method.disableTOTP;
+
+/**
+ * VerifyMFA exchanges the challenge token returned by Login for tokens.
+ *
+ * @generated from rpc auth.AuthService.VerifyMFA
+ */
+export const verifyMFA = AuthService.method.verifyMFA;
+
+/**
+ * ListSessions returns the devices the authenticated user is signed in on.
+ *
+ * @generated from rpc auth.AuthService.ListSessions
+ */
+export const listSessions = AuthService.method.listSessions;
+
+/**
+ * RevokeSession signs one of the authenticated user's sessions out.
+ *
+ * @generated from rpc auth.AuthService.RevokeSession
+ */
+export const revokeSession = AuthService.method.revokeSession;
+
+/**
+ * RevokeAllOtherSessions signs out every session but the caller's, which
+ * continues with the returned tokens.
+ *
+ * @generated from rpc auth.AuthService.RevokeAllOtherSessions
+ */
+export const revokeAllOtherSessions = AuthService.method.revokeAllOtherSessions;
+
+/**
+ * ListAuthEvents searches the authentication audit log, newest first.
+ *
+ * @generated from rpc auth.AuthService.ListAuthEvents
+ */
+export const listAuthEvents = AuthService.method.listAuthEvents;
+
+/**
+ * Impersonate issues a short-lived access token that acts as another
+ * user, for reproducing their issues. It can't be refreshed and carries
+ * the admin's ID in its act claim.
+ *
+ * @generated from rpc auth.AuthService.Impersonate
+ */
+export const impersonate = AuthService.method.impersonate;
+
+/**
+ * RequestMagicLink emails a single-use sign-in link. The response is the
+ * same whether or not the address is registered.
+ *
+ * @generated from rpc auth.AuthService.RequestMagicLink
+ */
+export const requestMagicLink = AuthService.method.requestMagicLink;
+
+/**
+ * ConsumeMagicLink signs in with the token from a magic link, creating
+ * the account on first use if the server allows it.
+ *
+ * @generated from rpc auth.AuthService.ConsumeMagicLink
+ */
+export const consumeMagicLink = AuthService.method.consumeMagicLink;
@@ -3,7 +3,7 @@
 /* eslint-disable */
 // @ts-nocheck
 
-import { LoginRequest, LoginResponse, LogoutRequest, LogoutResponse, RefreshTokenRequest, RefreshTokenResponse, RegisterRequest, RegisterResponse } from "./auth_service_pb";
+import { ChangePasswordRequest, ChangePasswordResponse, ConfirmTOTPRequest, ConfirmTOTPResponse, ConsumeMagicLinkRequest, DisableTOTPRequest, DisableTOTPResponse, EnrollTOTPRequest, EnrollTOTPResponse, ImpersonateRequest, ImpersonateResponse, ListAuthEventsRequest, ListAuthEventsResponse, ListSessionsRequest, ListSessionsResponse, LoginRequest, LoginResponse, LogoutRequest, LogoutResponse, RefreshTokenRequest, RefreshTokenResponse, RegisterRequest, RegisterResponse, RequestMagicLinkRequest, RequestMagicLinkResponse, RequestPasswordResetRequest, RequestPasswordResetResponse, ResetPasswordRequest, ResetPasswordResponse, RevokeAllOtherSessionsRequest, RevokeAllOtherSessionsResponse, RevokeSessionRequest, RevokeSessionResponse, SendVerificationEmailRequest, SendVerificationEmailResponse, VerifyEmailRequest, VerifyEmailResponse, VerifyMFARequest, VerifyMFAResponse } from "./auth_service_pb";
 import { MethodKind } from "@bufbuild/protobuf";
 
 /**
@@ -48,6 +48,189 @@ export const AuthService = {
       O: RefreshTokenResponse,
       kind: MethodKind.Unary,
     },
+    /**
+     * SendVerificationEmail emails the authenticated user a link to confirm their address.
+     *
+     * @generated from rpc auth.AuthService.SendVerificationEmail
+     */
+    sendVerificationEmail: {
+      name: "SendVerificationEmail",
+      I: SendVerificationEmailRequest,
+      O: SendVerificationEmailResponse,
+      kind: MethodKind.Unary,
+    },
+    /**
+     * VerifyEmail consumes the token from a verification link.
+     *
+     * @generated from rpc auth.AuthService.VerifyEmail
+     */
+    verifyEmail: {
+      name: "VerifyEmail",
+      I: VerifyEmailRequest,
+      O: VerifyEmailResponse,
+      kind: MethodKind.Unary,
+    },
+    /**
+     * RequestPasswordReset emails a reset link if the address is registered.
+     * The response is the same either way.
+     *
+     * @generated from rpc auth.AuthService.RequestPasswordReset
+     */
+    requestPasswordReset: {
+      name: "RequestPasswordReset",
+      I: RequestPasswordResetRequest,
+      O: RequestPasswordResetResponse,
+      kind: MethodKind.Unary,
+    },
+    /**
+     * ResetPassword sets a new password and signs the user out everywhere.
+     *
+     * @generated from rpc auth.AuthService.ResetPassword
+     */
+    resetPassword: {
+      name: "ResetPassword",
+      I: ResetPasswordRequest,
+      O: ResetPasswordResponse,
+      kind: MethodKind.Unary,
+    },
+    /**
+     * ChangePassword replaces the authenticated user's password after
+     * re-checking the current one.
+     *
+     * @generated from rpc auth.AuthService.ChangePassword
+     */
+    changePassword: {
+      name: "ChangePassword",
+      I: ChangePasswordRequest,
+      O: ChangePasswordResponse,
+      kind: MethodKind.Unary,
+    },
+    /**
+     * EnrollTOTP starts setting up an authenticator app for the authenticated user.
+     *
+     * @generated from rpc auth.AuthService.EnrollTOTP
+     */
+    enrollTOTP: {
+      name: "EnrollTOTP",
+      I: EnrollTOTPRequest,
+      O: EnrollTOTPResponse,
+      kind: MethodKind.Unary,
+    },
+    /**
+     * ConfirmTOTP enables MFA once the app produces a valid code.
+     *
+     * @generated from rpc auth.AuthService.ConfirmTOTP
+     */
+    confirmTOTP: {
+      name: "ConfirmTOTP",
+      I: ConfirmTOTPRequest,
+      O: ConfirmTOTPResponse,
+      kind: MethodKind.Unary,
+    },
+    /**
+     * DisableTOTP turns MFA off after checking a TOTP or recovery code.
+     *
+     * @generated from rpc auth.AuthService.DisableTOTP
+     */
+    disableTOTP: {
+      name: "DisableTOTP",
+      I: DisableTOTPRequest,
+      O: DisableTOTPResponse,
+      kind: MethodKind.Unary,
+    },
+    /**
+     * VerifyMFA exchanges the challenge token returned by Login for tokens.
+     *
+     * @generated from rpc auth.AuthService.VerifyMFA
+     */
+    verifyMFA: {
+      name: "VerifyMFA",
+      I: VerifyMFARequest,
+      O: VerifyMFAResponse,
+      kind: MethodKind.Unary,
+    },
+    /**
+     * ListSessions returns the devices the authenticated user is signed in on.
+     *
+     * @generated from rpc auth.AuthService.ListSessions
+     */
+    listSessions: {
+      name: "ListSessions",
+      I: ListSessionsRequest,
+      O: ListSessionsResponse,
+      kind: MethodKind.Unary,
+    },
+    /**
+     * RevokeSession signs one of the authenticated user's sessions out.
+     *
+     * @generated from rpc auth.AuthService.RevokeSession
+     */
+    revokeSession: {
+      name: "RevokeSession",
+      I: RevokeSessionRequest,
+      O: RevokeSessionResponse,
+      kind: MethodKind.Unary,
+    },
+    /**
+     * RevokeAllOtherSessions signs out every session but the caller's, which
+     * continues with the returned tokens.
+     *
+     * @generated from rpc auth.AuthService.RevokeAllOtherSessions
+     */
+    revokeAllOtherSessions: {
+      name: "RevokeAllOtherSessions",
+      I: RevokeAllOtherSessionsRequest,
+      O: RevokeAllOtherSessionsResponse,
+      kind: MethodKind.Unary,
+    },
+    /**
+     * ListAuthEvents searches the authentication audit log, newest first.
+     *
+     * @generated from rpc auth.AuthService.ListAuthEvents
+     */
+    listAuthEvents: {
+      name: "ListAuthEvents",
+      I: ListAuthEventsRequest,
+      O: ListAuthEventsResponse,
+      kind: MethodKind.Unary,
+    },
+    /**
+     * Impersonate issues a short-lived access token that acts as another
+     * user, for reproducing their issues. It can't be refreshed and carries
+     * the admin's ID in its act claim.
+     *
+     * @generated from rpc auth.AuthService.Impersonate
+     */
+    impersonate: {
+      name: "Impersonate",
+      I: ImpersonateRequest,
+      O: ImpersonateResponse,
+      kind: MethodKind.Unary,
+    },
+    /**
+     * RequestMagicLink emails a single-use sign-in link. The response is the
+     * same whether or not the address is registered.
+     *
+     * @generated from rpc auth.AuthService.RequestMagicLink
+     */
+    requestMagicLink: {
+      name: "RequestMagicLink",
+      I: RequestMagicLinkRequest,
+      O: RequestMagicLinkResponse,
+      kind: MethodKind.Unary,
+    },
+    /**
+     * ConsumeMagicLink signs in with the token from a magic link, creating
+     * the account on first use if the server allows it.
+     *
+     * @generated from rpc auth.AuthService.ConsumeMagicLink
+     */
+    consumeMagicLink: {
+      name: "ConsumeMagicLink",
+      I: ConsumeMagicLinkRequest,
+      O: LoginResponse,
+      kind: MethodKind.Unary,
+    },
   }
 } as const;
 
@@ -6,15 +6,18 @@ import type { GenFile, GenMessage, GenService } from "@bufbuild/protobuf/codegen
 import { fileDesc, messageDesc, serviceDesc } from "@bufbuild/protobuf/codegenv2";
 import type { User } from "../user/user_pb";
 import { file_user_user } from "../user/user_pb";
-import type { TokenPair } from "./auth_pb";
+import type { AuthEvent, AuthEvent_Outcome, Session, TokenPair } from "./auth_pb";
 import { file_auth_auth } from "./auth_pb";
+import { file_authz_authz } from "../authz/authz_pb";
+import type { Timestamp } from "@bufbuild/protobuf/wkt";
+import { file_google_protobuf_timestamp } from "@bufbuild/protobuf/wkt";
 import type { Message } from "@bufbuild/protobuf";
 
 /**
  * Describes the file auth/auth_service.proto.
  */
 export const file_auth_auth_service: GenFile = /*@__PURE__*/
-  fileDesc("ChdhdXRoL2F1dGhfc2VydmljZS5wcm90bxIEYXV0aCJACg9SZWdpc3RlclJlcXVlc3QSDQoFZW1haWwYASABKAkSEAoIcGFzc3dvcmQYAiABKAkSDAoEbmFtZRgDIAEoCSJNChBSZWdpc3RlclJlc3BvbnNlEhgKBHVzZXIYASABKAsyCi51c2VyLlVzZXISHwoGdG9rZW5zGAIgASgLMg8uYXV0aC5Ub2tlblBhaXIiLwoMTG9naW5SZXF1ZXN0Eg0KBWVtYWlsGAEgASgJEhAKCHBhc3N3b3JkGAIgASgJIkoKDUxvZ2luUmVzcG9uc2USGAoEdXNlchgBIAEoCzIKLnVzZXIuVXNlchIfCgZ0b2tlbnMYAiABKAsyDy5hdXRoLlRva2VuUGFpciIsChNSZWZyZXNoVG9rZW5SZXF1ZXN0EhUKDXJlZnJlc2hfdG9rZW4YASABKAkiNwoUUmVmcmVzaFRva2VuUmVzcG9uc2USHwoGdG9rZW5zGAEgASgLMg8uYXV0aC5Ub2tlblBhaXIiJQoNTG9nb3V0UmVxdWVzdBIUCgxhY2Nlc3NfdG9rZW4YASABKAkiEAoOTG9nb3V0UmVzcG9uc2Uy/gEKC0F1dGhTZXJ2aWNlEjsKCFJlZ2lzdGVyEhUuYXV0aC5SZWdpc3RlclJlcXVlc3QaFi5hdXRoLlJlZ2lzdGVyUmVzcG9uc2UiABIyCgVMb2dpbhISLmF1dGguTG9naW5SZXF1ZXN0GhMuYXV0aC5Mb2dpblJlc3BvbnNlIgASNQoGTG9nb3V0EhMuYXV0aC5Mb2dvdXRSZXF1ZXN0GhQuYXV0aC5Mb2dvdXRSZXNwb25zZSIAEkcKDFJlZnJlc2hUb2tlbhIZLmF1dGguUmVmcmVzaFRva2VuUmVxdWVzdBoaLmF1dGguUmVmcmVzaFRva2VuUmVzcG9uc2UiAEJuCghjb20uYXV0aEIQQXV0aFNlcnZpY2VQcm90b1ABWiBncnBjLXNlcnZlci9wcm90by1nZW5lcmF0ZWQvYXV0aKICA0FYWKoCBEF1dGjKAgRBdXRo4gIQQXV0aFxHUEJNZXRhZGF0YeoCBEF1dGhiBnByb3RvMw", [file_user_user, file_auth_auth]);
+  fileDesc("ChdhdXRoL2F1dGhfc2VydmljZS5wcm90bxIEYXV0aCJACg9SZWdpc3RlclJlcXVlc3QSDQoFZW1haWwYASABKAkSEAoIcGFzc3dvcmQYAiABKAkSDAoEbmFtZRgDIAEoCSJVChBSZWdpc3RlclJlc3BvbnNlEhwKBHVzZXIYASABKAsyCi51c2VyLlVzZXJCAhgBEiMKBnRva2VucxgCIAEoCzIPLmF1dGguVG9rZW5QYWlyQgIYASIvCgxMb2dpblJlcXVlc3QSDQoFZW1haWwYASABKAkSEAoIcGFzc3dvcmQYAiABKAkicwoNTG9naW5SZXNwb25zZRIYCgR1c2VyGAEgASgLMgoudXNlci5Vc2VyEh8KBnRva2VucxgCIAEoCzIPLmF1dGguVG9rZW5QYWlyEhQKDG1mYV9yZXF1aXJlZBgDIAEoCBIRCgltZmFfdG9rZW4YBCABKAkiLAoTUmVmcmVzaFRva2VuUmVxdWVzdBIVCg1yZWZyZXNoX3Rva2VuGAEgASgJIjcKFFJlZnJlc2hUb2tlblJlc3BvbnNlEh8KBnRva2VucxgBIAEoCzIPLmF1dGguVG9rZW5QYWlyIiUKDUxvZ291dFJlcXVlc3QSFAoMYWNjZXNzX3Rva2VuGAEgASgJIhAKDkxvZ291dFJlc3BvbnNlIh4KHFNlbmRWZXJpZmljYXRpb25FbWFpbFJlcXVlc3QiHwodU2VuZFZlcmlmaWNhdGlvbkVtYWlsUmVzcG9uc2UiIwoSVmVyaWZ5RW1haWxSZXF1ZXN0Eg0KBXRva2VuGAEgASgJIi8KE1ZlcmlmeUVtYWlsUmVzcG9uc2USGAoEdXNlchgBIAEoCzIKLnVzZXIuVXNlciIsChtSZXF1ZXN0UGFzc3dvcmRSZXNldFJlcXVlc3QSDQoFZW1haWwYASABKAkiHgocUmVxdWVzdFBhc3N3b3JkUmVzZXRSZXNwb25zZSI7ChRSZXNldFBhc3N3b3JkUmVxdWVzdBINCgV0b2tlbhgBIAEoCRIUCgxuZXdfcGFzc3dvcmQYAiABKAkiFwoVUmVzZXRQYXNzd29yZFJlc3BvbnNlImYKFUNoYW5nZVBhc3N3b3JkUmVxdWVzdBIYChBjdXJyZW50X3Bhc3N3b3JkGAEgASgJEhQKDG5ld19wYXNzd29yZBgCIAEoCRIdChVyZXZva2Vfb3RoZXJfc2Vzc2lvbnMYAyABKAgiOQoWQ2hhbmdlUGFzc3dvcmRSZXNwb25zZRIfCgZ0b2tlbnMYASABKAsyDy5hdXRoLlRva2VuUGFpciITChFFbnJvbGxUT1RQUmVxdWVzdCIxChJFbnJvbGxUT1RQUmVzcG9uc2USDgoGc2VjcmV0GAEgASgJEgsKA3VyaRgCIAEoCSIiChJDb25maXJtVE9UUFJlcXVlc3QSDAoEY29kZRgBIAEoCSItChNDb25maXJtVE9UUFJlc3BvbnNlEhYKDnJlY292ZXJ5X2NvZGVzGAEgAygJIiIKEkRpc2FibGVUT1RQUmVxdWVzdBIMCgRjb2RlGAEgASgJIhUKE0Rpc2FibGVUT1RQUmVzcG9uc2UiMwoQVmVyaWZ5TUZBUmVxdWVzdBIRCgltZmFfdG9rZW4YASABKAkSDAoEY29kZRgCIAEoCSJOChFWZXJpZnlNRkFSZXNwb25zZRIYCgR1c2VyGAEgASgLMgoudXNlci5Vc2VyEh8KBnRva2VucxgCIAEoCzIPLmF1dGguVG9rZW5QYWlyIhUKE0xpc3RTZXNzaW9uc1JlcXVlc3QiNwoUTGlzdFNlc3Npb25zUmVzcG9uc2USHwoIc2Vzc2lvbnMYASADKAsyDS5hdXRoLlNlc3Npb24iIgoUUmV2b2tlU2Vzc2lvblJlcXVlc3QSCgoCaWQYASABKAkiFwoVUmV2b2tlU2Vzc2lvblJlc3BvbnNlIh8KHVJldm9rZUFsbE90aGVyU2Vzc2lvbnNSZXF1ZXN0IkEKHlJldm9rZUFsbE90aGVyU2Vzc2lvbnNSZXNwb25zZRIfCgZ0b2tlbnMYASABKAsyDy5hdXRoLlRva2VuUGFpciKSAgoVTGlzdEF1dGhFdmVudHNSZXF1ZXN0Eg8KB3VzZXJfaWQYASABKAkSDQoFZW1haWwYAiABKAkSEgoKaXBfYWRkcmVzcxgDIAEoCRIMCgR0eXBlGAQgASgJEigKB291dGNvbWUYBSABKA4yFy5hdXRoLkF1dGhFdmVudC5PdXRjb21lEikKBXNpbmNlGAYgASgLMhouZ29vZ2xlLnByb3RvYnVmLlRpbWVzdGFtcBIpCgV1bnRpbBgHIAEoCzIaLmdvb2dsZS5wcm90b2J1Zi5UaW1lc3RhbXASEQoJcGFnZV9zaXplGAggASgFEhIKCnBhZ2VfdG9rZW4YCSABKAkSEAoIYWN0b3JfaWQYCiABKAkiUgoWTGlzdEF1dGhFdmVudHNSZXNwb25zZRIfCgZldmVudHMYASADKAsyDy5hdXRoLkF1dGhFdmVudBIXCg9uZXh0X3BhZ2VfdG9rZW4YAiABKAkiJQoSSW1wZXJzb25hdGVSZXF1ZXN0Eg8KB3VzZXJfaWQYASABKAkiWwoTSW1wZXJzb25hdGVSZXNwb25zZRIUCgxhY2Nlc3NfdG9rZW4YASABKAkSLgoKZXhwaXJlc19hdBgCIAEoCzIaLmdvb2dsZS5wcm90b2J1Zi5UaW1lc3RhbXAiKAoXUmVxdWVzdE1hZ2ljTGlua1JlcXVlc3QSDQoFZW1haWwYASABKAkiGgoYUmVxdWVzdE1hZ2ljTGlua1Jlc3BvbnNlIigKF0NvbnN1bWVNYWdpY0xpbmtSZXF1ZXN0Eg0KBXRva2VuGAEgASgJMogNCgtBdXRoU2VydmljZRJBCghSZWdpc3RlchIVLmF1dGguUmVnaXN0ZXJSZXF1ZXN0GhYuYXV0aC5SZWdpc3RlclJlc3BvbnNlIgaCtRgCCAISOAoFTG9naW4SEi5hdXRoLkxvZ2luUmVxdWVzdBoTLmF1dGguTG9naW5SZXNwb25zZSIGgrUYAggCEjsKBkxvZ291dBITLmF1dGguTG9nb3V0UmVxdWVzdBoULmF1dGguTG9nb3V0UmVzcG9uc2UiBoK1GAIIAhJNCgxSZWZyZXNoVG9rZW4SGS5hdXRoLlJlZnJlc2hUb2tlblJlcXVlc3QaGi5hdXRoLlJlZnJlc2hUb2tlblJlc3BvbnNlIgaCtRgCCAISaAoVU2VuZFZlcmlmaWNhdGlvbkVtYWlsEiIuYXV0aC5TZW5kVmVyaWZpY2F0aW9uRW1haWxSZXF1ZXN0GiMuYXV0aC5TZW5kVmVyaWZpY2F0aW9uRW1haWxSZXNwb25zZSIGgrUYAggBEkoKC1ZlcmlmeUVtYWlsEhguYXV0aC5WZXJpZnlFbWFpbFJlcXVlc3QaGS5hdXRoLlZlcmlmeUVtYWlsUmVzcG9uc2UiBoK1GAIIAhJlChRSZXF1ZXN0UGFzc3dvcmRSZXNldBIhLmF1dGguUmVxdWVzdFBhc3N3b3JkUmVzZXRSZXF1ZXN0GiIuYXV0aC5SZXF1ZXN0UGFzc3dvcmRSZXNldFJlc3BvbnNlIgaCtRgCCAISUAoNUmVzZXRQYXNzd29yZBIaLmF1dGguUmVzZXRQYXNzd29yZFJlcXVlc3QaGy5hdXRoLlJlc2V0UGFzc3dvcmRSZXNwb25zZSIGgrUYAggCElUKDkNoYW5nZVBhc3N3b3JkEhsuYXV0aC5DaGFuZ2VQYXNzd29yZFJlcXVlc3QaHC5hdXRoLkNoYW5nZVBhc3N3b3JkUmVzcG9uc2UiCIK1GAQIASABEkkKCkVucm9sbFRPVFASFy5hdXRoLkVucm9sbFRPVFBSZXF1ZXN0GhguYXV0aC5FbnJvbGxUT1RQUmVzcG9uc2UiCIK1GAQIASABEkwKC0NvbmZpcm1UT1RQEhguYXV0aC5Db25maXJtVE9UUFJlcXVlc3QaGS5hdXRoLkNvbmZpcm1UT1RQUmVzcG9uc2UiCIK1GAQIASABEkwKC0Rpc2FibGVUT1RQEhguYXV0aC5EaXNhYmxlVE9UUFJlcXVlc3QaGS5hdXRoLkRpc2FibGVUT1RQUmVzcG9uc2UiCIK1GAQIASABEkQKCVZlcmlmeU1GQRIWLmF1dGguVmVyaWZ5TUZBUmVxdWVzdBoXLmF1dGguVmVyaWZ5TUZBUmVzcG9uc2UiBoK1GAIIAhJNCgxMaXN0U2Vzc2lvbnMSGS5hdXRoLkxpc3RTZXNzaW9uc1JlcXVlc3QaGi5hdXRoLkxpc3RTZXNzaW9uc1Jlc3BvbnNlIgaCtRgCCAESUAoNUmV2b2tlU2Vzc2lvbhIaLmF1dGguUmV2b2tlU2Vzc2lvblJlcXVlc3QaGy5hdXRoLlJldm9rZVNlc3Npb25SZXNwb25zZSIGgrUYAggBEm0KFlJldm9rZUFsbE90aGVyU2Vzc2lvbnMSIy5hdXRoLlJldm9rZUFsbE90aGVyU2Vzc2lvbnNSZXF1ZXN0GiQuYXV0aC5SZXZva2VBbGxPdGhlclNlc3Npb25zUmVzcG9uc2UiCIK1GAQIASABEmMKDkxpc3RBdXRoRXZlbnRzEhsuYXV0aC5MaXN0QXV0aEV2ZW50c1JlcXVlc3QaHC5hdXRoLkxpc3RBdXRoRXZlbnRzUmVzcG9uc2UiFoK1GBIqEGF1dGhfZXZlbnRzOnJlYWQSXQoLSW1wZXJzb25hdGUSGC5hdXRoLkltcGVyc29uYXRlUmVxdWVzdBoZLmF1dGguSW1wZXJzb25hdGVSZXNwb25zZSIZgrUYFSABKhF1c2VyczppbXBlcnNvbmF0ZRJZChBSZXF1ZXN0TWFnaWNMaW5rEh0uYXV0aC5SZXF1ZXN0TWFnaWNMaW5rUmVxdWVzdBoeLmF1dGguUmVxdWVzdE1hZ2ljTGlua1Jlc3BvbnNlIgaCtRgCCAISTgoQQ29uc3VtZU1hZ2ljTGluaxIdLmF1dGguQ29uc3VtZU1hZ2ljTGlua1JlcXVlc3QaEy5hdXRoLkxvZ2luUmVzcG9uc2UiBoK1GAIIAkJuCghjb20uYXV0aEIQQXV0aFNlcnZpY2VQcm90b1ABWiBncnBjLXNlcnZlci9wcm90by1nZW5lcmF0ZWQvYXV0aKICA0FYWKoCBEF1dGjKAgRBdXRo4gIQQXV0aFxHUEJNZXRhZGF0YeoCBEF1dGhiBnByb3RvMw", [file_user_user, file_auth_auth, file_authz_authz, file_google_protobuf_timestamp]);
 
 /**
  * @generated from message auth.RegisterRequest
@@ -44,16 +47,27 @@ export const RegisterRequestSchema: GenMessage<RegisterRequest> = /*@__PURE__*/
   messageDesc(file_auth_auth_service, 0);
 
 /**
+ * RegisterResponse is the same whether or not the email address was already
+ * taken, so registration cannot be used to find out who has an account. The
+ * owner of the address is told by email either way; new users sign in once
+ * they have registered.
+ *
  * @generated from message auth.RegisterResponse
  */
 export type RegisterResponse = Message<"auth.RegisterResponse"> & {
   /**
-   * @generated from field: user.User user = 1;
+   * No longer set.
+   *
+   * @generated from field: user.User user = 1 [deprecated = true];
+   * @deprecated
    */
   user?: User;
 
   /**
-   * @generated from field: auth.TokenPair tokens = 2;
+   * No longer set.
+   *
+   * @generated from field: auth.TokenPair tokens = 2 [deprecated = true];
+   * @deprecated
    */
   tokens?: TokenPair;
 };
@@ -100,6 +114,19 @@ export type LoginResponse = Message<"auth.LoginResponse"> & {
    * @generated from field: auth.TokenPair tokens = 2;
    */
   tokens?: TokenPair;
+
+  /**
+   * When MFA is enabled, user and tokens are unset and the client must
+   * pass mfa_token and a code to VerifyMFA.
+   *
+   * @generated from field: bool mfa_required = 3;
+   */
+  mfaRequired: boolean;
+
+  /**
+   * @generated from field: string mfa_token = 4;
+   */
+  mfaToken: string;
 };
 
 /**
@@ -173,6 +200,603 @@ export type LogoutResponse = Message<"auth.LogoutResponse"> & {
 export const LogoutResponseSchema: GenMessage<LogoutResponse> = /*@__PURE__*/
   messageDesc(file_auth_auth_service, 7);
 
+/**
+ * @generated from message auth.SendVerificationEmailRequest
+ */
+export type SendVerificationEmailRequest = Message<"auth.SendVerificationEmailRequest"> & {
+};
+
+/**
+ * Describes the message auth.SendVerificationEmailRequest.
+ * Use `create(SendVerificationEmailRequestSchema)` to create a new message.
+ */
+export const SendVerificationEmailRequestSchema: GenMessage<SendVerificationEmailRequest> = /*@__PURE__*/
+  messageDesc(file_auth_auth_service, 8);
+
+/**
+ * @generated from message auth.SendVerificationEmailResponse
+ */
+export type SendVerificationEmailResponse = Message<"auth.SendVerificationEmailResponse"> & {
+};
+
+/**
+ * Describes the message auth.SendVerificationEmailResponse.
+ * Use `create(SendVerificationEmailResponseSchema)` to create a new message.
+ */
+export const SendVerificationEmailResponseSchema: GenMessage<SendVerificationEmailResponse> = /*@__PURE__*/
+  messageDesc(file_auth_auth_service, 9);
+
+/**
+ * @generated from message auth.VerifyEmailRequest
+ */
+export type VerifyEmailRequest = Message<"auth.VerifyEmailRequest"> & {
+  /**
+   * @generated from field: string token = 1;
+   */
+  token: string;
+};
+
+/**
+ * Describes the message auth.VerifyEmailRequest.
+ * Use `create(VerifyEmailRequestSchema)` to create a new message.
+ */
+export const VerifyEmailRequestSchema: GenMessage<VerifyEmailRequest> = /*@__PURE__*/
+  messageDesc(file_auth_auth_service, 10);
+
+/**
+ * @generated from message auth.VerifyEmailResponse
+ */
+export type VerifyEmailResponse = Message<"auth.VerifyEmailResponse"> & {
+  /**
+   * @generated from field: user.User user = 1;
+   */
+  user?: User;
+};
+
+/**
+ * Describes the message auth.VerifyEmailResponse.
+ * Use `create(VerifyEmailResponseSchema)` to create a new message.
+ */
+export const VerifyEmailResponseSchema: GenMessage<VerifyEmailResponse> = /*@__PURE__*/
+  messageDesc(file_auth_auth_service, 11);
+
+/**
+ * @generated from message auth.RequestPasswordResetRequest
+ */
+export type RequestPasswordResetRequest = Message<"auth.RequestPasswordResetRequest"> & {
+  /**
+   * @generated from field: string email = 1;
+   */
+  email: string;
+};
+
+/**
+ * Describes the message auth.RequestPasswordResetRequest.
+ * Use `create(RequestPasswordResetRequestSchema)` to create a new message.
+ */
+export const RequestPasswordResetRequestSchema: GenMessage<RequestPasswordResetRequest> = /*@__PURE__*/
+  messageDesc(file_auth_auth_service, 12);
+
+/**
+ * @generated from message auth.RequestPasswordResetResponse
+ */
+export type RequestPasswordResetResponse = Message<"auth.RequestPasswordResetResponse"> & {
+};
+
+/**
+ * Describes the message auth.RequestPasswordResetResponse.
+ * Use `create(RequestPasswordResetResponseSchema)` to create a new message.
+ */
+export const RequestPasswordResetResponseSchema: GenMessage<RequestPasswordResetResponse> = /*@__PURE__*/
+  messageDesc(file_auth_auth_service, 13);
+
+/**
+ * @generated from message auth.ResetPasswordRequest
+ */
+export type ResetPasswordRequest = Message<"auth.ResetPasswordRequest"> & {
+  /**
+   * @generated from field: string token = 1;
+   */
+  token: string;
+
+  /**
+   * @generated from field: string new_password = 2;
+   */
+  newPassword: string;
+};
+
+/**
+ * Describes the message auth.ResetPasswordRequest.
+ * Use `create(ResetPasswordRequestSchema)` to create a new message.
+ */
+export const ResetPasswordRequestSchema: GenMessage<ResetPasswordRequest> = /*@__PURE__*/
+  messageDesc(file_auth_auth_service, 14);
+
+/**
+ * @generated from message auth.ResetPasswordResponse
+ */
+export type ResetPasswordResponse = Message<"auth.ResetPasswordResponse"> & {
+};
+
+/**
+ * Describes the message auth.ResetPasswordResponse.
+ * Use `create(ResetPasswordResponseSchema)` to create a new message.
+ */
+export const ResetPasswordResponseSchema: GenMessage<ResetPasswordResponse> = /*@__PURE__*/
+  messageDesc(file_auth_auth_service, 15);
+
+/**
+ * @generated from message auth.ChangePasswordRequest
+ */
+export type ChangePasswordRequest = Message<"auth.ChangePasswordRequest"> & {
+  /**
+   * @generated from field: string current_password = 1;
+   */
+  currentPassword: string;
+
+  /**
+   * @generated from field: string new_password = 2;
+   */
+  newPassword: string;
+
+  /**
+   * Sign out every other session. The calling session keeps working with
+   * the tokens returned in the response.
+   *
+   * @generated from field: bool revoke_other_sessions = 3;
+   */
+  revokeOtherSessions: boolean;
+};
+
+/**
+ * Describes the message auth.ChangePasswordRequest.
+ * Use `create(ChangePasswordRequestSchema)` to create a new message.
+ */
+export const ChangePasswordRequestSchema: GenMessage<ChangePasswordRequest> = /*@__PURE__*/
+  messageDesc(file_auth_auth_service, 16);
+
+/**
+ * @generated from message auth.ChangePasswordResponse
+ */
+export type ChangePasswordResponse = Message<"auth.ChangePasswordResponse"> & {
+  /**
+   * Set when revoke_other_sessions was requested; replaces the caller's tokens.
+   *
+   * @generated from field: auth.TokenPair tokens = 1;
+   */
+  tokens?: TokenPair;
+};
+
+/**
+ * Describes the message auth.ChangePasswordResponse.
+ * Use `create(ChangePasswordResponseSchema)` to create a new message.
+ */
+export const ChangePasswordResponseSchema: GenMessage<ChangePasswordResponse> = /*@__PURE__*/
+  messageDesc(file_auth_auth_service, 17);
+
+/**
+ * @generated from message auth.EnrollTOTPRequest
+ */
+export type EnrollTOTPRequest = Message<"auth.EnrollTOTPRequest"> & {
+};
+
+/**
+ * Describes the message auth.EnrollTOTPRequest.
+ * Use `create(EnrollTOTPRequestSchema)` to create a new message.
+ */
+export const EnrollTOTPRequestSchema: GenMessage<EnrollTOTPRequest> = /*@__PURE__*/
+  messageDesc(file_auth_auth_service, 18);
+
+/**
+ * @generated from message auth.EnrollTOTPResponse
+ */
+export type EnrollTOTPResponse = Message<"auth.EnrollTOTPResponse"> & {
+  /**
+   * @generated from field: string secret = 1;
+   */
+  secret: string;
+
+  /**
+   * otpauth:// URI to show as a QR code.
+   *
+   * @generated from field: string uri = 2;
+   */
+  uri: string;
+};
+
+/**
+ * Describes the message auth.EnrollTOTPResponse.
+ * Use `create(EnrollTOTPResponseSchema)` to create a new message.
+ */
+export const EnrollTOTPResponseSchema: GenMessage<EnrollTOTPResponse> = /*@__PURE__*/
+  messageDesc(file_auth_auth_service, 19);
+
+/**
+ * @generated from message auth.ConfirmTOTPRequest
+ */
+export type ConfirmTOTPRequest = Message<"auth.ConfirmTOTPRequest"> & {
+  /**
+   * @generated from field: string code = 1;
+   */
+  code: string;
+};
+
+/**
+ * Describes the message auth.ConfirmTOTPRequest.
+ * Use `create(ConfirmTOTPRequestSchema)` to create a new message.
+ */
+export const ConfirmTOTPRequestSchema: GenMessage<ConfirmTOTPRequest> = /*@__PURE__*/
+  messageDesc(file_auth_auth_service, 20);
+
+/**
+ * @generated from message auth.ConfirmTOTPResponse
+ */
+export type ConfirmTOTPResponse = Message<"auth.ConfirmTOTPResponse"> & {
+  /**
+   * Shown once; each code can replace a TOTP code a single time.
+   *
+   * @generated from field: repeated string recovery_codes = 1;
+   */
+  recoveryCodes: string[];
+};
+
+/**
+ * Describes the message auth.ConfirmTOTPResponse.
+ * Use `create(ConfirmTOTPResponseSchema)` to create a new message.
+ */
+export const ConfirmTOTPResponseSchema: GenMessage<ConfirmTOTPResponse> = /*@__PURE__*/
+  messageDesc(file_auth_auth_service, 21);
+
+/**
+ * @generated from message auth.DisableTOTPRequest
+ */
+export type DisableTOTPRequest = Message<"auth.DisableTOTPRequest"> & {
+  /**
+   * A current TOTP code or an unused recovery code.
+   *
+   * @generated from field: string code = 1;
+   */
+  code: string;
+};
+
+/**
+ * Describes the message auth.DisableTOTPRequest.
+ * Use `create(DisableTOTPRequestSchema)` to create a new message.
+ */
+export const DisableTOTPRequestSchema: GenMessage<DisableTOTPRequest> = /*@__PURE__*/
+  messageDesc(file_auth_auth_service, 22);
+
+/**
+ * @generated from message auth.DisableTOTPResponse
+ */
+export type DisableTOTPResponse = Message<"auth.DisableTOTPResponse"> & {
+};
+
+/**
+ * Describes the message auth.DisableTOTPResponse.
+ * Use `create(DisableTOTPResponseSchema)` to create a new message.
+ */
+export const DisableTOTPResponseSchema: GenMessage<DisableTOTPResponse> = /*@__PURE__*/
+  messageDesc(file_auth_auth_service, 23);
+
+/**
+ * @generated from message auth.VerifyMFARequest
+ */
+export type VerifyMFARequest = Message<"auth.VerifyMFARequest"> & {
+  /**
+   * @generated from field: string mfa_token = 1;
+   */
+  mfaToken: string;
+
+  /**
+   * A current TOTP code or an unused recovery code.
+   *
+   * @generated from field: string code = 2;
+   */
+  code: string;
+};
+
+/**
+ * Describes the message auth.VerifyMFARequest.
+ * Use `create(VerifyMFARequestSchema)` to create a new message.
+ */
+export const VerifyMFARequestSchema: GenMessage<VerifyMFARequest> = /*@__PURE__*/
+  messageDesc(file_auth_auth_service, 24);
+
+/**
+ * @generated from message auth.VerifyMFAResponse
+ */
+export type VerifyMFAResponse = Message<"auth.VerifyMFAResponse"> & {
+  /**
+   * @generated from field: user.User user = 1;
+   */
+  user?: User;
+
+  /**
+   * @generated from field: auth.TokenPair tokens = 2;
+   */
+  tokens?: TokenPair;
+};
+
+/**
+ * Describes the message auth.VerifyMFAResponse.
+ * Use `create(VerifyMFAResponseSchema)` to create a new message.
+ */
+export const VerifyMFAResponseSchema: GenMessage<VerifyMFAResponse> = /*@__PURE__*/
+  messageDesc(file_auth_auth_service, 25);
+
+/**
+ * @generated from message auth.ListSessionsRequest
+ */
+export type ListSessionsRequest = Message<"auth.ListSessionsRequest"> & {
+};
+
+/**
+ * Describes the message auth.ListSessionsRequest.
+ * Use `create(ListSessionsRequestSchema)` to create a new message.
+ */
+export const ListSessionsRequestSchema: GenMessage<ListSessionsRequest> = /*@__PURE__*/
+  messageDesc(file_auth_auth_service, 26);
+
+/**
+ * @generated from message auth.ListSessionsResponse
+ */
+export type ListSessionsResponse = Message<"auth.ListSessionsResponse"> & {
+  /**
+   * @generated from field: repeated auth.Session sessions = 1;
+   */
+  sessions: Session[];
+};
+
+/**
+ * Describes the message auth.ListSessionsResponse.
+ * Use `create(ListSessionsResponseSchema)` to create a new message.
+ */
+export const ListSessionsResponseSchema: GenMessage<ListSessionsResponse> = /*@__PURE__*/
+  messageDesc(file_auth_auth_service, 27);
+
+/**
+ * @generated from message auth.RevokeSessionRequest
+ */
+export type RevokeSessionRequest = Message<"auth.RevokeSessionRequest"> & {
+  /**
+   * @generated from field: string id = 1;
+   */
+  id: string;
+};
+
+/**
+ * Describes the message auth.RevokeSessionRequest.
+ * Use `create(RevokeSessionRequestSchema)` to create a new message.
+ */
+export const RevokeSessionRequestSchema: GenMessage<RevokeSessionRequest> = /*@__PURE__*/
+  messageDesc(file_auth_auth_service, 28);
+
+/**
+ * @generated from message auth.RevokeSessionResponse
+ */
+export type RevokeSessionResponse = Message<"auth.RevokeSessionResponse"> & {
+};
+
+/**
+ * Describes the message auth.RevokeSessionResponse.
+ * Use `create(RevokeSessionResponseSchema)` to create a new message.
+ */
+export const RevokeSessionResponseSchema: GenMessage<RevokeSessionResponse> = /*@__PURE__*/
+  messageDesc(file_auth_auth_service, 29);
+
+/**
+ * @generated from message auth.RevokeAllOtherSessionsRequest
+ */
+export type RevokeAllOtherSessionsRequest = Message<"auth.RevokeAllOtherSessionsRequest"> & {
+};
+
+/**
+ * Describes the message auth.RevokeAllOtherSessionsRequest.
+ * Use `create(RevokeAllOtherSessionsRequestSchema)` to create a new message.
+ */
+export const RevokeAllOtherSessionsRequestSchema: GenMessage<RevokeAllOtherSessionsRequest> = /*@__PURE__*/
+  messageDesc(file_auth_auth_service, 30);
+
+/**
+ * @generated from message auth.RevokeAllOtherSessionsResponse
+ */
+export type RevokeAllOtherSessionsResponse = Message<"auth.RevokeAllOtherSessionsResponse"> & {
+  /**
+   * Replaces the caller's tokens.
+   *
+   * @generated from field: auth.TokenPair tokens = 1;
+   */
+  tokens?: TokenPair;
+};
+
+/**
+ * Describes the message auth.RevokeAllOtherSessionsResponse.
+ * Use `create(RevokeAllOtherSessionsResponseSchema)` to create a new message.
+ */
+export const RevokeAllOtherSessionsResponseSchema: GenMessage<RevokeAllOtherSessionsResponse> = /*@__PURE__*/
+  messageDesc(file_auth_auth_service, 31);
+
+/**
+ * @generated from message auth.ListAuthEventsRequest
+ */
+export type ListAuthEventsRequest = Message<"auth.ListAuthEventsRequest"> & {
+  /**
+   * Filters; unset fields match every event.
+   *
+   * @generated from field: string user_id = 1;
+   */
+  userId: string;
+
+  /**
+   * @generated from field: string email = 2;
+   */
+  email: string;
+
+  /**
+   * @generated from field: string ip_address = 3;
+   */
+  ipAddress: string;
+
+  /**
+   * @generated from field: string type = 4;
+   */
+  type: string;
+
+  /**
+   * @generated from field: auth.AuthEvent.Outcome outcome = 5;
+   */
+  outcome: AuthEvent_Outcome;
+
+  /**
+   * @generated from field: google.protobuf.Timestamp since = 6;
+   */
+  since?: Timestamp;
+
+  /**
+   * @generated from field: google.protobuf.Timestamp until = 7;
+   */
+  until?: Timestamp;
+
+  /**
+   * At most 100; defaults to 50.
+   *
+   * @generated from field: int32 page_size = 8;
+   */
+  pageSize: number;
+
+  /**
+   * next_page_token of the previous page.
+   *
+   * @generated from field: string page_token = 9;
+   */
+  pageToken: string;
+
+  /**
+   * Matches events made by an admin impersonating a user.
+   *
+   * @generated from field: string actor_id = 10;
+   */
+  actorId: string;
+};
+
+/**
+ * Describes the message auth.ListAuthEventsRequest.
+ * Use `create(ListAuthEventsRequestSchema)` to create a new message.
+ */
+export const ListAuthEventsRequestSchema: GenMessage<ListAuthEventsRequest> = /*@__PURE__*/
+  messageDesc(file_auth_auth_service, 32);
+
+/**
+ * @generated from message auth.ListAuthEventsResponse
+ */
+export type ListAuthEventsResponse = Message<"auth.ListAuthEventsResponse"> & {
+  /**
+   * @generated from field: repeated auth.AuthEvent events = 1;
+   */
+  events: AuthEvent[];
+
+  /**
+   * Empty on the last page.
+   *
+   * @generated from field: string next_page_token = 2;
+   */
+  nextPageToken: string;
+};
+
+/**
+ * Describes the message auth.ListAuthEventsResponse.
+ * Use `create(ListAuthEventsResponseSchema)` to create a new message.
+ */
+export const ListAuthEventsResponseSchema: GenMessage<ListAuthEventsResponse> = /*@__PURE__*/
+  messageDesc(file_auth_auth_service, 33);
+
+/**
+ * @generated from message auth.ImpersonateRequest
+ */
+export type ImpersonateRequest = Message<"auth.ImpersonateRequest"> & {
+  /**
+   * @generated from field: string user_id = 1;
+   */
+  userId: string;
+};
+
+/**
+ * Describes the message auth.ImpersonateRequest.
+ * Use `create(ImpersonateRequestSchema)` to create a new message.
+ */
+export const ImpersonateRequestSchema: GenMessage<ImpersonateRequest> = /*@__PURE__*/
+  messageDesc(file_auth_auth_service, 34);
+
+/**
+ * @generated from message auth.ImpersonateResponse
+ */
+export type ImpersonateResponse = Message<"auth.ImpersonateResponse"> & {
+  /**
+   * @generated from field: string access_token = 1;
+   */
+  accessToken: string;
+
+  /**
+   * @generated from field: google.protobuf.Timestamp expires_at = 2;
+   */
+  expiresAt?: Timestamp;
+};
+
+/**
+ * Describes the message auth.ImpersonateResponse.
+ * Use `create(ImpersonateResponseSchema)` to create a new message.
+ */
+export const ImpersonateResponseSchema: GenMessage<ImpersonateResponse> = /*@__PURE__*/
+  messageDesc(file_auth_auth_service, 35);
+
+/**
+ * @generated from message auth.RequestMagicLinkRequest
+ */
+export type RequestMagicLinkRequest = Message<"auth.RequestMagicLinkRequest"> & {
+  /**
+   * @generated from field: string email = 1;
+   */
+  email: string;
+};
+
+/**
+ * Describes the message auth.RequestMagicLinkRequest.
+ * Use `create(RequestMagicLinkRequestSchema)` to create a new message.
+ */
+export const RequestMagicLinkRequestSchema: GenMessage<RequestMagicLinkRequest> = /*@__PURE__*/
+  messageDesc(file_auth_auth_service, 36);
+
+/**
+ * @generated from message auth.RequestMagicLinkResponse
+ */
+export type RequestMagicLinkResponse = Message<"auth.RequestMagicLinkResponse"> & {
+};
+
+/**
+ * Describes the message auth.RequestMagicLinkResponse.
+ * Use `create(RequestMagicLinkResponseSchema)` to create a new message.
+ */
+export const RequestMagicLinkResponseSchema: GenMessage<RequestMagicLinkResponse> = /*@__PURE__*/
+  messageDesc(file_auth_auth_service, 37);
+
+/**
+ * @generated from message auth.ConsumeMagicLinkRequest
+ */
+export type ConsumeMagicLinkRequest = Message<"auth.ConsumeMagicLinkRequest"> & {
+  /**
+   * @generated from field: string token = 1;
+   */
+  token: string;
+};
+
+/**
+ * Describes the message auth.ConsumeMagicLinkRequest.
+ * Use `create(ConsumeMagicLinkRequestSchema)` to create a new message.
+ */
+export const ConsumeMagicLinkRequestSchema: GenMessage<ConsumeMagicLinkRequest> = /*@__PURE__*/
+  messageDesc(file_auth_auth_service, 38);
+
 /**
  * @generated from service auth.AuthService
  */
@@ -209,6 +833,173 @@ export const AuthService: GenService<{
     input: typeof RefreshTokenRequestSchema;
     output: typeof RefreshTokenResponseSchema;
   },
+  /**
+   * SendVerificationEmail emails the authenticated user a link to confirm their address.
+   *
+   * @generated from rpc auth.AuthService.SendVerificationEmail
+   */
+  sendVerificationEmail: {
+    methodKind: "unary";
+    input: typeof SendVerificationEmailRequestSchema;
+    output: typeof SendVerificationEmailResponseSchema;
+  },
+  /**
+   * VerifyEmail consumes the token from a verification link.
+   *
+   * @generated from rpc auth.AuthService.VerifyEmail
+   */
+  verifyEmail: {
+    methodKind: "unary";
+    input: typeof VerifyEmailRequestSchema;
+    output: typeof VerifyEmailResponseSchema;
+  },
+  /**
+   * RequestPasswordReset emails a reset link if the address is registered.
+   * The response is the same either way.
+   *
+   * @generated from rpc auth.AuthService.RequestPasswordReset
+   */
+  requestPasswordReset: {
+    methodKind: "unary";
+    input: typeof RequestPasswordResetRequestSchema;
+    output: typeof RequestPasswordResetResponseSchema;
+  },
+  /**
+   * ResetPassword sets a new password and signs the user out everywhere.
+   *
+   * @generated from rpc auth.AuthService.ResetPassword
+   */
+  resetPassword: {
+    methodKind: "unary";
+    input: typeof ResetPasswordRequestSchema;
+    output: typeof ResetPasswordResponseSchema;
+  },
+  /**
+   * ChangePassword replaces the authenticated user's password after
+   * re-checking the current one.
+   *
+   * @generated from rpc auth.AuthService.ChangePassword
+   */
+  changePassword: {
+    methodKind: "unary";
+    input: typeof ChangePasswordRequestSchema;
+    output: typeof ChangePasswordResponseSchema;
+  },
+  /**
+   * EnrollTOTP starts setting up an authenticator app for the authenticated user.
+   *
+   * @generated from rpc auth.AuthService.EnrollTOTP
+   */
+  enrollTOTP: {
+    methodKind: "unary";
+    input: typeof EnrollTOTPRequestSchema;
+    output: typeof EnrollTOTPResponseSchema;
+  },
+  /**
+   * ConfirmTOTP enables MFA once the app produces a valid code.
+   *
+   * @generated from rpc auth.AuthService.ConfirmTOTP
+   */
+  confirmTOTP: {
+    methodKind: "unary";
+    input: typeof ConfirmTOTPRequestSchema;
+    output: typeof ConfirmTOTPResponseSchema;
+  },
+  /**
+   * DisableTOTP turns MFA off after checking a TOTP or recovery code.
+   *
+   * @generated from rpc auth.AuthService.DisableTOTP
+   */
+  disableTOTP: {
+    methodKind: "unary";
+    input: typeof DisableTOTPRequestSchema;
+    output: typeof DisableTOTPResponseSchema;
+  },
+  /**
+   * VerifyMFA exchanges the challenge token returned by Login for tokens.
+   *
+   * @generated from rpc auth.AuthService.VerifyMFA
+   */
+  verifyMFA: {
+    methodKind: "unary";
+    input: typeof VerifyMFARequestSchema;
+    output: typeof VerifyMFAResponseSchema;
+  },
+  /**
+   * ListSessions returns the devices the authenticated user is signed in on.
+   *
+   * @generated from rpc auth.AuthService.ListSessions
+   */
+  listSessions: {
+    methodKind: "unary";
+    input: typeof ListSessionsRequestSchema;
+    output: typeof ListSessionsResponseSchema;
+  },
+  /**
+   * RevokeSession signs one of the authenticated user's sessions out.
+   *
+   * @generated from rpc auth.AuthService.RevokeSession
+   */
+  revokeSession: {
+    methodKind: "unary";
+    input: typeof RevokeSessionRequestSchema;
+    output: typeof RevokeSessionResponseSchema;
+  },
+  /**
+   * RevokeAllOtherSessions signs out every session but the caller's, which
+   * continues with the returned tokens.
+   *
+   * @generated from rpc auth.AuthService.RevokeAllOtherSessions
+   */
+  revokeAllOtherSessions: {
+    methodKind: "unary";
+    input: typeof RevokeAllOtherSessionsRequestSchema;
+    output: typeof RevokeAllOtherSessionsResponseSchema;
+  },
+  /**
+   * ListAuthEvents searches the authentication audit log, newest first.
+   *
+   * @generated from rpc auth.AuthService.ListAuthEvents
+   */
+  listAuthEvents: {
+    methodKind: "unary";
+    input: typeof ListAuthEventsRequestSchema;
+    output: typeof ListAuthEventsResponseSchema;
+  },
+  /**
+   * Impersonate issues a short-lived access token that acts as another
+   * user, for reproducing their issues. It can't be refreshed and carries
+   * the admin's ID in its act claim.
+   *
+   * @generated from rpc auth.AuthService.Impersonate
+   */
+  impersonate: {
+    methodKind: "unary";
+    input: typeof ImpersonateRequestSchema;
+    output: typeof ImpersonateResponseSchema;
+  },
+  /**
+   * RequestMagicLink emails a single-use sign-in link. The response is the
+   * same whether or not the address is registered.
+   *
+   * @generated from rpc auth.AuthService.RequestMagicLink
+   */
+  requestMagicLink: {
+    methodKind: "unary";
+    input: typeof RequestMagicLinkRequestSchema;
+    output: typeof RequestMagicLinkResponseSchema;
+  },
+  /**
+   * ConsumeMagicLink signs in with the token from a magic link, creating
+   * the account on first use if the server allows it.
+   *
+   * @generated from rpc auth.AuthService.ConsumeMagicLink
+   */
+  consumeMagicLink: {
+    methodKind: "unary";
+    input: typeof ConsumeMagicLinkRequestSchema;
+    output: typeof LoginResponseSchema;
+  },
 }> = /*@__PURE__*/
   serviceDesc(file_auth_auth_service, 0);
 
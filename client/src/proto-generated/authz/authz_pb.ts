@@ -0,0 +1,105 @@
+// @generated by protoc-gen-es v2.10.1 with parameter "target=ts,import_extension=none"
+// @generated from file authz/authz.proto (package authz, syntax proto3)
+/* eslint-disable */
+
+import type { GenEnum, GenExtension, GenFile, GenMessage } from "@bufbuild/protobuf/codegenv2";
+import { enumDesc, extDesc, fileDesc, messageDesc } from "@bufbuild/protobuf/codegenv2";
+import type { MethodOptions } from "@bufbuild/protobuf/wkt";
+import { file_google_protobuf_descriptor } from "@bufbuild/protobuf/wkt";
+import type { Message } from "@bufbuild/protobuf";
+
+/**
+ * Describes the file authz/authz.proto.
+ */
+export const file_authz_authz: GenFile = /*@__PURE__*/
+  fileDesc("ChFhdXRoei9hdXRoei5wcm90bxIFYXV0aHoieQoIQXV0aFJ1bGUSHQoGYWNjZXNzGAEgASgOMg0uYXV0aHouQWNjZXNzEg4KBnNjb3BlcxgCIAMoCRINCgVyb2xlcxgDIAMoCRIaChJkZW55X2ltcGVyc29uYXRpb24YBCABKAgSEwoLcGVybWlzc2lvbnMYBSADKAkqTQoGQWNjZXNzEhYKEkFDQ0VTU19VTlNQRUNJRklFRBAAEhgKFEFDQ0VTU19BVVRIRU5USUNBVEVEEAESEQoNQUNDRVNTX1BVQkxJQxACOj8KBHJ1bGUSHi5nb29nbGUucHJvdG9idWYuTWV0aG9kT3B0aW9ucxjQhgMgASgLMg8uYXV0aHouQXV0aFJ1bGVCbgoJY29tLmF1dGh6QgpBdXRoelByb3RvUAFaIWdycGMtc2VydmVyL3Byb3RvLWdlbmVyYXRlZC9hdXRoeqICA0FYWKoCBUF1dGh6ygIFQXV0aHriAhFBdXRoelxHUEJNZXRhZGF0YeoCBUF1dGh6YgZwcm90bzM", [file_google_protobuf_descriptor]);
+
+/**
+ * AuthRule declares the credentials an RPC requires. It is enforced by the
+ * server's auth interceptor before the handler runs.
+ *
+ * @generated from message authz.AuthRule
+ */
+export type AuthRule = Message<"authz.AuthRule"> & {
+  /**
+   * @generated from field: authz.Access access = 1;
+   */
+  access: Access;
+
+  /**
+   * API keys may only call RPCs listing one of the key's scopes. RPCs
+   * without scopes can't be called with an API key.
+   *
+   * @generated from field: repeated string scopes = 2;
+   */
+  scopes: string[];
+
+  /**
+   * If set, the caller must hold one of these roles.
+   *
+   * @generated from field: repeated string roles = 3;
+   */
+  roles: string[];
+
+  /**
+   * Impersonation tokens can't call the RPC. Set it on RPCs that change
+   * credentials or hand out new ones.
+   *
+   * @generated from field: bool deny_impersonation = 4;
+   */
+  denyImpersonation: boolean;
+
+  /**
+   * If set, the caller must hold one of these permissions, through their
+   * role or granted to them directly.
+   *
+   * @generated from field: repeated string permissions = 5;
+   */
+  permissions: string[];
+};
+
+/**
+ * Describes the message authz.AuthRule.
+ * Use `create(AuthRuleSchema)` to create a new message.
+ */
+export const AuthRuleSchema: GenMessage<AuthRule> = /*@__PURE__*/
+  messageDesc(file_authz_authz, 0);
+
+/**
+ * Access says who may call an RPC at all.
+ *
+ * @generated from enum authz.Access
+ */
+export enum Access {
+  /**
+   * Unannotated RPCs require an authenticated caller.
+   *
+   * @generated from enum value: ACCESS_UNSPECIFIED = 0;
+   */
+  UNSPECIFIED = 0,
+
+  /**
+   * @generated from enum value: ACCESS_AUTHENTICATED = 1;
+   */
+  AUTHENTICATED = 1,
+
+  /**
+   * Anyone may call the RPC, with or without credentials.
+   *
+   * @generated from enum value: ACCESS_PUBLIC = 2;
+   */
+  PUBLIC = 2,
+}
+
+/**
+ * Describes the enum authz.Access.
+ */
+export const AccessSchema: GenEnum<Access> = /*@__PURE__*/
+  enumDesc(file_authz_authz, 0);
+
+/**
+ * @generated from extension: authz.AuthRule rule = 50000;
+ */
+export const rule: GenExtension<MethodOptions, AuthRule> = /*@__PURE__*/
+  extDesc(file_authz_authz, 0);
+
@@ -8,13 +8,14 @@ import type { Timestamp } from "@bufbuild/protobuf/wkt";
 import { file_google_protobuf_timestamp } from "@bufbuild/protobuf/wkt";
 import type { Item, ItemStatus } from "./item_pb";
 import { file_item_item } from "./item_pb";
+import { file_authz_authz } from "../authz/authz_pb";
 import type { Message } from "@bufbuild/protobuf";
 
 /**
  * Describes the file item/item_service.proto.
  */
 export const file_item_item_service: GenFile = /*@__PURE__*/
-  fileDesc("ChdpdGVtL2l0ZW1fc2VydmljZS5wcm90bxIEaXRlbSKDAQoKSXRlbUZpbHRlchIRCgRuYW1lGAEgASgJSACIAQESGAoLZGVzY3JpcHRpb24YAiABKAlIAYgBARIiCghzdGF0dXNlcxgDIAMoDjIQLml0ZW0uSXRlbVN0YXR1cxILCgNpZHMYBCADKAlCBwoFX25hbWVCDgoMX2Rlc2NyaXB0aW9uIvQBChFDcmVhdGVJdGVtUmVxdWVzdBIMCgRuYW1lGAEgASgJEhMKC2Rlc2NyaXB0aW9uGAIgASgJEiAKBnN0YXR1cxgDIAEoDjIQLml0ZW0uSXRlbVN0YXR1cxI2Cg1jcmVhdGVkX2FmdGVyGAUgASgLMhouZ29vZ2xlLnByb3RvYnVmLlRpbWVzdGFtcEgAiAEBEjcKDmNyZWF0ZWRfYmVmb3JlGAYgASgLMhouZ29vZ2xlLnByb3RvYnVmLlRpbWVzdGFtcEgBiAEBQhAKDl9jcmVhdGVkX2FmdGVyQhEKD19jcmVhdGVkX2JlZm9yZUoECAcQFSIuChJDcmVhdGVJdGVtUmVzcG9uc2USGAoEaXRlbRgBIAEoCzIKLml0ZW0uSXRlbSIcCg5HZXRJdGVtUmVxdWVzdBIKCgJpZBgBIAEoCSIrCg9HZXRJdGVtUmVzcG9uc2USGAoEaXRlbRgBIAEoCzIKLml0ZW0uSXRlbSJcChBMaXN0SXRlbXNSZXF1ZXN0EhEKCXBhZ2Vfc2l6ZRgBIAEoBRISCgpwYWdlX3Rva2VuGAIgASgJEiEKB2ZpbHRlcnMYAyADKAsyEC5pdGVtLkl0ZW1GaWx0ZXIiXAoRTGlzdEl0ZW1zUmVzcG9uc2USGQoFaXRlbXMYASADKAsyCi5pdGVtLkl0ZW0SFwoPbmV4dF9wYWdlX3Rva2VuGAIgASgJEhMKC3RvdGFsX2NvdW50GAMgASgFIpcBChFVcGRhdGVJdGVtUmVxdWVzdBIKCgJpZBgBIAEoCRIRCgRuYW1lGAIgASgJSACIAQESGAoLZGVzY3JpcHRpb24YAyABKAlIAYgBARIlCgZzdGF0dXMYBCABKA4yEC5pdGVtLkl0ZW1TdGF0dXNIAogBAUIHCgVfbmFtZUIOCgxfZGVzY3JpcHRpb25CCQoHX3N0YXR1cyIuChJVcGRhdGVJdGVtUmVzcG9uc2USGAoEaXRlbRgBIAEoCzIKLml0ZW0uSXRlbSIfChFEZWxldGVJdGVtUmVxdWVzdBIKCgJpZBgBIAEoCSIUChJEZWxldGVJdGVtUmVzcG9uc2UiEwoRV2F0Y2hJdGVtc1JlcXVlc3QiQgoSV2F0Y2hJdGVtc1Jlc3BvbnNlEhgKBGl0ZW0YASABKAsyCi5pdGVtLkl0ZW0SEgoKZXZlbnRfdHlwZRgCIAEoCTKVAwoLSXRlbVNlcnZpY2USQQoKQ3JlYXRlSXRlbRIXLml0ZW0uQ3JlYXRlSXRlbVJlcXVlc3QaGC5pdGVtLkNyZWF0ZUl0ZW1SZXNwb25zZSIAEjgKB0dldEl0ZW0SFC5pdGVtLkdldEl0ZW1SZXF1ZXN0GhUuaXRlbS5HZXRJdGVtUmVzcG9uc2UiABI+CglMaXN0SXRlbXMSFi5pdGVtLkxpc3RJdGVtc1JlcXVlc3QaFy5pdGVtLkxpc3RJdGVtc1Jlc3BvbnNlIgASQQoKVXBkYXRlSXRlbRIXLml0ZW0uVXBkYXRlSXRlbVJlcXVlc3QaGC5pdGVtLlVwZGF0ZUl0ZW1SZXNwb25zZSIAEkEKCkRlbGV0ZUl0ZW0SFy5pdGVtLkRlbGV0ZUl0ZW1SZXF1ZXN0GhguaXRlbS5EZWxldGVJdGVtUmVzcG9uc2UiABJDCgpXYXRjaEl0ZW1zEhcuaXRlbS5XYXRjaEl0ZW1zUmVxdWVzdBoYLml0ZW0uV2F0Y2hJdGVtc1Jlc3BvbnNlIgAwAUJuCghjb20uaXRlbUIQSXRlbVNlcnZpY2VQcm90b1ABWiBncnBjLXNlcnZlci9wcm90by1nZW5lcmF0ZWQvaXRlbaICA0lYWKoCBEl0ZW3KAgRJdGVt4gIQSXRlbVxHUEJNZXRhZGF0YeoCBEl0ZW1iBnByb3RvMw", [file_google_protobuf_timestamp, file_item_item]);
+  fileDesc("ChdpdGVtL2l0ZW1fc2VydmljZS5wcm90bxIEaXRlbSKDAQoKSXRlbUZpbHRlchIRCgRuYW1lGAEgASgJSACIAQESGAoLZGVzY3JpcHRpb24YAiABKAlIAYgBARIiCghzdGF0dXNlcxgDIAMoDjIQLml0ZW0uSXRlbVN0YXR1cxILCgNpZHMYBCADKAlCBwoFX25hbWVCDgoMX2Rlc2NyaXB0aW9uIvQBChFDcmVhdGVJdGVtUmVxdWVzdBIMCgRuYW1lGAEgASgJEhMKC2Rlc2NyaXB0aW9uGAIgASgJEiAKBnN0YXR1cxgDIAEoDjIQLml0ZW0uSXRlbVN0YXR1cxI2Cg1jcmVhdGVkX2FmdGVyGAUgASgLMhouZ29vZ2xlLnByb3RvYnVmLlRpbWVzdGFtcEgAiAEBEjcKDmNyZWF0ZWRfYmVmb3JlGAYgASgLMhouZ29vZ2xlLnByb3RvYnVmLlRpbWVzdGFtcEgBiAEBQhAKDl9jcmVhdGVkX2FmdGVyQhEKD19jcmVhdGVkX2JlZm9yZUoECAcQFSIuChJDcmVhdGVJdGVtUmVzcG9uc2USGAoEaXRlbRgBIAEoCzIKLml0ZW0uSXRlbSIcCg5HZXRJdGVtUmVxdWVzdBIKCgJpZBgBIAEoCSIrCg9HZXRJdGVtUmVzcG9uc2USGAoEaXRlbRgBIAEoCzIKLml0ZW0uSXRlbSJcChBMaXN0SXRlbXNSZXF1ZXN0EhEKCXBhZ2Vfc2l6ZRgBIAEoBRISCgpwYWdlX3Rva2VuGAIgASgJEiEKB2ZpbHRlcnMYAyADKAsyEC5pdGVtLkl0ZW1GaWx0ZXIiXAoRTGlzdEl0ZW1zUmVzcG9uc2USGQoFaXRlbXMYASADKAsyCi5pdGVtLkl0ZW0SFwoPbmV4dF9wYWdlX3Rva2VuGAIgASgJEhMKC3RvdGFsX2NvdW50GAMgASgFIpcBChFVcGRhdGVJdGVtUmVxdWVzdBIKCgJpZBgBIAEoCRIRCgRuYW1lGAIgASgJSACIAQESGAoLZGVzY3JpcHRpb24YAyABKAlIAYgBARIlCgZzdGF0dXMYBCABKA4yEC5pdGVtLkl0ZW1TdGF0dXNIAogBAUIHCgVfbmFtZUIOCgxfZGVzY3JpcHRpb25CCQoHX3N0YXR1cyIuChJVcGRhdGVJdGVtUmVzcG9uc2USGAoEaXRlbRgBIAEoCzIKLml0ZW0uSXRlbSIfChFEZWxldGVJdGVtUmVxdWVzdBIKCgJpZBgBIAEoCSIUChJEZWxldGVJdGVtUmVzcG9uc2UiEwoRV2F0Y2hJdGVtc1JlcXVlc3QiQgoSV2F0Y2hJdGVtc1Jlc3BvbnNlEhgKBGl0ZW0YASABKAsyCi5pdGVtLkl0ZW0SEgoKZXZlbnRfdHlwZRgCIAEoCTL4AwoLSXRlbVNlcnZpY2USUgoKQ3JlYXRlSXRlbRIXLml0ZW0uQ3JlYXRlSXRlbVJlcXVlc3QaGC5pdGVtLkNyZWF0ZUl0ZW1SZXNwb25zZSIRgrUYDRILaXRlbXM6d3JpdGUSSAoHR2V0SXRlbRIULml0ZW0uR2V0SXRlbVJlcXVlc3QaFS5pdGVtLkdldEl0ZW1SZXNwb25zZSIQgrUYDBIKaXRlbXM6cmVhZBJOCglMaXN0SXRlbXMSFi5pdGVtLkxpc3RJdGVtc1JlcXVlc3QaFy5pdGVtLkxpc3RJdGVtc1Jlc3BvbnNlIhCCtRgMEgppdGVtczpyZWFkElIKClVwZGF0ZUl0ZW0SFy5pdGVtLlVwZGF0ZUl0ZW1SZXF1ZXN0GhguaXRlbS5VcGRhdGVJdGVtUmVzcG9uc2UiEYK1GA0SC2l0ZW1zOndyaXRlElIKCkRlbGV0ZUl0ZW0SFy5pdGVtLkRlbGV0ZUl0ZW1SZXF1ZXN0GhguaXRlbS5EZWxldGVJdGVtUmVzcG9uc2UiEYK1GA0SC2l0ZW1zOndyaXRlElMKCldhdGNoSXRlbXMSFy5pdGVtLldhdGNoSXRlbXNSZXF1ZXN0GhguaXRlbS5XYXRjaEl0ZW1zUmVzcG9uc2UiEIK1GAwSCml0ZW1zOnJlYWQwAUJuCghjb20uaXRlbUIQSXRlbVNlcnZpY2VQcm90b1ABWiBncnBjLXNlcnZlci9wcm90by1nZW5lcmF0ZWQvaXRlbaICA0lYWKoCBEl0ZW3KAgRJdGVt4gIQSXRlbVxHUEJNZXRhZGF0YeoCBEl0ZW1iBnByb3RvMw", [file_google_protobuf_timestamp, file_item_item, file_authz_authz]);
 
 /**
  * @generated from message item.ItemFilter
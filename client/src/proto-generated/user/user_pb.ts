@@ -2,8 +2,8 @@
 // @generated from file user/user.proto (package user, syntax proto3)
 /* eslint-disable */
 
-import type { GenFile, GenMessage } from "@bufbuild/protobuf/codegenv2";
-import { fileDesc, messageDesc } from "@bufbuild/protobuf/codegenv2";
+import type { GenEnum, GenFile, GenMessage } from "@bufbuild/protobuf/codegenv2";
+import { enumDesc, fileDesc, messageDesc } from "@bufbuild/protobuf/codegenv2";
 import type { Timestamp } from "@bufbuild/protobuf/wkt";
 import { file_google_protobuf_timestamp } from "@bufbuild/protobuf/wkt";
 import type { Message } from "@bufbuild/protobuf";
@@ -12,7 +12,7 @@ import type { Message } from "@bufbuild/protobuf";
  * Describes the file user/user.proto.
  */
 export const file_user_user: GenFile = /*@__PURE__*/
-  fileDesc("Cg91c2VyL3VzZXIucHJvdG8SBHVzZXIijwEKBFVzZXISCgoCaWQYASABKAkSDQoFZW1haWwYAiABKAkSDAoEbmFtZRgDIAEoCRIuCgpjcmVhdGVkX2F0GAQgASgLMhouZ29vZ2xlLnByb3RvYnVmLlRpbWVzdGFtcBIuCgp1cGRhdGVkX2F0GAUgASgLMhouZ29vZ2xlLnByb3RvYnVmLlRpbWVzdGFtcEJnCghjb20udXNlckIJVXNlclByb3RvUAFaIGdycGMtc2VydmVyL3Byb3RvLWdlbmVyYXRlZC91c2VyogIDVVhYqgIEVXNlcsoCBFVzZXLiAhBVc2VyXEdQQk1ldGFkYXRh6gIEVXNlcmIGcHJvdG8z", [file_google_protobuf_timestamp]);
+  fileDesc("Cg91c2VyL3VzZXIucHJvdG8SBHVzZXIizgIKBFVzZXISCgoCaWQYASABKAkSDQoFZW1haWwYAiABKAkSDAoEbmFtZRgDIAEoCRIuCgpjcmVhdGVkX2F0GAQgASgLMhouZ29vZ2xlLnByb3RvYnVmLlRpbWVzdGFtcBIuCgp1cGRhdGVkX2F0GAUgASgLMhouZ29vZ2xlLnByb3RvYnVmLlRpbWVzdGFtcBI1ChFlbWFpbF92ZXJpZmllZF9hdBgGIAEoCzIaLmdvb2dsZS5wcm90b2J1Zi5UaW1lc3RhbXASGAoEcm9sZRgHIAEoDjIKLnVzZXIuUm9sZRIUCgxkaXNwbGF5X25hbWUYCCABKAkSCwoDYmlvGAkgASgJEg4KBmxvY2FsZRgKIAEoCRIQCgh0aW1lem9uZRgLIAEoCRISCgphdmF0YXJfdXJsGAwgASgJEhMKC3Blcm1pc3Npb25zGA0gAygJKjsKBFJvbGUSFAoQUk9MRV9VTlNQRUNJRklFRBAAEg0KCVJPTEVfVVNFUhABEg4KClJPTEVfQURNSU4QAkJnCghjb20udXNlckIJVXNlclByb3RvUAFaIGdycGMtc2VydmVyL3Byb3RvLWdlbmVyYXRlZC91c2VyogIDVVhYqgIEVXNlcsoCBFVzZXLiAhBVc2VyXEdQQk1ldGFkYXRh6gIEVXNlcmIGcHJvdG8z", [file_google_protobuf_timestamp]);
 
 /**
  * @generated from message user.User
@@ -42,6 +42,58 @@ export type User = Message<"user.User"> & {
    * @generated from field: google.protobuf.Timestamp updated_at = 5;
    */
   updatedAt?: Timestamp;
+
+  /**
+   * Unset until the user has confirmed their email address.
+   *
+   * @generated from field: google.protobuf.Timestamp email_verified_at = 6;
+   */
+  emailVerifiedAt?: Timestamp;
+
+  /**
+   * @generated from field: user.Role role = 7;
+   */
+  role: Role;
+
+  /**
+   * Shown instead of name where set.
+   *
+   * @generated from field: string display_name = 8;
+   */
+  displayName: string;
+
+  /**
+   * @generated from field: string bio = 9;
+   */
+  bio: string;
+
+  /**
+   * BCP 47 language tag, e.g. "ko-KR".
+   *
+   * @generated from field: string locale = 10;
+   */
+  locale: string;
+
+  /**
+   * IANA time zone name, e.g. "Asia/Seoul".
+   *
+   * @generated from field: string timezone = 11;
+   */
+  timezone: string;
+
+  /**
+   * HTTPS URL of the user's picture.
+   *
+   * @generated from field: string avatar_url = 12;
+   */
+  avatarUrl: string;
+
+  /**
+   * Permissions granted on top of those of the role, e.g. "users:manage".
+   *
+   * @generated from field: repeated string permissions = 13;
+   */
+  permissions: string[];
 };
 
 /**
@@ -51,3 +103,31 @@ export type User = Message<"user.User"> & {
 export const UserSchema: GenMessage<User> = /*@__PURE__*/
   messageDesc(file_user_user, 0);
 
+/**
+ * @generated from enum user.Role
+ */
+export enum Role {
+  /**
+   * @generated from enum value: ROLE_UNSPECIFIED = 0;
+   */
+  UNSPECIFIED = 0,
+
+  /**
+   * @generated from enum value: ROLE_USER = 1;
+   */
+  USER = 1,
+
+  /**
+   * Admins can manage every item and every user.
+   *
+   * @generated from enum value: ROLE_ADMIN = 2;
+   */
+  ADMIN = 2,
+}
+
+/**
+ * Describes the enum user.Role.
+ */
+export const RoleSchema: GenEnum<Role> = /*@__PURE__*/
+  enumDesc(file_user_user, 0);
+
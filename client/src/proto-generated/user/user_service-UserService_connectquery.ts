@@ -5,16 +5,53 @@
 import { UserService } from "./user_service_pb";
 
 /**
+ * GetMe returns the user the request's token belongs to.
+ *
+ * @generated from rpc user.UserService.GetMe
+ */
+export const getMe = UserService.method.getMe;
+
+/**
+ * GetUser returns a user. Users may only read themselves; admins may
+ * read anyone.
+ *
  * @generated from rpc user.UserService.GetUser
  */
 export const getUser = UserService.method.getUser;
 
 /**
+ * UpdateUser changes the fields that are set; an empty string clears a
+ * profile field. Users may only update themselves; admins may update
+ * anyone. A new email address has to be verified again.
+ *
  * @generated from rpc user.UserService.UpdateUser
  */
 export const updateUser = UserService.method.updateUser;
 
 /**
+ * DeleteUser deletes a user together with their items, sessions, API
+ * keys and linked identities. Users may delete themselves; admins may
+ * delete anyone but themselves.
+ *
  * @generated from rpc user.UserService.DeleteUser
  */
 export const deleteUser = UserService.method.deleteUser;
+
+/**
+ * SetUserRole changes a user's role. Only callers allowed to manage
+ * users may call it, and not on themselves. The user is signed out so new
+ * tokens carry the new role.
+ *
+ * @generated from rpc user.UserService.SetUserRole
+ */
+export const setUserRole = UserService.method.setUserRole;
+
+/**
+ * SetUserPermissions replaces the permissions a user holds on top of
+ * their role. Only callers allowed to manage users may call it, and not
+ * on themselves. The user is signed out so new tokens carry the new
+ * permissions.
+ *
+ * @generated from rpc user.UserService.SetUserPermissions
+ */
+export const setUserPermissions = UserService.method.setUserPermissions;
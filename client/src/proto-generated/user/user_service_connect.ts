@@ -3,7 +3,7 @@
 /* eslint-disable */
 // @ts-nocheck
 
-import { DeleteUserRequest, DeleteUserResponse, GetUserRequest, GetUserResponse, UpdateUserRequest, UpdateUserResponse } from "./user_service_pb";
+import { DeleteUserRequest, DeleteUserResponse, GetMeRequest, GetMeResponse, GetUserRequest, GetUserResponse, SetUserPermissionsRequest, SetUserPermissionsResponse, SetUserRoleRequest, SetUserRoleResponse, UpdateUserRequest, UpdateUserResponse } from "./user_service_pb";
 import { MethodKind } from "@bufbuild/protobuf";
 
 /**
@@ -13,6 +13,20 @@ export const UserService = {
   typeName: "user.UserService",
   methods: {
     /**
+     * GetMe returns the user the request's token belongs to.
+     *
+     * @generated from rpc user.UserService.GetMe
+     */
+    getMe: {
+      name: "GetMe",
+      I: GetMeRequest,
+      O: GetMeResponse,
+      kind: MethodKind.Unary,
+    },
+    /**
+     * GetUser returns a user. Users may only read themselves; admins may
+     * read anyone.
+     *
      * @generated from rpc user.UserService.GetUser
      */
     getUser: {
@@ -22,6 +36,10 @@ export const UserService = {
       kind: MethodKind.Unary,
     },
     /**
+     * UpdateUser changes the fields that are set; an empty string clears a
+     * profile field. Users may only update themselves; admins may update
+     * anyone. A new email address has to be verified again.
+     *
      * @generated from rpc user.UserService.UpdateUser
      */
     updateUser: {
@@ -31,6 +49,10 @@ export const UserService = {
       kind: MethodKind.Unary,
     },
     /**
+     * DeleteUser deletes a user together with their items, sessions, API
+     * keys and linked identities. Users may delete themselves; admins may
+     * delete anyone but themselves.
+     *
      * @generated from rpc user.UserService.DeleteUser
      */
     deleteUser: {
@@ -39,6 +61,33 @@ export const UserService = {
       O: DeleteUserResponse,
       kind: MethodKind.Unary,
     },
+    /**
+     * SetUserRole changes a user's role. Only callers allowed to manage
+     * users may call it, and not on themselves. The user is signed out so new
+     * tokens carry the new role.
+     *
+     * @generated from rpc user.UserService.SetUserRole
+     */
+    setUserRole: {
+      name: "SetUserRole",
+      I: SetUserRoleRequest,
+      O: SetUserRoleResponse,
+      kind: MethodKind.Unary,
+    },
+    /**
+     * SetUserPermissions replaces the permissions a user holds on top of
+     * their role. Only callers allowed to manage users may call it, and not
+     * on themselves. The user is signed out so new tokens carry the new
+     * permissions.
+     *
+     * @generated from rpc user.UserService.SetUserPermissions
+     */
+    setUserPermissions: {
+      name: "SetUserPermissions",
+      I: SetUserPermissionsRequest,
+      O: SetUserPermissionsResponse,
+      kind: MethodKind.Unary,
+    },
   }
 } as const;
 
@@ -4,15 +4,46 @@
 
 import type { GenFile, GenMessage, GenService } from "@bufbuild/protobuf/codegenv2";
 import { fileDesc, messageDesc, serviceDesc } from "@bufbuild/protobuf/codegenv2";
-import type { User } from "./user_pb";
+import type { Role, User } from "./user_pb";
 import { file_user_user } from "./user_pb";
+import { file_authz_authz } from "../authz/authz_pb";
 import type { Message } from "@bufbuild/protobuf";
 
 /**
  * Describes the file user/user_service.proto.
  */
 export const file_user_user_service: GenFile = /*@__PURE__*/
-  fileDesc("Chd1c2VyL3VzZXJfc2VydmljZS5wcm90bxIEdXNlciIcCg5HZXRVc2VyUmVxdWVzdBIKCgJpZBgBIAEoCSIrCg9HZXRVc2VyUmVzcG9uc2USGAoEdXNlchgBIAEoCzIKLnVzZXIuVXNlciJZChFVcGRhdGVVc2VyUmVxdWVzdBIKCgJpZBgBIAEoCRIRCgRuYW1lGAIgASgJSACIAQESEgoFZW1haWwYAyABKAlIAYgBAUIHCgVfbmFtZUIICgZfZW1haWwiLgoSVXBkYXRlVXNlclJlc3BvbnNlEhgKBHVzZXIYASABKAsyCi51c2VyLlVzZXIiHwoRRGVsZXRlVXNlclJlcXVlc3QSCgoCaWQYASABKAkiFAoSRGVsZXRlVXNlclJlc3BvbnNlMs0BCgtVc2VyU2VydmljZRI4CgdHZXRVc2VyEhQudXNlci5HZXRVc2VyUmVxdWVzdBoVLnVzZXIuR2V0VXNlclJlc3BvbnNlIgASQQoKVXBkYXRlVXNlchIXLnVzZXIuVXBkYXRlVXNlclJlcXVlc3QaGC51c2VyLlVwZGF0ZVVzZXJSZXNwb25zZSIAEkEKCkRlbGV0ZVVzZXISFy51c2VyLkRlbGV0ZVVzZXJSZXF1ZXN0GhgudXNlci5EZWxldGVVc2VyUmVzcG9uc2UiAEJuCghjb20udXNlckIQVXNlclNlcnZpY2VQcm90b1ABWiBncnBjLXNlcnZlci9wcm90by1nZW5lcmF0ZWQvdXNlcqICA1VYWKoCBFVzZXLKAgRVc2Vy4gIQVXNlclxHUEJNZXRhZGF0YeoCBFVzZXJiBnByb3RvMw", [file_user_user]);
+  fileDesc("Chd1c2VyL3VzZXJfc2VydmljZS5wcm90bxIEdXNlciIOCgxHZXRNZVJlcXVlc3QiKQoNR2V0TWVSZXNwb25zZRIYCgR1c2VyGAEgASgLMgoudXNlci5Vc2VyIhwKDkdldFVzZXJSZXF1ZXN0EgoKAmlkGAEgASgJIisKD0dldFVzZXJSZXNwb25zZRIYCgR1c2VyGAEgASgLMgoudXNlci5Vc2VyIqUCChFVcGRhdGVVc2VyUmVxdWVzdBIKCgJpZBgBIAEoCRIRCgRuYW1lGAIgASgJSACIAQESEgoFZW1haWwYAyABKAlIAYgBARIZCgxkaXNwbGF5X25hbWUYBCABKAlIAogBARIQCgNiaW8YBSABKAlIA4gBARITCgZsb2NhbGUYBiABKAlIBIgBARIVCgh0aW1lem9uZRgHIAEoCUgFiAEBEhcKCmF2YXRhcl91cmwYCCABKAlIBogBARIYChBjdXJyZW50X3Bhc3N3b3JkGAkgASgJQgcKBV9uYW1lQggKBl9lbWFpbEIPCg1fZGlzcGxheV9uYW1lQgYKBF9iaW9CCQoHX2xvY2FsZUILCglfdGltZXpvbmVCDQoLX2F2YXRhcl91cmwiLgoSVXBkYXRlVXNlclJlc3BvbnNlEhgKBHVzZXIYASABKAsyCi51c2VyLlVzZXIiHwoRRGVsZXRlVXNlclJlcXVlc3QSCgoCaWQYASABKAkiFAoSRGVsZXRlVXNlclJlc3BvbnNlIjoKElNldFVzZXJSb2xlUmVxdWVzdBIKCgJpZBgBIAEoCRIYCgRyb2xlGAIgASgOMgoudXNlci5Sb2xlIi8KE1NldFVzZXJSb2xlUmVzcG9uc2USGAoEdXNlchgBIAEoCzIKLnVzZXIuVXNlciI8ChlTZXRVc2VyUGVybWlzc2lvbnNSZXF1ZXN0EgoKAmlkGAEgASgJEhMKC3Blcm1pc3Npb25zGAIgAygJIjYKGlNldFVzZXJQZXJtaXNzaW9uc1Jlc3BvbnNlEhgKBHVzZXIYASABKAsyCi51c2VyLlVzZXIy4AMKC1VzZXJTZXJ2aWNlEjgKBUdldE1lEhIudXNlci5HZXRNZVJlcXVlc3QaEy51c2VyLkdldE1lUmVzcG9uc2UiBoK1GAIIARI+CgdHZXRVc2VyEhQudXNlci5HZXRVc2VyUmVxdWVzdBoVLnVzZXIuR2V0VXNlclJlc3BvbnNlIgaCtRgCCAESRwoKVXBkYXRlVXNlchIXLnVzZXIuVXBkYXRlVXNlclJlcXVlc3QaGC51c2VyLlVwZGF0ZVVzZXJSZXNwb25zZSIGgrUYAggBEkkKCkRlbGV0ZVVzZXISFy51c2VyLkRlbGV0ZVVzZXJSZXF1ZXN0GhgudXNlci5EZWxldGVVc2VyUmVzcG9uc2UiCIK1GAQIASABElYKC1NldFVzZXJSb2xlEhgudXNlci5TZXRVc2VyUm9sZVJlcXVlc3QaGS51c2VyLlNldFVzZXJSb2xlUmVzcG9uc2UiEoK1GA4qDHVzZXJzOm1hbmFnZRJrChJTZXRVc2VyUGVybWlzc2lvbnMSHy51c2VyLlNldFVzZXJQZXJtaXNzaW9uc1JlcXVlc3QaIC51c2VyLlNldFVzZXJQZXJtaXNzaW9uc1Jlc3BvbnNlIhKCtRgOKgx1c2VyczptYW5hZ2VCbgoIY29tLnVzZXJCEFVzZXJTZXJ2aWNlUHJvdG9QAVogZ3JwYy1zZXJ2ZXIvcHJvdG8tZ2VuZXJhdGVkL3VzZXKiAgNVWFiqAgRVc2VyygIEVXNlcuICEFVzZXJcR1BCTWV0YWRhdGHqAgRVc2VyYgZwcm90bzM", [file_user_user, file_authz_authz]);
+
+/**
+ * @generated from message user.GetMeRequest
+ */
+export type GetMeRequest = Message<"user.GetMeRequest"> & {
+};
+
+/**
+ * Describes the message user.GetMeRequest.
+ * Use `create(GetMeRequestSchema)` to create a new message.
+ */
+export const GetMeRequestSchema: GenMessage<GetMeRequest> = /*@__PURE__*/
+  messageDesc(file_user_user_service, 0);
+
+/**
+ * @generated from message user.GetMeResponse
+ */
+export type GetMeResponse = Message<"user.GetMeResponse"> & {
+  /**
+   * @generated from field: user.User user = 1;
+   */
+  user?: User;
+};
+
+/**
+ * Describes the message user.GetMeResponse.
+ * Use `create(GetMeResponseSchema)` to create a new message.
+ */
+export const GetMeResponseSchema: GenMessage<GetMeResponse> = /*@__PURE__*/
+  messageDesc(file_user_user_service, 1);
 
 /**
  * @generated from message user.GetUserRequest
@@ -29,7 +60,7 @@ export type GetUserRequest = Message<"user.GetUserRequest"> & {
  * Use `create(GetUserRequestSchema)` to create a new message.
  */
 export const GetUserRequestSchema: GenMessage<GetUserRequest> = /*@__PURE__*/
-  messageDesc(file_user_user_service, 0);
+  messageDesc(file_user_user_service, 2);
 
 /**
  * @generated from message user.GetUserResponse
@@ -46,7 +77,7 @@ export type GetUserResponse = Message<"user.GetUserResponse"> & {
  * Use `create(GetUserResponseSchema)` to create a new message.
  */
 export const GetUserResponseSchema: GenMessage<GetUserResponse> = /*@__PURE__*/
-  messageDesc(file_user_user_service, 1);
+  messageDesc(file_user_user_service, 3);
 
 /**
  * @generated from message user.UpdateUserRequest
@@ -66,6 +97,38 @@ export type UpdateUserRequest = Message<"user.UpdateUserRequest"> & {
    * @generated from field: optional string email = 3;
    */
   email?: string;
+
+  /**
+   * @generated from field: optional string display_name = 4;
+   */
+  displayName?: string;
+
+  /**
+   * @generated from field: optional string bio = 5;
+   */
+  bio?: string;
+
+  /**
+   * @generated from field: optional string locale = 6;
+   */
+  locale?: string;
+
+  /**
+   * @generated from field: optional string timezone = 7;
+   */
+  timezone?: string;
+
+  /**
+   * @generated from field: optional string avatar_url = 8;
+   */
+  avatarUrl?: string;
+
+  /**
+   * Required to change email: the caller's current password.
+   *
+   * @generated from field: string current_password = 9;
+   */
+  currentPassword: string;
 };
 
 /**
@@ -73,7 +136,7 @@ export type UpdateUserRequest = Message<"user.UpdateUserRequest"> & {
  * Use `create(UpdateUserRequestSchema)` to create a new message.
  */
 export const UpdateUserRequestSchema: GenMessage<UpdateUserRequest> = /*@__PURE__*/
-  messageDesc(file_user_user_service, 2);
+  messageDesc(file_user_user_service, 4);
 
 /**
  * @generated from message user.UpdateUserResponse
@@ -90,7 +153,7 @@ export type UpdateUserResponse = Message<"user.UpdateUserResponse"> & {
  * Use `create(UpdateUserResponseSchema)` to create a new message.
  */
 export const UpdateUserResponseSchema: GenMessage<UpdateUserResponse> = /*@__PURE__*/
-  messageDesc(file_user_user_service, 3);
+  messageDesc(file_user_user_service, 5);
 
 /**
  * @generated from message user.DeleteUserRequest
@@ -107,7 +170,7 @@ export type DeleteUserRequest = Message<"user.DeleteUserRequest"> & {
  * Use `create(DeleteUserRequestSchema)` to create a new message.
  */
 export const DeleteUserRequestSchema: GenMessage<DeleteUserRequest> = /*@__PURE__*/
-  messageDesc(file_user_user_service, 4);
+  messageDesc(file_user_user_service, 6);
 
 /**
  * @generated from message user.DeleteUserResponse
@@ -120,13 +183,104 @@ export type DeleteUserResponse = Message<"user.DeleteUserResponse"> & {
  * Use `create(DeleteUserResponseSchema)` to create a new message.
  */
 export const DeleteUserResponseSchema: GenMessage<DeleteUserResponse> = /*@__PURE__*/
-  messageDesc(file_user_user_service, 5);
+  messageDesc(file_user_user_service, 7);
+
+/**
+ * @generated from message user.SetUserRoleRequest
+ */
+export type SetUserRoleRequest = Message<"user.SetUserRoleRequest"> & {
+  /**
+   * @generated from field: string id = 1;
+   */
+  id: string;
+
+  /**
+   * @generated from field: user.Role role = 2;
+   */
+  role: Role;
+};
+
+/**
+ * Describes the message user.SetUserRoleRequest.
+ * Use `create(SetUserRoleRequestSchema)` to create a new message.
+ */
+export const SetUserRoleRequestSchema: GenMessage<SetUserRoleRequest> = /*@__PURE__*/
+  messageDesc(file_user_user_service, 8);
+
+/**
+ * @generated from message user.SetUserRoleResponse
+ */
+export type SetUserRoleResponse = Message<"user.SetUserRoleResponse"> & {
+  /**
+   * @generated from field: user.User user = 1;
+   */
+  user?: User;
+};
+
+/**
+ * Describes the message user.SetUserRoleResponse.
+ * Use `create(SetUserRoleResponseSchema)` to create a new message.
+ */
+export const SetUserRoleResponseSchema: GenMessage<SetUserRoleResponse> = /*@__PURE__*/
+  messageDesc(file_user_user_service, 9);
+
+/**
+ * @generated from message user.SetUserPermissionsRequest
+ */
+export type SetUserPermissionsRequest = Message<"user.SetUserPermissionsRequest"> & {
+  /**
+   * @generated from field: string id = 1;
+   */
+  id: string;
+
+  /**
+   * @generated from field: repeated string permissions = 2;
+   */
+  permissions: string[];
+};
+
+/**
+ * Describes the message user.SetUserPermissionsRequest.
+ * Use `create(SetUserPermissionsRequestSchema)` to create a new message.
+ */
+export const SetUserPermissionsRequestSchema: GenMessage<SetUserPermissionsRequest> = /*@__PURE__*/
+  messageDesc(file_user_user_service, 10);
+
+/**
+ * @generated from message user.SetUserPermissionsResponse
+ */
+export type SetUserPermissionsResponse = Message<"user.SetUserPermissionsResponse"> & {
+  /**
+   * @generated from field: user.User user = 1;
+   */
+  user?: User;
+};
+
+/**
+ * Describes the message user.SetUserPermissionsResponse.
+ * Use `create(SetUserPermissionsResponseSchema)` to create a new message.
+ */
+export const SetUserPermissionsResponseSchema: GenMessage<SetUserPermissionsResponse> = /*@__PURE__*/
+  messageDesc(file_user_user_service, 11);
 
 /**
  * @generated from service user.UserService
  */
 export const UserService: GenService<{
   /**
+   * GetMe returns the user the request's token belongs to.
+   *
+   * @generated from rpc user.UserService.GetMe
+   */
+  getMe: {
+    methodKind: "unary";
+    input: typeof GetMeRequestSchema;
+    output: typeof GetMeResponseSchema;
+  },
+  /**
+   * GetUser returns a user. Users may only read themselves; admins may
+   * read anyone.
+   *
    * @generated from rpc user.UserService.GetUser
    */
   getUser: {
@@ -135,6 +289,10 @@ export const UserService: GenService<{
     output: typeof GetUserResponseSchema;
   },
   /**
+   * UpdateUser changes the fields that are set; an empty string clears a
+   * profile field. Users may only update themselves; admins may update
+   * anyone. A new email address has to be verified again.
+   *
    * @generated from rpc user.UserService.UpdateUser
    */
   updateUser: {
@@ -143,6 +301,10 @@ export const UserService: GenService<{
     output: typeof UpdateUserResponseSchema;
   },
   /**
+   * DeleteUser deletes a user together with their items, sessions, API
+   * keys and linked identities. Users may delete themselves; admins may
+   * delete anyone but themselves.
+   *
    * @generated from rpc user.UserService.DeleteUser
    */
   deleteUser: {
@@ -150,6 +312,31 @@ export const UserService: GenService<{
     input: typeof DeleteUserRequestSchema;
     output: typeof DeleteUserResponseSchema;
   },
+  /**
+   * SetUserRole changes a user's role. Only callers allowed to manage
+   * users may call it, and not on themselves. The user is signed out so new
+   * tokens carry the new role.
+   *
+   * @generated from rpc user.UserService.SetUserRole
+   */
+  setUserRole: {
+    methodKind: "unary";
+    input: typeof SetUserRoleRequestSchema;
+    output: typeof SetUserRoleResponseSchema;
+  },
+  /**
+   * SetUserPermissions replaces the permissions a user holds on top of
+   * their role. Only callers allowed to manage users may call it, and not
+   * on themselves. The user is signed out so new tokens carry the new
+   * permissions.
+   *
+   * @generated from rpc user.UserService.SetUserPermissions
+   */
+  setUserPermissions: {
+    methodKind: "unary";
+    input: typeof SetUserPermissionsRequestSchema;
+    output: typeof SetUserPermissionsResponseSchema;
+  },
 }> = /*@__PURE__*/
   serviceDesc(file_user_user_service, 0);
 
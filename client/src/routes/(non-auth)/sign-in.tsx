@@ -1,6 +1,7 @@
 import { useMutation } from "@connectrpc/connect-query";
 import { useForm } from "@tanstack/react-form";
 import { createFileRoute, redirect, useNavigate } from "@tanstack/react-router";
+import { useState } from "react";
 import { toast } from "sonner";
 import { z } from "zod";
 import { ButtonRoot } from "@/components/ui/button/button";
@@ -11,7 +12,11 @@ import {
 	InputRoot,
 } from "@/components/ui/input/input";
 import { useAuth } from "@/context/auth-context";
-import { login as loginMethod } from "@/proto-generated/auth/auth_service-AuthService_connectquery";
+import type { TokenPair } from "@/proto-generated/auth/auth_pb";
+import {
+	login as loginMethod,
+	verifyMFA,
+} from "@/proto-generated/auth/auth_service-AuthService_connectquery";
 
 const loginSchema = z.object({
 	email: z
@@ -23,6 +28,8 @@ const loginSchema = z.object({
 
 type LoginData = z.infer<typeof loginSchema>;
 
+const mfaCodeSchema = z.string().trim().min(1, "인증 코드를 입력하세요");
+
 export const Route = createFileRoute("/(non-auth)/sign-in")({
 	component: RouteComponent,
 	beforeLoad: ({ context }) => {
@@ -37,28 +44,40 @@ export const Route = createFileRoute("/(non-auth)/sign-in")({
 function RouteComponent() {
 	const navigate = useNavigate();
 	const { login } = useAuth();
+	// 2단계 인증이 켜진 계정은 Login이 토큰 대신 챌린지 토큰을 돌려줍니다.
+	const [mfaToken, setMfaToken] = useState<string | null>(null);
+	const [mfaCode, setMfaCode] = useState("");
+
+	const completeLogin = (tokens: TokenPair | undefined) => {
+		if (tokens) {
+			login(tokens.accessToken, tokens.refreshToken);
+			toast.success("로그인 성공!");
+			navigate({ to: "/" });
+		}
+	};
 
 	const loginMutation = useMutation(loginMethod, {
 		onSuccess: (data) => {
-			if (data.user && data.tokens) {
-				login(
-					{
-						email: data.user.email,
-						id: data.user.id,
-						name: data.user.name,
-					},
-					data.tokens.accessToken,
-					data.tokens.refreshToken,
-				);
-				toast.success("로그인 성공!");
-				navigate({ to: "/" });
+			if (data.mfaRequired) {
+				setMfaToken(data.mfaToken);
+				return;
 			}
+			completeLogin(data.tokens);
 		},
 		onError: (error) => {
 			toast.error(`로그인 실패: ${error.message}`);
 		},
 	});
 
+	const verifyMFAMutation = useMutation(verifyMFA, {
+		onSuccess: (data) => {
+			completeLogin(data.tokens);
+		},
+		onError: (error) => {
+			toast.error(`인증 실패: ${error.message}`);
+		},
+	});
+
 	const form = useForm({
 		defaultValues: {
 			email: "",
@@ -74,6 +93,68 @@ function RouteComponent() {
 		},
 	});
 
+	if (mfaToken) {
+		return (
+			<div className="flex min-h-screen items-center justify-center">
+				<div className="w-full max-w-md space-y-8 rounded-lg border p-8">
+					<div className="text-center">
+						<h2 className="text-3xl font-bold">2단계 인증</h2>
+						<p className="mt-2 text-sm text-muted-foreground">
+							인증 앱의 코드나 복구 코드를 입력하세요
+						</p>
+					</div>
+
+					<form
+						onSubmit={(e) => {
+							e.preventDefault();
+							e.stopPropagation();
+							const result = mfaCodeSchema.safeParse(mfaCode);
+							if (!result.success) {
+								toast.error(result.error.issues[0]?.message);
+								return;
+							}
+							verifyMFAMutation.mutate({ mfaToken, code: result.data });
+						}}
+						className="space-y-6"
+					>
+						<InputRoot>
+							<InputLabel htmlFor="mfa-code">인증 코드</InputLabel>
+							<InputControl
+								id="mfa-code"
+								autoComplete="one-time-code"
+								value={mfaCode}
+								onChange={(e: React.ChangeEvent<HTMLInputElement>) =>
+									setMfaCode(e.target.value)
+								}
+							/>
+						</InputRoot>
+
+						<ButtonRoot
+							type="submit"
+							className="w-full"
+							disabled={verifyMFAMutation.isPending}
+						>
+							{verifyMFAMutation.isPending ? "확인 중..." : "확인"}
+						</ButtonRoot>
+
+						<div className="text-center text-sm">
+							<button
+								type="button"
+								onClick={() => {
+									setMfaToken(null);
+									setMfaCode("");
+								}}
+								className="text-primary hover:underline"
+							>
+								다시 로그인
+							</button>
+						</div>
+					</form>
+				</div>
+			</div>
+		);
+	}
+
 	return (
 		<div className="flex min-h-screen items-center justify-center">
 			<div className="w-full max-w-md space-y-8 rounded-lg border p-8">
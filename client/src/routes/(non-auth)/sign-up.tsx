@@ -10,7 +10,6 @@ import {
 	InputLabel,
 	InputRoot,
 } from "@/components/ui/input/input";
-import { useAuth } from "@/context/auth-context";
 import { register } from "@/proto-generated/auth/auth_service-AuthService_connectquery";
 
 const signUpSchema = z
@@ -43,15 +42,12 @@ export const Route = createFileRoute("/(non-auth)/sign-up")({
 
 function RouteComponent() {
 	const navigate = useNavigate();
-	const { login } = useAuth();
 
 	const registerMutation = useMutation(register, {
-		onSuccess: (data) => {
-			if (data.user && data.tokens) {
-				login(data.tokens.accessToken, data.tokens.refreshToken);
-				toast.success("회원가입 성공!");
-				navigate({ to: "/" });
-			}
+		onSuccess: () => {
+			// 이미 가입된 이메일인지 알 수 없도록 서버는 항상 같은 응답을 보냅니다.
+			toast.success("가입 안내 메일을 보냈습니다. 메일을 확인한 뒤 로그인하세요.");
+			navigate({ to: "/sign-in" });
 		},
 		onError: (error) => {
 			toast.error(`회원가입 실패: ${error.message}`);
@@ -22,7 +22,7 @@ echo ""
 
 # Start Go server
 echo "📦 Starting Go server on :8080..."
-cd server && go run main.go &
+cd server && DEV_EPHEMERAL_KEYS=true go run main.go &
 
 # Wait a bit for server to start
 sleep 2
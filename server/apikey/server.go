@@ -0,0 +1,179 @@
+package apikey
+
+import (
+	"context"
+	"fmt"
+	"net/http"
+	"strings"
+	"time"
+
+	"grpc-server/auth"
+	"grpc-server/database"
+	"grpc-server/ent"
+	"grpc-server/ent/apikey"
+	"grpc-server/ent/user"
+	apikeyv1 "grpc-server/proto-generated/apikey"
+	"grpc-server/proto-generated/apikey/apikeyconnect"
+
+	"connectrpc.com/connect"
+	"google.golang.org/protobuf/types/known/timestamppb"
+)
+
+const maxNameLength = 100
+
+func Register(db *database.DB, mux *http.ServeMux, authenticator *auth.Authenticator) {
+	server := NewApiKeyServer(db, authenticator)
+	path, handler := apikeyconnect.NewApiKeyServiceHandler(server, connect.WithInterceptors(authenticator.Interceptor()))
+	mux.Handle(path, handler)
+}
+
+type Server struct {
+	db            *database.DB
+	authenticator *auth.Authenticator
+}
+
+func NewApiKeyServer(db *database.DB, authenticator *auth.Authenticator) *Server {
+	return &Server{
+		db:            db,
+		authenticator: authenticator,
+	}
+}
+
+func (s *Server) CreateApiKey(
+	ctx context.Context,
+	req *connect.Request[apikeyv1.CreateApiKeyRequest],
+) (*connect.Response[apikeyv1.CreateApiKeyResponse], error) {
+	userID, err := auth.RequireAuth(ctx)
+	if err != nil {
+		return nil, connect.NewError(connect.CodeUnauthenticated, fmt.Errorf("authentication required"))
+	}
+
+	name := strings.TrimSpace(req.Msg.Name)
+	if name == "" || len(name) > maxNameLength {
+		return nil, connect.NewError(connect.CodeInvalidArgument, fmt.Errorf("name must be between 1 and %d characters", maxNameLength))
+	}
+	if err := auth.ValidateScopes(req.Msg.Scopes); err != nil {
+		return nil, connect.NewError(connect.CodeInvalidArgument, err)
+	}
+
+	secret, prefix, hash, err := auth.GenerateAPIKey()
+	if err != nil {
+		return nil, connect.NewError(connect.CodeInternal, err)
+	}
+
+	create := s.db.Client.APIKey.
+		Create().
+		SetName(name).
+		SetPrefix(prefix).
+		SetKeyHash(hash).
+		SetScopes(dedupe(req.Msg.Scopes)).
+		SetUserID(userID)
+
+	if req.Msg.ExpiresAt != nil {
+		expiresAt := req.Msg.ExpiresAt.AsTime()
+		if !expiresAt.After(time.Now()) {
+			return nil, connect.NewError(connect.CodeInvalidArgument, fmt.Errorf("expires_at must be in the future"))
+		}
+		create.SetExpiresAt(expiresAt)
+	}
+
+	entKey, err := create.Save(ctx)
+	if err != nil {
+		return nil, connect.NewError(connect.CodeInternal, fmt.Errorf("failed to create API key: %w", err))
+	}
+
+	return connect.NewResponse(&apikeyv1.CreateApiKeyResponse{
+		ApiKey: entAPIKeyToProto(entKey),
+		Secret: secret,
+	}), nil
+}
+
+func (s *Server) ListApiKeys(
+	ctx context.Context,
+	req *connect.Request[apikeyv1.ListApiKeysRequest],
+) (*connect.Response[apikeyv1.ListApiKeysResponse], error) {
+	userID, err := auth.RequireAuth(ctx)
+	if err != nil {
+		return nil, connect.NewError(connect.CodeUnauthenticated, fmt.Errorf("authentication required"))
+	}
+
+	entKeys, err := s.db.Client.APIKey.
+		Query().
+		Where(
+			apikey.HasUserWith(user.IDEQ(userID)),
+			apikey.RevokedAtIsNil(),
+		).
+		Order(ent.Desc(apikey.FieldCreatedAt)).
+		All(ctx)
+	if err != nil {
+		return nil, connect.NewError(connect.CodeInternal, fmt.Errorf("failed to list API keys: %w", err))
+	}
+
+	keys := make([]*apikeyv1.ApiKey, len(entKeys))
+	for i, entKey := range entKeys {
+		keys[i] = entAPIKeyToProto(entKey)
+	}
+
+	return connect.NewResponse(&apikeyv1.ListApiKeysResponse{
+		ApiKeys: keys,
+	}), nil
+}
+
+func (s *Server) RevokeApiKey(
+	ctx context.Context,
+	req *connect.Request[apikeyv1.RevokeApiKeyRequest],
+) (*connect.Response[apikeyv1.RevokeApiKeyResponse], error) {
+	userID, err := auth.RequireAuth(ctx)
+	if err != nil {
+		return nil, connect.NewError(connect.CodeUnauthenticated, fmt.Errorf("authentication required"))
+	}
+
+	// Keys of other users are reported as missing rather than forbidden so
+	// their IDs can't be probed.
+	revoked, err := s.db.Client.APIKey.
+		Update().
+		Where(
+			apikey.IDEQ(req.Msg.Id),
+			apikey.HasUserWith(user.IDEQ(userID)),
+			apikey.RevokedAtIsNil(),
+		).
+		SetRevokedAt(time.Now()).
+		Save(ctx)
+	if err != nil {
+		return nil, connect.NewError(connect.CodeInternal, fmt.Errorf("failed to revoke API key: %w", err))
+	}
+	if revoked == 0 {
+		return nil, connect.NewError(connect.CodeNotFound, fmt.Errorf("API key not found"))
+	}
+
+	return connect.NewResponse(&apikeyv1.RevokeApiKeyResponse{}), nil
+}
+
+func entAPIKeyToProto(entKey *ent.APIKey) *apikeyv1.ApiKey {
+	key := &apikeyv1.ApiKey{
+		Id:        entKey.ID,
+		Name:      entKey.Name,
+		Prefix:    entKey.Prefix,
+		Scopes:    entKey.Scopes,
+		CreatedAt: timestamppb.New(entKey.CreatedAt),
+	}
+	if entKey.ExpiresAt != nil {
+		key.ExpiresAt = timestamppb.New(*entKey.ExpiresAt)
+	}
+	if entKey.LastUsedAt != nil {
+		key.LastUsedAt = timestamppb.New(*entKey.LastUsedAt)
+	}
+	return key
+}
+
+func dedupe(scopes []string) []string {
+	seen := make(map[string]bool, len(scopes))
+	unique := make([]string, 0, len(scopes))
+	for _, scope := range scopes {
+		if !seen[scope] {
+			seen[scope] = true
+			unique = append(unique, scope)
+		}
+	}
+	return unique
+}
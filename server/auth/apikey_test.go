@@ -0,0 +1,53 @@
+package auth
+
+import (
+	"strings"
+	"testing"
+)
+
+func TestGenerateAPIKey(t *testing.T) {
+	secret, prefix, hash, err := GenerateAPIKey()
+	if err != nil {
+		t.Fatalf("GenerateAPIKey() error = %v", err)
+	}
+
+	if !isAPIKey(secret) {
+		t.Errorf("Expected secret to start with %q, got %q", APIKeyPrefix, secret)
+	}
+	if !strings.HasPrefix(secret, prefix) || len(prefix) >= len(secret) {
+		t.Errorf("Expected %q to be a short prefix of the secret", prefix)
+	}
+	if hash != hashToken(secret) {
+		t.Errorf("Expected hash to be the digest of the secret")
+	}
+
+	other, _, _, err := GenerateAPIKey()
+	if err != nil {
+		t.Fatalf("GenerateAPIKey() error = %v", err)
+	}
+	if other == secret {
+		t.Errorf("Expected unique secrets")
+	}
+}
+
+func TestValidateScopes(t *testing.T) {
+	tests := []struct {
+		name    string
+		scopes  []string
+		wantErr bool
+	}{
+		{"read", []string{"items:read"}, false},
+		{"read and write", []string{"items:read", "items:write"}, false},
+		{"empty", nil, true},
+		{"unknown", []string{"items:read", "users:admin"}, true},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			err := ValidateScopes(tt.scopes)
+			if (err != nil) != tt.wantErr {
+				t.Errorf("ValidateScopes() error = %v, wantErr %v", err, tt.wantErr)
+			}
+		})
+	}
+}
@@ -0,0 +1,287 @@
+package auth
+
+import (
+	"context"
+	"encoding/base64"
+	"fmt"
+	"log"
+	"strconv"
+	"strings"
+	"time"
+	"unicode"
+
+	"grpc-server/ent"
+	"grpc-server/ent/authevent"
+	"grpc-server/ent/predicate"
+
+	"connectrpc.com/connect"
+)
+
+const (
+	// AuthEventAuthenticate is recorded when Middleware rejects the
+	// credentials a request was made with.
+	AuthEventAuthenticate = "authenticate"
+	// AuthEventAuthorize is recorded when the interceptor rejects a caller
+	// for a procedure.
+	AuthEventAuthorize = "authorize"
+
+	defaultAuthEventPageSize = 50
+	maxAuthEventPageSize     = 100
+)
+
+// authEvent is an entry for the audit log.
+type authEvent struct {
+	Type      string
+	Procedure string
+	UserID    string
+	ActorID   string
+	Email     string
+	Client    sessionInfo
+	// Err is nil for successes.
+	Err error
+}
+
+// recordAuthEvent appends event to the audit log. Failing to record it must
+// not fail the request, so errors are only logged.
+func (a *Authenticator) recordAuthEvent(ctx context.Context, event authEvent) {
+	create := a.client.AuthEvent.
+		Create().
+		SetType(event.Type).
+		SetProcedure(event.Procedure).
+		SetUserID(event.UserID).
+		SetActorID(event.ActorID).
+		SetEmail(event.Email).
+		SetIPAddress(event.Client.IPAddress).
+		SetUserAgent(truncate(event.Client.UserAgent, maxUserAgentLength)).
+		SetOutcome(authevent.OutcomeSuccess)
+	if principal, ok := GetServicePrincipalFromContext(ctx); ok {
+		create.SetPrincipal(principal.ID)
+	}
+	if event.Err != nil {
+		create.
+			SetOutcome(authevent.OutcomeFailure).
+			SetReason(event.Err.Error())
+	}
+
+	if err := create.Exec(context.WithoutCancel(ctx)); err != nil {
+		log.Printf("audit: failed to record %s event: %v", event.Type, err)
+	}
+}
+
+// auditRecord collects what a handler knows about the caller of the RPC it
+// serves, for the event AuditInterceptor records once the handler returns.
+type auditRecord struct {
+	userID  string
+	actorID string
+	email   string
+	// failure marks calls that fail although the client is told otherwise.
+	failure error
+}
+
+type auditContextKey struct{}
+
+// auditFromContext returns the record of the RPC being served. Outside
+// AuditInterceptor it returns a record nobody reads.
+func auditFromContext(ctx context.Context) *auditRecord {
+	if record, ok := ctx.Value(auditContextKey{}).(*auditRecord); ok {
+		return record
+	}
+	return &auditRecord{}
+}
+
+// setUser attributes the event to userID, for RPCs made before signing in.
+func (r *auditRecord) setUser(userID string) {
+	r.userID = userID
+}
+
+// setActor records the admin acting as the user.
+func (r *auditRecord) setActor(actorID string) {
+	r.actorID = actorID
+}
+
+// setEmail records the address the caller tried to sign in with.
+func (r *auditRecord) setEmail(email string) {
+	r.email = email
+}
+
+// fail records err for a call that reports success to the client.
+func (r *auditRecord) fail(err error) {
+	r.failure = err
+}
+
+// AuditInterceptor records an auth event for every unary call of the service
+// it wraps. Install it inside Interceptor, which records the calls it
+// rejects itself.
+func (a *Authenticator) AuditInterceptor() connect.Interceptor {
+	return &auditInterceptor{authenticator: a}
+}
+
+type auditInterceptor struct {
+	authenticator *Authenticator
+}
+
+func (i *auditInterceptor) WrapUnary(next connect.UnaryFunc) connect.UnaryFunc {
+	return func(ctx context.Context, req connect.AnyRequest) (connect.AnyResponse, error) {
+		if req.Spec().IsClient {
+			return next(ctx, req)
+		}
+
+		record := &auditRecord{}
+		res, err := next(context.WithValue(ctx, auditContextKey{}, record), req)
+
+		event := authEvent{
+			Type:      procedureEventType(req.Spec().Procedure),
+			Procedure: req.Spec().Procedure,
+			UserID:    record.userID,
+			ActorID:   record.actorID,
+			Email:     record.email,
+			Client:    connectSessionInfo(req),
+			Err:       err,
+		}
+		if event.UserID == "" {
+			event.UserID, _ = GetUserIDFromContext(ctx)
+		}
+		if event.ActorID == "" {
+			event.ActorID, _ = GetActorIDFromContext(ctx)
+		}
+		if event.Err == nil {
+			event.Err = record.failure
+		}
+		i.authenticator.recordAuthEvent(ctx, event)
+
+		return res, err
+	}
+}
+
+func (i *auditInterceptor) WrapStreamingClient(next connect.StreamingClientFunc) connect.StreamingClientFunc {
+	return next
+}
+
+func (i *auditInterceptor) WrapStreamingHandler(next connect.StreamingHandlerFunc) connect.StreamingHandlerFunc {
+	return next
+}
+
+// procedureEventType turns "/auth.AuthService/RefreshToken" into
+// "refresh_token".
+func procedureEventType(procedure string) string {
+	method := procedure[strings.LastIndex(procedure, "/")+1:]
+
+	var b strings.Builder
+	runes := []rune(method)
+	for i, r := range runes {
+		if i > 0 && unicode.IsUpper(r) && unicode.IsLower(runes[i-1]) {
+			b.WriteByte('_')
+		}
+		b.WriteRune(unicode.ToLower(r))
+	}
+	return b.String()
+}
+
+// AuthEventFilter selects auth events. Zero fields match every event.
+type AuthEventFilter struct {
+	UserID    string
+	ActorID   string
+	Email     string
+	IPAddress string
+	Type      string
+	Outcome   authevent.Outcome
+	Since     time.Time
+	Until     time.Time
+}
+
+func (f AuthEventFilter) predicates() []predicate.AuthEvent {
+	var where []predicate.AuthEvent
+	if f.UserID != "" {
+		where = append(where, authevent.UserIDEQ(f.UserID))
+	}
+	if f.ActorID != "" {
+		where = append(where, authevent.ActorIDEQ(f.ActorID))
+	}
+	if f.Email != "" {
+		where = append(where, authevent.EmailEqualFold(f.Email))
+	}
+	if f.IPAddress != "" {
+		where = append(where, authevent.IPAddressEQ(f.IPAddress))
+	}
+	if f.Type != "" {
+		where = append(where, authevent.TypeEQ(f.Type))
+	}
+	if f.Outcome != "" {
+		where = append(where, authevent.OutcomeEQ(f.Outcome))
+	}
+	if !f.Since.IsZero() {
+		where = append(where, authevent.CreatedAtGTE(f.Since))
+	}
+	if !f.Until.IsZero() {
+		where = append(where, authevent.CreatedAtLT(f.Until))
+	}
+	return where
+}
+
+// authEventCursor is the position after the last event of a page.
+type authEventCursor struct {
+	createdAt time.Time
+	id        string
+}
+
+func (c authEventCursor) encode() string {
+	raw := strconv.FormatInt(c.createdAt.UnixMicro(), 10) + ":" + c.id
+	return base64.RawURLEncoding.EncodeToString([]byte(raw))
+}
+
+func decodeAuthEventCursor(token string) (authEventCursor, error) {
+	raw, err := base64.RawURLEncoding.DecodeString(token)
+	if err != nil {
+		return authEventCursor{}, ErrInvalidPageToken
+	}
+	micros, id, ok := strings.Cut(string(raw), ":")
+	if !ok || id == "" {
+		return authEventCursor{}, ErrInvalidPageToken
+	}
+	us, err := strconv.ParseInt(micros, 10, 64)
+	if err != nil {
+		return authEventCursor{}, ErrInvalidPageToken
+	}
+	return authEventCursor{createdAt: time.UnixMicro(us), id: id}, nil
+}
+
+// listAuthEvents returns a page of the events matching filter, newest first,
+// and the token of the next page, which is empty on the last one.
+func (a *Authenticator) listAuthEvents(ctx context.Context, filter AuthEventFilter, pageSize int, pageToken string) ([]*ent.AuthEvent, string, error) {
+	if pageSize <= 0 {
+		pageSize = defaultAuthEventPageSize
+	}
+	pageSize = min(pageSize, maxAuthEventPageSize)
+
+	where := filter.predicates()
+	if pageToken != "" {
+		cursor, err := decodeAuthEventCursor(pageToken)
+		if err != nil {
+			return nil, "", err
+		}
+		where = append(where, authevent.Or(
+			authevent.CreatedAtLT(cursor.createdAt),
+			authevent.And(
+				authevent.CreatedAtEQ(cursor.createdAt),
+				authevent.IDLT(cursor.id),
+			),
+		))
+	}
+
+	events, err := a.client.AuthEvent.
+		Query().
+		Where(where...).
+		Order(ent.Desc(authevent.FieldCreatedAt, authevent.FieldID)).
+		Limit(pageSize + 1).
+		All(ctx)
+	if err != nil {
+		return nil, "", fmt.Errorf("failed to query auth events: %w", err)
+	}
+
+	if len(events) <= pageSize {
+		return events, "", nil
+	}
+	events = events[:pageSize]
+	last := events[len(events)-1]
+	return events, authEventCursor{createdAt: last.CreatedAt, id: last.ID}.encode(), nil
+}
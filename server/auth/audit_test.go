@@ -0,0 +1,61 @@
+package auth
+
+import (
+	"context"
+	"errors"
+	"testing"
+	"time"
+)
+
+func TestProcedureEventType(t *testing.T) {
+	tests := map[string]string{
+		"/auth.AuthService/Login":                  "login",
+		"/auth.AuthService/RefreshToken":           "refresh_token",
+		"/auth.AuthService/EnrollTOTP":             "enroll_totp",
+		"/auth.AuthService/VerifyMFA":              "verify_mfa",
+		"/auth.AuthService/RevokeAllOtherSessions": "revoke_all_other_sessions",
+	}
+	for procedure, want := range tests {
+		if got := procedureEventType(procedure); got != want {
+			t.Errorf("Expected %q for %s, got %q", want, procedure, got)
+		}
+	}
+}
+
+func TestAuthEventCursorRoundTrip(t *testing.T) {
+	cursor := authEventCursor{
+		createdAt: time.Date(2025, 3, 1, 12, 30, 0, 123456000, time.UTC),
+		id:        "9b2f4a8e-1c1d-4a57-9d62-3f1e2b7c8d90",
+	}
+
+	decoded, err := decodeAuthEventCursor(cursor.encode())
+	if err != nil {
+		t.Fatalf("decodeAuthEventCursor() error = %v", err)
+	}
+	if !decoded.createdAt.Equal(cursor.createdAt) || decoded.id != cursor.id {
+		t.Errorf("Expected %+v, got %+v", cursor, decoded)
+	}
+}
+
+func TestDecodeAuthEventCursorRejectsGarbage(t *testing.T) {
+	for _, token := range []string{"not base64!", "bm9jb2xvbg", "YWJjOmlk", "MTIzOg"} {
+		if _, err := decodeAuthEventCursor(token); !errors.Is(err, ErrInvalidPageToken) {
+			t.Errorf("Expected ErrInvalidPageToken for %q, got %v", token, err)
+		}
+	}
+}
+
+func TestAuditRecordOutsideInterceptor(t *testing.T) {
+	// Handlers annotate their record unconditionally, so it must be safe
+	// to do without the interceptor.
+	record := auditFromContext(context.Background())
+	record.setUser("user-1")
+	record.fail(errors.New("ignored"))
+
+	record = &auditRecord{}
+	ctx := context.WithValue(context.Background(), auditContextKey{}, record)
+	auditFromContext(ctx).setEmail("user@example.com")
+	if record.email != "user@example.com" {
+		t.Errorf("Expected the handler to annotate the interceptor's record")
+	}
+}
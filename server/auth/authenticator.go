@@ -0,0 +1,295 @@
+package auth
+
+import (
+	"context"
+	"errors"
+	"fmt"
+	"log"
+	"slices"
+	"time"
+
+	"grpc-server/database"
+	"grpc-server/ent"
+	"grpc-server/ent/apikey"
+	"grpc-server/ent/identity"
+	"grpc-server/ent/item"
+	"grpc-server/ent/passwordresettoken"
+	"grpc-server/ent/refreshtoken"
+	"grpc-server/ent/session"
+	"grpc-server/ent/totpfactor"
+	"grpc-server/ent/user"
+
+	"connectrpc.com/connect"
+)
+
+const revocationSweepInterval = time.Minute
+
+// Authenticator issues and validates credentials. It is shared by the HTTP
+// middleware and the auth service so both use the same keys and see the
+// same revocations.
+type Authenticator struct {
+	config        Config
+	client        *ent.Client
+	keys          KeyManager
+	passwords     *PasswordHasher
+	policy        PasswordPolicy
+	revocations   *RevocationStore
+	loginThrottle *LoginThrottler
+	mailThrottle  *LoginThrottler
+	mail          *mailQueue
+	oidcProviders map[string]*oidcProvider
+}
+
+func NewAuthenticator(db *database.DB, config Config) *Authenticator {
+	oidcProviders := make(map[string]*oidcProvider)
+	for _, provider := range config.OIDCProviders {
+		oidcProviders[provider.Name] = newOIDCProvider(provider)
+	}
+
+	passwords := config.Passwords
+	if passwords == nil {
+		passwords = DefaultPasswordHasher()
+	}
+	policy := DefaultPasswordPolicy()
+	if config.PasswordPolicy != nil {
+		policy = *config.PasswordPolicy
+	}
+	// Passwords the hash can't take must fail the policy, not the hashing.
+	if limit := passwords.maxPasswordBytes(); limit > 0 && (policy.MaxBytes == 0 || policy.MaxBytes > limit) {
+		policy.MaxBytes = limit
+	}
+
+	return &Authenticator{
+		config:        config,
+		client:        db.Client,
+		keys:          config.Keys,
+		passwords:     passwords,
+		policy:        policy,
+		revocations:   NewRevocationStore(db.Client),
+		loginThrottle: NewLoginThrottler(db.Client),
+		mailThrottle:  newMailThrottler(db.Client),
+		mail:          newMailQueue(mailQueueSize),
+		oidcProviders: oidcProviders,
+	}
+}
+
+// Start runs the background maintenance tasks until ctx is done.
+func (a *Authenticator) Start(ctx context.Context) {
+	go a.revocations.RunSweeper(ctx, revocationSweepInterval)
+	go a.loginThrottle.RunSweeper(ctx, throttleSweepInterval)
+	go a.mailThrottle.RunSweeper(ctx, throttleSweepInterval)
+	for range mailWorkers {
+		go a.mail.run(ctx)
+	}
+}
+
+// ValidateAccessToken validates tokenString and rejects it if it was revoked.
+func (a *Authenticator) ValidateAccessToken(tokenString string) (*Claims, error) {
+	claims, err := a.ValidateToken(tokenString, TokenTypeAccess)
+	if err != nil {
+		return nil, err
+	}
+
+	if a.revocations.IsRevoked(claims) {
+		return nil, fmt.Errorf("token has been revoked")
+	}
+
+	return claims, nil
+}
+
+// RevokeAccessToken revokes the token described by claims until it expires.
+func (a *Authenticator) RevokeAccessToken(ctx context.Context, claims *Claims) error {
+	return a.revocations.Revoke(ctx, claims.ID, claims.ExpiresAt.Time)
+}
+
+// RequireVerifiedEmail rejects users who have not verified their email
+// address, when the deployment is configured to require it.
+func (a *Authenticator) RequireVerifiedEmail(ctx context.Context, userID string) error {
+	if !a.config.RequireVerifiedEmail {
+		return nil
+	}
+
+	verified, err := a.client.User.
+		Query().
+		Where(
+			user.IDEQ(userID),
+			user.EmailVerifiedAtNotNil(),
+		).
+		Exist(ctx)
+	if err != nil {
+		return fmt.Errorf("failed to check email verification: %w", err)
+	}
+	if !verified {
+		return ErrEmailNotVerified
+	}
+	return nil
+}
+
+// VerifyPassword checks password against the current password of u before
+// a sensitive change. Guesses count against the account like failed logins,
+// so a stolen access token cannot be used to brute-force the password. The
+// error is ready to return from an RPC.
+func (a *Authenticator) VerifyPassword(ctx context.Context, u *ent.User, password string, peer connect.Peer) error {
+	ip := clientIP(peer)
+	if err := a.loginThrottle.Check(ctx, u.Email, ip); err != nil {
+		var throttled *ThrottledError
+		if errors.As(err, &throttled) {
+			return throttledError(throttled)
+		}
+		return connect.NewError(connect.CodeInternal, err)
+	}
+
+	if _, err := a.passwords.Verify(u.PasswordHash, password); err != nil {
+		if err := a.loginThrottle.RecordFailure(ctx, u.Email, ip); err != nil {
+			log.Printf("verify password: %v", err)
+		}
+		return connect.NewError(connect.CodePermissionDenied, fmt.Errorf("current password is incorrect"))
+	}
+	if err := a.loginThrottle.Reset(ctx, u.Email); err != nil {
+		log.Printf("verify password: %v", err)
+	}
+	return nil
+}
+
+// SetUserRole changes the role of userID and signs them out everywhere, so
+// tokens carrying the old role stop working.
+func (a *Authenticator) SetUserRole(ctx context.Context, userID string, role Role) (*ent.User, error) {
+	if !ValidRole(role) {
+		return nil, fmt.Errorf("unknown role %q", role)
+	}
+
+	tx, err := a.client.Tx(ctx)
+	if err != nil {
+		return nil, fmt.Errorf("failed to start transaction: %w", err)
+	}
+
+	u, err := tx.User.
+		UpdateOneID(userID).
+		SetRole(user.Role(role)).
+		Save(ctx)
+	if err == nil {
+		err = a.revokeUserSessions(ctx, tx.Client(), userID)
+	}
+	if err != nil {
+		_ = tx.Rollback()
+		return nil, err
+	}
+
+	if err := tx.Commit(); err != nil {
+		return nil, fmt.Errorf("failed to commit transaction: %w", err)
+	}
+	return u.Unwrap(), nil
+}
+
+// SetUserPermissions replaces the permissions userID holds on top of their
+// role and signs them out everywhere, so that no token carries the old ones.
+func (a *Authenticator) SetUserPermissions(ctx context.Context, userID string, permissions []Permission) (*ent.User, error) {
+	if err := ValidatePermissions(permissions); err != nil {
+		return nil, err
+	}
+
+	names := make([]string, 0, len(permissions))
+	for _, perm := range permissions {
+		if !slices.Contains(names, string(perm)) {
+			names = append(names, string(perm))
+		}
+	}
+
+	tx, err := a.client.Tx(ctx)
+	if err != nil {
+		return nil, fmt.Errorf("failed to start transaction: %w", err)
+	}
+
+	u, err := tx.User.
+		UpdateOneID(userID).
+		SetPermissions(names).
+		Save(ctx)
+	if err == nil {
+		err = a.revokeUserSessions(ctx, tx.Client(), userID)
+	}
+	if err != nil {
+		_ = tx.Rollback()
+		return nil, err
+	}
+
+	if err := tx.Commit(); err != nil {
+		return nil, fmt.Errorf("failed to commit transaction: %w", err)
+	}
+	return u.Unwrap(), nil
+}
+
+// DeleteUser deletes userID along with their items and everything they
+// authenticate with. Access tokens they still hold are rejected by this
+// instance right away; other instances reject them once they expire, since
+// the user row that would tell them is gone.
+func (a *Authenticator) DeleteUser(ctx context.Context, userID string) error {
+	tx, err := a.client.Tx(ctx)
+	if err != nil {
+		return fmt.Errorf("failed to start transaction: %w", err)
+	}
+
+	if err := deleteUserTx(ctx, tx, userID); err != nil {
+		_ = tx.Rollback()
+		return err
+	}
+
+	if err := tx.Commit(); err != nil {
+		return fmt.Errorf("failed to commit transaction: %w", err)
+	}
+	a.revocations.revokeUserBefore(userID, time.Now())
+	return nil
+}
+
+func deleteUserTx(ctx context.Context, tx *ent.Tx, userID string) error {
+	// Lock the user first so nothing is added for them meanwhile.
+	if _, err := tx.User.Query().Where(user.IDEQ(userID)).ForUpdate().Only(ctx); err != nil {
+		return err
+	}
+
+	owned := []struct {
+		name   string
+		delete func(context.Context) (int, error)
+	}{
+		{"items", tx.Item.Delete().Where(item.HasUserWith(user.IDEQ(userID))).Exec},
+		{"sessions", tx.Session.Delete().Where(session.HasUserWith(user.IDEQ(userID))).Exec},
+		{"refresh tokens", tx.RefreshToken.Delete().Where(refreshtoken.HasUserWith(user.IDEQ(userID))).Exec},
+		{"password reset tokens", tx.PasswordResetToken.Delete().Where(passwordresettoken.HasUserWith(user.IDEQ(userID))).Exec},
+		{"TOTP factor", tx.TOTPFactor.Delete().Where(totpfactor.HasUserWith(user.IDEQ(userID))).Exec},
+		{"identities", tx.Identity.Delete().Where(identity.HasUserWith(user.IDEQ(userID))).Exec},
+		{"API keys", tx.APIKey.Delete().Where(apikey.HasUserWith(user.IDEQ(userID))).Exec},
+	}
+	for _, o := range owned {
+		if _, err := o.delete(ctx); err != nil {
+			return fmt.Errorf("failed to delete %s: %w", o.name, err)
+		}
+	}
+
+	if err := tx.User.DeleteOneID(userID).Exec(ctx); err != nil {
+		return fmt.Errorf("failed to delete user: %w", err)
+	}
+	return nil
+}
+
+// rehashPassword replaces the stored hash of u's password with one made by
+// the current hasher. It runs after a successful login, the only time the
+// plaintext is at hand, so failures are only logged.
+func (a *Authenticator) rehashPassword(ctx context.Context, u *ent.User, password string) {
+	passwordHash, err := a.passwords.Hash(password)
+	if err != nil {
+		log.Printf("rehash password: %v", err)
+		return
+	}
+
+	// Leave the hash alone if the password changed in the meantime.
+	err = a.client.User.
+		Update().
+		Where(
+			user.IDEQ(u.ID),
+			user.PasswordHashEQ(u.PasswordHash),
+		).
+		SetPasswordHash(passwordHash).
+		Exec(ctx)
+	if err != nil {
+		log.Printf("rehash password: %v", err)
+	}
+}
@@ -0,0 +1,36 @@
+package auth
+
+import "grpc-server/mail"
+
+// Config holds the settings the auth package is deployed with.
+type Config struct {
+	// Keys signs and verifies tokens.
+	Keys KeyManager
+	// Passwords hashes and verifies user passwords. Defaults to argon2id.
+	Passwords *PasswordHasher
+	// PasswordPolicy decides which passwords users may choose. Defaults to
+	// DefaultPasswordPolicy.
+	PasswordPolicy *PasswordPolicy
+	// EmailRules canonicalizes addresses at the providers it lists, keyed
+	// by domain. Addresses are always trimmed and their domain lowercased.
+	EmailRules map[string]EmailRule
+	// Mailer delivers account emails such as verification links.
+	Mailer mail.Mailer
+	// AppURL is the base URL of the web client; links in emails point there.
+	AppURL string
+	// RequireVerifiedEmail keeps users who have not verified their email
+	// address from writing items.
+	RequireVerifiedEmail bool
+	// MagicLinkSignup lets magic links create accounts for addresses that
+	// don't have one yet.
+	MagicLinkSignup bool
+	// Cookies keeps browser sessions in HttpOnly cookies instead of
+	// returning tokens to the web client.
+	Cookies CookieConfig
+	// ServicePrincipals are the internal services that may call with a
+	// client certificate, and what each of them may call.
+	ServicePrincipals []ServicePrincipal
+	// OIDCProviders are the external identity providers users can sign in
+	// with.
+	OIDCProviders []OIDCProviderConfig
+}
@@ -0,0 +1,155 @@
+package auth
+
+import (
+	"crypto/subtle"
+	"fmt"
+	"net/http"
+	"net/url"
+	"strconv"
+
+	protoAuth "grpc-server/proto-generated/auth"
+	"grpc-server/proto-generated/auth/authconnect"
+
+	"google.golang.org/protobuf/types/known/timestamppb"
+)
+
+const (
+	AccessTokenCookie  = "access_token"
+	RefreshTokenCookie = "refresh_token"
+	// CSRFCookie holds the double-submit token. It is readable by scripts
+	// so the web client can echo it in CSRFHeader.
+	CSRFCookie = "csrf_token"
+	CSRFHeader = "X-CSRF-Token"
+
+	csrfTokenBytes = 32
+)
+
+// refreshTokenCookiePath keeps the refresh token from being sent anywhere
+// but the auth service.
+var refreshTokenCookiePath = "/" + authconnect.AuthServiceName + "/"
+
+// CookieConfig enables browser sessions kept in cookies instead of tokens
+// the web client stores itself.
+type CookieConfig struct {
+	// Enabled makes the auth service set cookies instead of returning
+	// tokens, and Middleware accept the access token cookie.
+	Enabled bool
+	// Secure restricts the cookies to HTTPS. Only turn it off for local
+	// development over plain HTTP.
+	Secure bool
+	// Domain is the cookies' Domain attribute; empty means the host only.
+	Domain   string
+	SameSite http.SameSite
+}
+
+// deliverTokens hands a new token pair to the client. In cookie mode the
+// tokens go into HttpOnly cookies on header and the returned message only
+// tells the client when the access token expires.
+func (a *Authenticator) deliverTokens(header http.Header, pair *TokenPair) (*protoAuth.TokenPair, error) {
+	if !a.config.Cookies.Enabled {
+		return tokenPairToProto(pair), nil
+	}
+
+	csrfToken, err := randomToken(csrfTokenBytes)
+	if err != nil {
+		return nil, err
+	}
+
+	cookies := []*http.Cookie{
+		a.newCookie(AccessTokenCookie, pair.AccessToken, "/", int(accessTokenExpiry.Seconds()), true),
+		a.newCookie(RefreshTokenCookie, pair.RefreshToken, refreshTokenCookiePath, int(refreshTokenExpiry.Seconds()), true),
+		a.newCookie(CSRFCookie, csrfToken, "/", int(refreshTokenExpiry.Seconds()), false),
+	}
+	for _, cookie := range cookies {
+		header.Add("Set-Cookie", cookie.String())
+	}
+
+	return &protoAuth.TokenPair{
+		ExpiresAt: timestamppb.New(pair.AccessTokenExpiry),
+	}, nil
+}
+
+// deliverTokensToApp is deliverTokens for redirects back to the web client,
+// which receive the result in the URL fragment.
+func (a *Authenticator) deliverTokensToApp(w http.ResponseWriter, pair *TokenPair) (url.Values, error) {
+	tokens, err := a.deliverTokens(w.Header(), pair)
+	if err != nil {
+		return nil, err
+	}
+
+	fragment := url.Values{"expires_at": {strconv.FormatInt(pair.AccessTokenExpiry.Unix(), 10)}}
+	if tokens.AccessToken != "" {
+		fragment.Set("access_token", tokens.AccessToken)
+		fragment.Set("refresh_token", tokens.RefreshToken)
+	}
+	return fragment, nil
+}
+
+// clearSessionCookies removes the cookies deliverTokens sets.
+func (a *Authenticator) clearSessionCookies(header http.Header) {
+	if !a.config.Cookies.Enabled {
+		return
+	}
+	header.Add("Set-Cookie", a.newCookie(AccessTokenCookie, "", "/", -1, true).String())
+	header.Add("Set-Cookie", a.newCookie(RefreshTokenCookie, "", refreshTokenCookiePath, -1, true).String())
+	header.Add("Set-Cookie", a.newCookie(CSRFCookie, "", "/", -1, false).String())
+}
+
+func (a *Authenticator) newCookie(name, value, path string, maxAge int, httpOnly bool) *http.Cookie {
+	return &http.Cookie{
+		Name:     name,
+		Value:    value,
+		Path:     path,
+		Domain:   a.config.Cookies.Domain,
+		MaxAge:   maxAge,
+		HttpOnly: httpOnly,
+		Secure:   a.config.Cookies.Secure,
+		SameSite: a.config.Cookies.SameSite,
+	}
+}
+
+// cookieValue returns the value of the named cookie sent with header, if
+// cookie mode is enabled.
+func (a *Authenticator) cookieValue(header http.Header, name string) string {
+	if !a.config.Cookies.Enabled {
+		return ""
+	}
+	r := http.Request{Header: header}
+	cookie, err := r.Cookie(name)
+	if err != nil {
+		return ""
+	}
+	return cookie.Value
+}
+
+// checkCSRF enforces the double-submit pattern on requests that may change
+// state: a page on another origin can make the browser send our cookies but
+// can't read CSRFCookie to copy it into CSRFHeader. Connect only uses GET
+// for side-effect-free calls.
+func checkCSRF(r *http.Request) error {
+	switch r.Method {
+	case http.MethodGet, http.MethodHead, http.MethodOptions:
+		return nil
+	}
+
+	cookie, err := r.Cookie(CSRFCookie)
+	if err != nil || cookie.Value == "" {
+		return fmt.Errorf("missing CSRF cookie")
+	}
+	header := r.Header.Get(CSRFHeader)
+	if subtle.ConstantTimeCompare([]byte(cookie.Value), []byte(header)) != 1 {
+		return fmt.Errorf("CSRF token does not match")
+	}
+	return nil
+}
+
+// hasSessionCookie reports whether r carries one of the cookies that
+// authenticate a browser.
+func hasSessionCookie(r *http.Request) bool {
+	for _, name := range []string{AccessTokenCookie, RefreshTokenCookie} {
+		if _, err := r.Cookie(name); err == nil {
+			return true
+		}
+	}
+	return false
+}
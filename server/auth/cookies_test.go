@@ -0,0 +1,194 @@
+package auth
+
+import (
+	"net/http"
+	"net/http/httptest"
+	"testing"
+)
+
+func newCookieTestAuthenticator(t *testing.T) *Authenticator {
+	t.Helper()
+	a := newTestAuthenticator(t)
+	a.revocations = NewRevocationStore(nil)
+	a.config.Cookies = CookieConfig{
+		Enabled:  true,
+		Secure:   true,
+		SameSite: http.SameSiteLaxMode,
+	}
+	return a
+}
+
+func TestDeliverTokensSetsCookies(t *testing.T) {
+	a := newCookieTestAuthenticator(t)
+	pair, err := a.GenerateTokenPair("user-1", "user@example.com", RoleUser, "session-1")
+	if err != nil {
+		t.Fatalf("GenerateTokenPair() error = %v", err)
+	}
+
+	header := http.Header{}
+	tokens, err := a.deliverTokens(header, pair)
+	if err != nil {
+		t.Fatalf("deliverTokens() error = %v", err)
+	}
+	if tokens.AccessToken != "" || tokens.RefreshToken != "" {
+		t.Errorf("Expected tokens to stay out of the response body in cookie mode")
+	}
+	if tokens.ExpiresAt == nil {
+		t.Errorf("Expected the response to carry the access token expiry")
+	}
+
+	cookies := make(map[string]*http.Cookie)
+	for _, cookie := range (&http.Response{Header: header}).Cookies() {
+		cookies[cookie.Name] = cookie
+	}
+
+	tests := []struct {
+		name     string
+		value    string
+		path     string
+		httpOnly bool
+	}{
+		{AccessTokenCookie, pair.AccessToken, "/", true},
+		{RefreshTokenCookie, pair.RefreshToken, refreshTokenCookiePath, true},
+		{CSRFCookie, "", "/", false},
+	}
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			cookie, ok := cookies[tt.name]
+			if !ok {
+				t.Fatalf("Expected a %s cookie", tt.name)
+			}
+			if tt.value != "" && cookie.Value != tt.value {
+				t.Errorf("Expected %s to hold the token", tt.name)
+			}
+			if cookie.Value == "" {
+				t.Errorf("Expected %s to have a value", tt.name)
+			}
+			if cookie.Path != tt.path {
+				t.Errorf("Expected path %q, got %q", tt.path, cookie.Path)
+			}
+			if cookie.HttpOnly != tt.httpOnly {
+				t.Errorf("Expected HttpOnly %v, got %v", tt.httpOnly, cookie.HttpOnly)
+			}
+			if !cookie.Secure || cookie.SameSite != http.SameSiteLaxMode {
+				t.Errorf("Expected a Secure, SameSite=Lax cookie")
+			}
+		})
+	}
+}
+
+func TestDeliverTokensWithoutCookies(t *testing.T) {
+	a := newTestAuthenticator(t)
+	pair, err := a.GenerateTokenPair("user-1", "user@example.com", RoleUser, "session-1")
+	if err != nil {
+		t.Fatalf("GenerateTokenPair() error = %v", err)
+	}
+
+	header := http.Header{}
+	tokens, err := a.deliverTokens(header, pair)
+	if err != nil {
+		t.Fatalf("deliverTokens() error = %v", err)
+	}
+	if tokens.AccessToken != pair.AccessToken || tokens.RefreshToken != pair.RefreshToken {
+		t.Errorf("Expected the tokens in the response body")
+	}
+	if len(header.Values("Set-Cookie")) != 0 {
+		t.Errorf("Expected no cookies, got %v", header.Values("Set-Cookie"))
+	}
+}
+
+func TestCheckCSRF(t *testing.T) {
+	tests := []struct {
+		name    string
+		method  string
+		cookie  string
+		header  string
+		wantErr bool
+	}{
+		{"matching token", http.MethodPost, "token", "token", false},
+		{"missing header", http.MethodPost, "token", "", true},
+		{"mismatched header", http.MethodPost, "token", "other", true},
+		{"missing cookie", http.MethodPost, "", "token", true},
+		{"safe method", http.MethodGet, "", "", false},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			r := httptest.NewRequest(tt.method, "/items.ItemService/CreateItem", nil)
+			if tt.cookie != "" {
+				r.AddCookie(&http.Cookie{Name: CSRFCookie, Value: tt.cookie})
+			}
+			if tt.header != "" {
+				r.Header.Set(CSRFHeader, tt.header)
+			}
+
+			err := checkCSRF(r)
+			if (err != nil) != tt.wantErr {
+				t.Errorf("checkCSRF() error = %v, wantErr %v", err, tt.wantErr)
+			}
+		})
+	}
+}
+
+func TestMiddlewareReadsAccessTokenCookie(t *testing.T) {
+	a := newCookieTestAuthenticator(t)
+	pair, err := a.GenerateTokenPair("user-1", "user@example.com", RoleUser, "session-1")
+	if err != nil {
+		t.Fatalf("GenerateTokenPair() error = %v", err)
+	}
+
+	tests := []struct {
+		name       string
+		token      string
+		wantUserID string
+	}{
+		{"valid cookie", pair.AccessToken, "user-1"},
+		// Stale cookies leave the request anonymous instead of failing it.
+		{"invalid cookie", "not-a-token", ""},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			var gotUserID string
+			handler := a.Middleware(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+				gotUserID, _ = GetUserIDFromContext(r.Context())
+			}))
+
+			r := httptest.NewRequest(http.MethodPost, "/items.ItemService/CreateItem", nil)
+			r.AddCookie(&http.Cookie{Name: AccessTokenCookie, Value: tt.token})
+			r.AddCookie(&http.Cookie{Name: CSRFCookie, Value: "csrf"})
+			r.Header.Set(CSRFHeader, "csrf")
+			rec := httptest.NewRecorder()
+			handler.ServeHTTP(rec, r)
+
+			if rec.Code != http.StatusOK {
+				t.Fatalf("Expected status 200, got %d", rec.Code)
+			}
+			if gotUserID != tt.wantUserID {
+				t.Errorf("Expected user %q, got %q", tt.wantUserID, gotUserID)
+			}
+		})
+	}
+}
+
+func TestMiddlewareIgnoresCookiesWhenDisabled(t *testing.T) {
+	a := newTestAuthenticator(t)
+	a.revocations = NewRevocationStore(nil)
+	pair, err := a.GenerateTokenPair("user-1", "user@example.com", RoleUser, "session-1")
+	if err != nil {
+		t.Fatalf("GenerateTokenPair() error = %v", err)
+	}
+
+	var authenticated bool
+	handler := a.Middleware(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		_, authenticated = GetUserIDFromContext(r.Context())
+	}))
+
+	r := httptest.NewRequest(http.MethodPost, "/items.ItemService/CreateItem", nil)
+	r.AddCookie(&http.Cookie{Name: AccessTokenCookie, Value: pair.AccessToken})
+	handler.ServeHTTP(httptest.NewRecorder(), r)
+
+	if authenticated {
+		t.Errorf("Expected the cookie to be ignored when cookie mode is off")
+	}
+}
@@ -0,0 +1,89 @@
+package auth
+
+import (
+	"crypto/sha256"
+	"encoding/hex"
+	"fmt"
+	"sort"
+	"strings"
+
+	"grpc-server/ent/predicate"
+	"grpc-server/ent/user"
+
+	"entgo.io/ent/dialect/sql"
+)
+
+// EmailRule canonicalizes the local part of addresses at one mail provider.
+type EmailRule func(local string) string
+
+// GmailRule drops dots and "+tag" suffixes, which Gmail delivers to the same
+// inbox.
+func GmailRule(local string) string {
+	local, _, _ = strings.Cut(local, "+")
+	return strings.ToLower(strings.ReplaceAll(local, ".", ""))
+}
+
+// DefaultEmailRules are the built-in provider rules, keyed by domain, for
+// deployments that opt into them through Config.EmailRules.
+var DefaultEmailRules = map[string]EmailRule{
+	"gmail.com":      GmailRule,
+	"googlemail.com": GmailRule,
+}
+
+// NormalizeEmail returns the form email is stored and looked up in: trimmed,
+// with a lowercase domain and, if configured, the provider's rule applied to
+// the local part. Addresses without an @ are only trimmed; ValidateEmail
+// rejects them.
+func (a *Authenticator) NormalizeEmail(email string) string {
+	return normalizeEmail(email, a.config.EmailRules)
+}
+
+// EmailRulesFingerprint identifies what NormalizeEmail does, so that stored
+// addresses can be normalized again when the rules change. Rules are
+// functions, so they are told apart by what they make of sample addresses
+// at each domain they apply to.
+func (a *Authenticator) EmailRulesFingerprint() string {
+	return emailRulesFingerprint(a.config.EmailRules)
+}
+
+// emailRuleSamples are local parts that bring out what a rule ignores.
+var emailRuleSamples = []string{"Jane.Doe+news", "jane.doe", "JANE_DOE-1"}
+
+func emailRulesFingerprint(rules map[string]EmailRule) string {
+	domains := make([]string, 0, len(rules))
+	for domain := range rules {
+		domains = append(domains, domain)
+	}
+	sort.Strings(domains)
+
+	h := sha256.New()
+	for _, domain := range domains {
+		for _, local := range emailRuleSamples {
+			fmt.Fprintf(h, "%s\x00", normalizeEmail(local+"@"+domain, rules))
+		}
+	}
+	return "email-rules:" + hex.EncodeToString(h.Sum(nil))[:16]
+}
+
+func normalizeEmail(email string, rules map[string]EmailRule) string {
+	email = strings.TrimSpace(email)
+
+	at := strings.LastIndex(email, "@")
+	if at < 0 {
+		return email
+	}
+	local, domain := email[:at], strings.ToLower(email[at+1:])
+
+	if rule, ok := rules[domain]; ok {
+		local = rule(local)
+	}
+	return local + "@" + domain
+}
+
+// emailIs matches the user whose email equals email regardless of case. It
+// is backed by the unique index on lower(email).
+func emailIs(email string) predicate.User {
+	return predicate.User(func(s *sql.Selector) {
+		s.Where(sql.EQ(sql.Lower(s.C(user.FieldEmail)), strings.ToLower(email)))
+	})
+}
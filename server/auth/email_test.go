@@ -0,0 +1,61 @@
+package auth
+
+import (
+	"strings"
+	"testing"
+)
+
+func TestNormalizeEmail(t *testing.T) {
+	tests := []struct {
+		name  string
+		email string
+		rules map[string]EmailRule
+		want  string
+	}{
+		{"already normalized", "bob@example.com", nil, "bob@example.com"},
+		{"surrounding spaces", "  bob@example.com\n", nil, "bob@example.com"},
+		{"domain case", "Bob@Example.COM", nil, "Bob@example.com"},
+		{"gmail without rules", "Bob.Smith+news@Gmail.com", nil, "Bob.Smith+news@gmail.com"},
+		{"gmail with rules", "Bob.Smith+news@Gmail.com", DefaultEmailRules, "bobsmith@gmail.com"},
+		{"other domain with rules", "Bob.Smith+news@example.com", DefaultEmailRules, "Bob.Smith+news@example.com"},
+		{"quoted at sign", `"a@b"@example.COM`, nil, `"a@b"@example.com`},
+		{"no at sign", " bob ", nil, "bob"},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			if got := normalizeEmail(tt.email, tt.rules); got != tt.want {
+				t.Errorf("Expected %q, got %q", tt.want, got)
+			}
+		})
+	}
+}
+
+func TestEmailRulesFingerprint(t *testing.T) {
+	plusOnly := func(local string) string {
+		local, _, _ = strings.Cut(local, "+")
+		return local
+	}
+
+	none := emailRulesFingerprint(nil)
+	defaults := emailRulesFingerprint(DefaultEmailRules)
+	gmailOnly := emailRulesFingerprint(map[string]EmailRule{"gmail.com": GmailRule})
+	otherRule := emailRulesFingerprint(map[string]EmailRule{"gmail.com": plusOnly})
+
+	if defaults != emailRulesFingerprint(map[string]EmailRule{"googlemail.com": GmailRule, "gmail.com": GmailRule}) {
+		t.Errorf("Expected the same rules to have the same fingerprint")
+	}
+	fingerprints := map[string]string{
+		"no rules":   none,
+		"defaults":   defaults,
+		"gmail only": gmailOnly,
+		"other rule": otherRule,
+	}
+	seen := make(map[string]string)
+	for name, fingerprint := range fingerprints {
+		if other, ok := seen[fingerprint]; ok {
+			t.Errorf("Expected %s and %s to have different fingerprints, both got %q", name, other, fingerprint)
+		}
+		seen[fingerprint] = name
+	}
+}
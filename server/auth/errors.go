@@ -3,5 +3,20 @@ package auth
 import "fmt"
 
 var (
-	ErrUnauthorized = fmt.Errorf("unauthorized: authentication required")
+	ErrUnauthorized        = fmt.Errorf("unauthorized: authentication required")
+	ErrInvalidCredentials  = fmt.Errorf("invalid email or password")
+	ErrInvalidRefreshToken = fmt.Errorf("invalid refresh token")
+	ErrRefreshTokenReused  = fmt.Errorf("refresh token reuse detected")
+	ErrEmailNotVerified    = fmt.Errorf("email address has not been verified")
+	ErrInvalidToken        = fmt.Errorf("invalid or expired token")
+	ErrTokenAlreadyUsed    = fmt.Errorf("token has already been used")
+	ErrMFAAlreadyEnabled   = fmt.Errorf("two-factor authentication is already enabled")
+	ErrMFANotEnabled       = fmt.Errorf("two-factor authentication is not enabled")
+	ErrInvalidMFACode      = fmt.Errorf("invalid two-factor authentication code")
+	ErrInvalidAPIKey       = fmt.Errorf("invalid or expired API key")
+	ErrInsufficientScope   = fmt.Errorf("API key does not grant access to this procedure")
+	ErrSessionNotFound     = fmt.Errorf("session not found")
+	ErrPermissionDenied    = fmt.Errorf("permission denied")
+	ErrInvalidPageToken    = fmt.Errorf("invalid page token")
+	ErrImpersonation       = fmt.Errorf("not allowed while impersonating a user")
 )
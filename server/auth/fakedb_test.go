@@ -0,0 +1,162 @@
+package auth
+
+import (
+	"context"
+	"database/sql"
+	"database/sql/driver"
+	"errors"
+	"fmt"
+	"io"
+	"strings"
+
+	"grpc-server/ent"
+
+	"entgo.io/ent/dialect"
+	entsql "entgo.io/ent/dialect/sql"
+)
+
+// fakeDriver is a dialect.Driver for tests that need a database. It records
+// every statement, reports each write as affecting one row, and answers
+// queries with the scripted results in order; once the script runs out,
+// queries return no rows.
+type fakeDriver struct {
+	statements []fakeStatement
+	results    []fakeResult
+	// err fails every statement, failures only the statements starting
+	// with a given prefix.
+	err      error
+	failures map[string]error
+}
+
+type fakeStatement struct {
+	query string
+	args  []any
+}
+
+// fakeResult is the answer to one query.
+type fakeResult struct {
+	columns []string
+	rows    [][]any
+}
+
+func newFakeClient() (*ent.Client, *fakeDriver) {
+	drv := &fakeDriver{}
+	return ent.NewClient(ent.Driver(drv)), drv
+}
+
+func (d *fakeDriver) record(query string, args any) {
+	statement := fakeStatement{query: query}
+	statement.args, _ = args.([]any)
+	d.statements = append(d.statements, statement)
+}
+
+// failure returns the error query is scripted to fail with, if any.
+func (d *fakeDriver) failure(query string) error {
+	if d.err != nil {
+		return d.err
+	}
+	for prefix, err := range d.failures {
+		if strings.HasPrefix(query, prefix) {
+			return err
+		}
+	}
+	return nil
+}
+
+func (d *fakeDriver) Exec(ctx context.Context, query string, args, v any) error {
+	d.record(query, args)
+	if err := d.failure(query); err != nil {
+		return err
+	}
+	if res, ok := v.(*entsql.Result); ok {
+		*res = driver.RowsAffected(1)
+	}
+	return nil
+}
+
+func (d *fakeDriver) Query(ctx context.Context, query string, args, v any) error {
+	d.record(query, args)
+	if err := d.failure(query); err != nil {
+		return err
+	}
+	rows, ok := v.(*entsql.Rows)
+	if !ok {
+		return fmt.Errorf("fakeDriver: unexpected query destination %T", v)
+	}
+	var result fakeResult
+	if len(d.results) > 0 {
+		result, d.results = d.results[0], d.results[1:]
+	}
+	rows.ColumnScanner = &fakeRows{result: result}
+	return nil
+}
+
+func (d *fakeDriver) Tx(ctx context.Context) (dialect.Tx, error) {
+	if d.err != nil {
+		return nil, d.err
+	}
+	return dialect.NopTx(d), nil
+}
+
+func (d *fakeDriver) Close() error    { return nil }
+func (d *fakeDriver) Dialect() string { return dialect.Postgres }
+
+// find returns the recorded statements starting with prefix, e.g.
+// `UPDATE "sessions"`.
+func (d *fakeDriver) find(prefix string) []fakeStatement {
+	var found []fakeStatement
+	for _, statement := range d.statements {
+		if strings.HasPrefix(statement.query, prefix) {
+			found = append(found, statement)
+		}
+	}
+	return found
+}
+
+// fakeRows serves a fakeResult to ent's scanner.
+type fakeRows struct {
+	result fakeResult
+	next   int
+}
+
+func (r *fakeRows) Close() error                            { return nil }
+func (r *fakeRows) ColumnTypes() ([]*sql.ColumnType, error) { return nil, nil }
+func (r *fakeRows) Columns() ([]string, error)              { return r.result.columns, nil }
+func (r *fakeRows) Err() error                              { return nil }
+func (r *fakeRows) NextResultSet() bool                     { return false }
+
+func (r *fakeRows) Next() bool {
+	if r.next >= len(r.result.rows) {
+		return false
+	}
+	r.next++
+	return true
+}
+
+func (r *fakeRows) Scan(dest ...any) error {
+	if r.next == 0 {
+		return io.EOF
+	}
+	row := r.result.rows[r.next-1]
+	if len(dest) != len(row) {
+		return fmt.Errorf("fakeRows: scanning %d values into %d destinations", len(row), len(dest))
+	}
+	for i, value := range row {
+		// JSON columns are scanned as raw bytes.
+		if raw, ok := dest[i].(*[]byte); ok {
+			b, _ := value.([]byte)
+			*raw = b
+			continue
+		}
+		scanner, ok := dest[i].(sql.Scanner)
+		if !ok {
+			return fmt.Errorf("fakeRows: unsupported destination %T", dest[i])
+		}
+		if err := scanner.Scan(value); err != nil {
+			return err
+		}
+	}
+	return nil
+}
+
+var errFakeDatabase = errors.New("connection refused")
@@ -0,0 +1,166 @@
+package auth
+
+import (
+	"context"
+	"errors"
+	"fmt"
+	"slices"
+
+	"grpc-server/proto-generated/authz"
+
+	"connectrpc.com/connect"
+	"google.golang.org/protobuf/proto"
+	"google.golang.org/protobuf/reflect/protoreflect"
+)
+
+// Interceptor enforces the authz.rule option declared on each RPC. RPCs
+// without the option require an authenticated caller, so forgetting to
+// annotate one fails closed. It relies on Middleware having put the caller
+// into the context. Rejected calls are recorded in the audit log.
+func (a *Authenticator) Interceptor() connect.Interceptor {
+	return &authInterceptor{authenticator: a}
+}
+
+type authInterceptor struct {
+	authenticator *Authenticator
+}
+
+func (i *authInterceptor) WrapUnary(next connect.UnaryFunc) connect.UnaryFunc {
+	return func(ctx context.Context, req connect.AnyRequest) (connect.AnyResponse, error) {
+		if req.Spec().IsClient {
+			return next(ctx, req)
+		}
+		if err := authorizeProcedure(ctx, req.Spec()); err != nil {
+			i.recordRejection(ctx, req.Spec(), connectSessionInfo(req), err)
+			return nil, err
+		}
+		return next(ctx, req)
+	}
+}
+
+func (i *authInterceptor) WrapStreamingClient(next connect.StreamingClientFunc) connect.StreamingClientFunc {
+	return next
+}
+
+func (i *authInterceptor) WrapStreamingHandler(next connect.StreamingHandlerFunc) connect.StreamingHandlerFunc {
+	return func(ctx context.Context, conn connect.StreamingHandlerConn) error {
+		if err := authorizeProcedure(ctx, conn.Spec()); err != nil {
+			i.recordRejection(ctx, conn.Spec(), sessionInfo{
+				UserAgent: conn.RequestHeader().Get("User-Agent"),
+				IPAddress: clientIP(conn.Peer()),
+			}, err)
+			return err
+		}
+		return next(ctx, conn)
+	}
+}
+
+func (i *authInterceptor) recordRejection(ctx context.Context, spec connect.Spec, client sessionInfo, err error) {
+	userID, _ := GetUserIDFromContext(ctx)
+	actorID, _ := GetActorIDFromContext(ctx)
+	i.authenticator.recordAuthEvent(ctx, authEvent{
+		Type:      AuthEventAuthorize,
+		Procedure: spec.Procedure,
+		UserID:    userID,
+		ActorID:   actorID,
+		Client:    client,
+		Err:       err,
+	})
+}
+
+// authorizeProcedure checks the caller in ctx against the rule of the
+// procedure described by spec.
+func authorizeProcedure(ctx context.Context, spec connect.Spec) error {
+	rule, err := procedureRule(spec)
+	if err != nil {
+		return connect.NewError(connect.CodeInternal, err)
+	}
+
+	if err := checkRule(ctx, rule); err != nil {
+		switch {
+		case errors.Is(err, ErrUnauthorized):
+			return connect.NewError(connect.CodeUnauthenticated, err)
+		case errors.Is(err, ErrInsufficientScope), errors.Is(err, ErrPermissionDenied), errors.Is(err, ErrImpersonation):
+			return connect.NewError(connect.CodePermissionDenied, err)
+		default:
+			return connect.NewError(connect.CodeInternal, err)
+		}
+	}
+	return nil
+}
+
+// procedureRule reads the authz.rule option from the method descriptor the
+// handler was built with.
+func procedureRule(spec connect.Spec) (*authz.AuthRule, error) {
+	method, ok := spec.Schema.(protoreflect.MethodDescriptor)
+	if !ok {
+		return nil, fmt.Errorf("no schema for procedure %s", spec.Procedure)
+	}
+	rule, _ := proto.GetExtension(method.Options(), authz.E_Rule).(*authz.AuthRule)
+	if rule == nil {
+		rule = &authz.AuthRule{}
+	}
+	return rule, nil
+}
+
+func checkRule(ctx context.Context, rule *authz.AuthRule) error {
+	if rule.Access == authz.Access_ACCESS_PUBLIC {
+		return nil
+	}
+
+	// Services are limited to their scopes whether they call as
+	// themselves or on behalf of a user.
+	if principal, ok := GetServicePrincipalFromContext(ctx); ok {
+		if !grantsAny(principal.Scopes, rule.Scopes) {
+			return ErrInsufficientScope
+		}
+		if _, isUser := GetUserIDFromContext(ctx); !isUser {
+			if len(rule.Roles) > 0 || len(rule.Permissions) > 0 {
+				return ErrPermissionDenied
+			}
+			return nil
+		}
+	}
+
+	if _, err := RequireAuth(ctx); err != nil {
+		return err
+	}
+
+	if _, ok := GetAPIKeyIDFromContext(ctx); ok {
+		scopes, _ := GetScopesFromContext(ctx)
+		if !grantsAny(scopes, rule.Scopes) {
+			return ErrInsufficientScope
+		}
+	}
+
+	if _, ok := GetActorIDFromContext(ctx); ok && rule.DenyImpersonation {
+		return ErrImpersonation
+	}
+
+	if len(rule.Roles) > 0 {
+		role, _ := GetRoleFromContext(ctx)
+		if !grantsAny([]string{string(role)}, rule.Roles) {
+			return ErrPermissionDenied
+		}
+	}
+
+	if len(rule.Permissions) > 0 && !slices.ContainsFunc(rule.Permissions, func(perm string) bool {
+		return HasPermission(ctx, Permission(perm))
+	}) {
+		return ErrPermissionDenied
+	}
+
+	return nil
+}
+
+// grantsAny reports whether held contains any of required.
+func grantsAny(held, required []string) bool {
+	for _, r := range required {
+		for _, h := range held {
+			if h == r {
+				return true
+			}
+		}
+	}
+	return false
+}
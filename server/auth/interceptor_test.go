@@ -0,0 +1,130 @@
+package auth
+
+import (
+	"context"
+	"testing"
+
+	"grpc-server/proto-generated/apikey"
+	protoAuth "grpc-server/proto-generated/auth"
+	"grpc-server/proto-generated/authz"
+	"grpc-server/proto-generated/item"
+	"grpc-server/proto-generated/user"
+
+	"connectrpc.com/connect"
+	"google.golang.org/protobuf/proto"
+	"google.golang.org/protobuf/reflect/protoreflect"
+)
+
+var serviceFiles = []protoreflect.FileDescriptor{
+	protoAuth.File_auth_auth_service_proto,
+	item.File_item_item_service_proto,
+	user.File_user_user_service_proto,
+	apikey.File_apikey_api_key_service_proto,
+}
+
+func methodSpec(t *testing.T, file protoreflect.FileDescriptor, service, method string) connect.Spec {
+	t.Helper()
+	md := file.Services().ByName(protoreflect.Name(service)).Methods().ByName(protoreflect.Name(method))
+	if md == nil {
+		t.Fatalf("No method %s.%s", service, method)
+	}
+	return connect.Spec{
+		Procedure: "/" + string(md.Parent().FullName()) + "/" + method,
+		Schema:    md,
+	}
+}
+
+func apiKeyContext(scopes ...string) context.Context {
+	ctx := contextWithUser("user-1", RoleUser)
+	ctx = context.WithValue(ctx, APIKeyIDContextKey, "key-1")
+	return context.WithValue(ctx, ScopesContextKey, scopes)
+}
+
+func impersonationContext(userID, actorID string) context.Context {
+	return context.WithValue(contextWithUser(userID, RoleUser), ActorIDContextKey, actorID)
+}
+
+func serviceContext(ctx context.Context, scopes ...string) context.Context {
+	return context.WithValue(ctx, ServicePrincipalContextKey, &ServicePrincipal{ID: "spiffe://example.org/reporter", Scopes: scopes})
+}
+
+func contextWithPermissions(ctx context.Context, permissions ...Permission) context.Context {
+	return context.WithValue(ctx, PermissionsContextKey, permissions)
+}
+
+func TestAuthorizeProcedure(t *testing.T) {
+	login := methodSpec(t, protoAuth.File_auth_auth_service_proto, "AuthService", "Login")
+	changePassword := methodSpec(t, protoAuth.File_auth_auth_service_proto, "AuthService", "ChangePassword")
+	listItems := methodSpec(t, item.File_item_item_service_proto, "ItemService", "ListItems")
+	watchItems := methodSpec(t, item.File_item_item_service_proto, "ItemService", "WatchItems")
+	createItem := methodSpec(t, item.File_item_item_service_proto, "ItemService", "CreateItem")
+	setUserRole := methodSpec(t, user.File_user_user_service_proto, "UserService", "SetUserRole")
+	createApiKey := methodSpec(t, apikey.File_apikey_api_key_service_proto, "ApiKeyService", "CreateApiKey")
+	deleteUser := methodSpec(t, user.File_user_user_service_proto, "UserService", "DeleteUser")
+
+	tests := []struct {
+		name string
+		ctx  context.Context
+		spec connect.Spec
+		code connect.Code
+	}{
+		{"anonymous login", context.Background(), login, 0},
+		{"anonymous list items", context.Background(), listItems, connect.CodeUnauthenticated},
+		{"anonymous watch items", context.Background(), watchItems, connect.CodeUnauthenticated},
+		{"user lists items", contextWithUser("user-1", RoleUser), listItems, 0},
+		{"user changes password", contextWithUser("user-1", RoleUser), changePassword, 0},
+		{"read key lists items", apiKeyContext("items:read"), listItems, 0},
+		{"read key cannot create items", apiKeyContext("items:read"), createItem, connect.CodePermissionDenied},
+		{"write key creates items", apiKeyContext("items:write"), createItem, 0},
+		{"key cannot change passwords", apiKeyContext("items:read", "items:write"), changePassword, connect.CodePermissionDenied},
+		{"user cannot set roles", contextWithUser("user-1", RoleUser), setUserRole, connect.CodePermissionDenied},
+		{"admin sets roles", contextWithUser("admin-1", RoleAdmin), setUserRole, 0},
+		{"user managing users sets roles", contextWithPermissions(contextWithUser("user-1", RoleUser), PermissionManageUsers), setUserRole, 0},
+		{"user with another permission cannot set roles", contextWithPermissions(contextWithUser("user-1", RoleUser), PermissionViewAuthEvents), setUserRole, connect.CodePermissionDenied},
+		{"impersonator lists items", impersonationContext("user-1", "admin-1"), listItems, 0},
+		{"impersonator cannot change passwords", impersonationContext("user-1", "admin-1"), changePassword, connect.CodePermissionDenied},
+		{"impersonator cannot create API keys", impersonationContext("user-1", "admin-1"), createApiKey, connect.CodePermissionDenied},
+		{"user deletes their account", contextWithUser("user-1", RoleUser), deleteUser, 0},
+		{"impersonator cannot delete the account", impersonationContext("user-1", "admin-1"), deleteUser, connect.CodePermissionDenied},
+		{"service lists items", serviceContext(context.Background(), "items:read"), listItems, 0},
+		{"read service cannot create items", serviceContext(context.Background(), "items:read"), createItem, connect.CodePermissionDenied},
+		{"service cannot change passwords", serviceContext(context.Background(), "items:read", "items:write"), changePassword, connect.CodePermissionDenied},
+		{"service cannot set roles", serviceContext(context.Background(), "items:write"), setUserRole, connect.CodePermissionDenied},
+		{"service may log users in", serviceContext(context.Background()), login, 0},
+		{"service creates items for a user", serviceContext(contextWithUser("user-1", RoleUser), "items:write"), createItem, 0},
+		{"service limits the user it acts for", serviceContext(contextWithUser("admin-1", RoleAdmin), "items:read"), setUserRole, connect.CodePermissionDenied},
+		{"no schema", contextWithUser("user-1", RoleUser), connect.Spec{Procedure: "/unknown"}, connect.CodeInternal},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			err := authorizeProcedure(tt.ctx, tt.spec)
+			if tt.code == 0 {
+				if err != nil {
+					t.Errorf("Expected no error, got %v", err)
+				}
+				return
+			}
+			if got := connect.CodeOf(err); got != tt.code {
+				t.Errorf("Expected %v, got %v (%v)", tt.code, got, err)
+			}
+		})
+	}
+}
+
+// Every RPC should state its rule explicitly, even though unannotated ones
+// fail closed.
+func TestEveryProcedureHasAuthRule(t *testing.T) {
+	for _, file := range serviceFiles {
+		services := file.Services()
+		for i := 0; i < services.Len(); i++ {
+			methods := services.Get(i).Methods()
+			for j := 0; j < methods.Len(); j++ {
+				method := methods.Get(j)
+				if !proto.HasExtension(method.Options(), authz.E_Rule) {
+					t.Errorf("Expected %s to declare an authz.rule option", method.FullName())
+				}
+			}
+		}
+	}
+}
@@ -0,0 +1,238 @@
+package auth
+
+import (
+	"crypto"
+	"crypto/ed25519"
+	"crypto/rand"
+	"crypto/rsa"
+	"crypto/x509"
+	"encoding/pem"
+	"fmt"
+	"os"
+	"path/filepath"
+	"sort"
+	"strings"
+	"sync"
+
+	"github.com/golang-jwt/jwt/v5"
+)
+
+const minRSAKeyBits = 2048
+
+// Key is a JWT signing key identified by the kid header. Keys without a
+// private half can only verify tokens, which is how retired keys stay
+// trusted until the tokens they signed have expired.
+type Key struct {
+	ID      string
+	Method  jwt.SigningMethod
+	Private crypto.Signer
+	Public  crypto.PublicKey
+}
+
+// KeyManager supplies the key new tokens are signed with and every key that
+// tokens may still be verified against.
+type KeyManager interface {
+	SigningKey() (*Key, error)
+	VerificationKey(kid string) (*Key, error)
+	VerificationKeys() []*Key
+}
+
+// KeySet is an in-memory KeyManager with one active signing key.
+type KeySet struct {
+	mu       sync.RWMutex
+	keys     map[string]*Key
+	activeID string
+}
+
+func NewKeySet(active *Key, others ...*Key) (*KeySet, error) {
+	ks := &KeySet{keys: make(map[string]*Key)}
+	for _, key := range append([]*Key{active}, others...) {
+		if err := ks.Add(key); err != nil {
+			return nil, err
+		}
+	}
+	if err := ks.SetActive(active.ID); err != nil {
+		return nil, err
+	}
+	return ks, nil
+}
+
+// GenerateKeySet returns a KeySet holding a fresh Ed25519 key. Tokens signed
+// with it do not survive a restart, so it is only meant for development.
+func GenerateKeySet() (*KeySet, error) {
+	_, private, err := ed25519.GenerateKey(rand.Reader)
+	if err != nil {
+		return nil, fmt.Errorf("failed to generate signing key: %w", err)
+	}
+
+	key, err := newKey("ephemeral", private)
+	if err != nil {
+		return nil, err
+	}
+	return NewKeySet(key)
+}
+
+// LoadKeySet reads every PEM file in dir, using the file name without its
+// extension as the kid. Private keys can sign; public keys only verify. The
+// key named activeID signs new tokens; if activeID is empty, the private key
+// whose kid sorts last is used, so date-prefixed names rotate naturally.
+func LoadKeySet(dir, activeID string) (*KeySet, error) {
+	paths, err := filepath.Glob(filepath.Join(dir, "*.pem"))
+	if err != nil {
+		return nil, fmt.Errorf("failed to list keys: %w", err)
+	}
+
+	ks := &KeySet{keys: make(map[string]*Key)}
+	var signers []string
+	for _, path := range paths {
+		key, err := loadKey(path)
+		if err != nil {
+			return nil, err
+		}
+		if err := ks.Add(key); err != nil {
+			return nil, err
+		}
+		if key.Private != nil {
+			signers = append(signers, key.ID)
+		}
+	}
+
+	if activeID == "" {
+		if len(signers) == 0 {
+			return nil, fmt.Errorf("no private keys found in %s", dir)
+		}
+		sort.Strings(signers)
+		activeID = signers[len(signers)-1]
+	}
+
+	if err := ks.SetActive(activeID); err != nil {
+		return nil, err
+	}
+	return ks, nil
+}
+
+// Add makes key available for verification.
+func (ks *KeySet) Add(key *Key) error {
+	if key.ID == "" {
+		return fmt.Errorf("key must have an id")
+	}
+
+	ks.mu.Lock()
+	defer ks.mu.Unlock()
+	if _, exists := ks.keys[key.ID]; exists {
+		return fmt.Errorf("duplicate key id %q", key.ID)
+	}
+	ks.keys[key.ID] = key
+	return nil
+}
+
+// SetActive switches signing to the key with the given kid.
+func (ks *KeySet) SetActive(kid string) error {
+	ks.mu.Lock()
+	defer ks.mu.Unlock()
+
+	key, ok := ks.keys[kid]
+	if !ok {
+		return fmt.Errorf("unknown key id %q", kid)
+	}
+	if key.Private == nil {
+		return fmt.Errorf("key %q has no private key and cannot sign", kid)
+	}
+	ks.activeID = kid
+	return nil
+}
+
+func (ks *KeySet) SigningKey() (*Key, error) {
+	ks.mu.RLock()
+	defer ks.mu.RUnlock()
+
+	key, ok := ks.keys[ks.activeID]
+	if !ok {
+		return nil, fmt.Errorf("no active signing key")
+	}
+	return key, nil
+}
+
+func (ks *KeySet) VerificationKey(kid string) (*Key, error) {
+	ks.mu.RLock()
+	defer ks.mu.RUnlock()
+
+	key, ok := ks.keys[kid]
+	if !ok {
+		return nil, fmt.Errorf("unknown key id %q", kid)
+	}
+	return key, nil
+}
+
+func (ks *KeySet) VerificationKeys() []*Key {
+	ks.mu.RLock()
+	defer ks.mu.RUnlock()
+
+	keys := make([]*Key, 0, len(ks.keys))
+	for _, key := range ks.keys {
+		keys = append(keys, key)
+	}
+	sort.Slice(keys, func(i, j int) bool { return keys[i].ID < keys[j].ID })
+	return keys
+}
+
+func loadKey(path string) (*Key, error) {
+	data, err := os.ReadFile(path)
+	if err != nil {
+		return nil, fmt.Errorf("failed to read key %s: %w", path, err)
+	}
+
+	block, _ := pem.Decode(data)
+	if block == nil {
+		return nil, fmt.Errorf("no PEM block in %s", path)
+	}
+
+	kid := strings.TrimSuffix(filepath.Base(path), filepath.Ext(path))
+
+	var parsed any
+	switch block.Type {
+	case "PRIVATE KEY":
+		parsed, err = x509.ParsePKCS8PrivateKey(block.Bytes)
+	case "RSA PRIVATE KEY":
+		parsed, err = x509.ParsePKCS1PrivateKey(block.Bytes)
+	case "PUBLIC KEY":
+		parsed, err = x509.ParsePKIXPublicKey(block.Bytes)
+	default:
+		return nil, fmt.Errorf("unsupported PEM block %q in %s", block.Type, path)
+	}
+	if err != nil {
+		return nil, fmt.Errorf("failed to parse key %s: %w", path, err)
+	}
+
+	return newKey(kid, parsed)
+}
+
+// newKey wraps a parsed private or public key, picking the JWT algorithm
+// from its type.
+func newKey(kid string, k any) (*Key, error) {
+	switch k := k.(type) {
+	case *rsa.PrivateKey:
+		if err := checkRSAKeySize(kid, k.N.BitLen()); err != nil {
+			return nil, err
+		}
+		return &Key{ID: kid, Method: jwt.SigningMethodRS256, Private: k, Public: &k.PublicKey}, nil
+	case *rsa.PublicKey:
+		if err := checkRSAKeySize(kid, k.N.BitLen()); err != nil {
+			return nil, err
+		}
+		return &Key{ID: kid, Method: jwt.SigningMethodRS256, Public: k}, nil
+	case ed25519.PrivateKey:
+		return &Key{ID: kid, Method: jwt.SigningMethodEdDSA, Private: k, Public: k.Public()}, nil
+	case ed25519.PublicKey:
+		return &Key{ID: kid, Method: jwt.SigningMethodEdDSA, Public: k}, nil
+	default:
+		return nil, fmt.Errorf("unsupported key type %T for key %q", k, kid)
+	}
+}
+
+func checkRSAKeySize(kid string, bits int) error {
+	if bits < minRSAKeyBits {
+		return fmt.Errorf("key %q is %d bits, at least %d are required", kid, bits, minRSAKeyBits)
+	}
+	return nil
+}
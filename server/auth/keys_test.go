@@ -0,0 +1,129 @@
+package auth
+
+import (
+	"crypto/ed25519"
+	"crypto/rand"
+	"crypto/rsa"
+	"crypto/x509"
+	"encoding/json"
+	"encoding/pem"
+	"net/http/httptest"
+	"os"
+	"path/filepath"
+	"testing"
+)
+
+func writePEM(t *testing.T, dir, name, blockType string, der []byte) {
+	t.Helper()
+	data := pem.EncodeToMemory(&pem.Block{Type: blockType, Bytes: der})
+	if err := os.WriteFile(filepath.Join(dir, name), data, 0o600); err != nil {
+		t.Fatalf("WriteFile() error = %v", err)
+	}
+}
+
+func writePrivateKey(t *testing.T, dir, name string, key any) {
+	t.Helper()
+	der, err := x509.MarshalPKCS8PrivateKey(key)
+	if err != nil {
+		t.Fatalf("MarshalPKCS8PrivateKey() error = %v", err)
+	}
+	writePEM(t, dir, name, "PRIVATE KEY", der)
+}
+
+func TestLoadKeySetRotation(t *testing.T) {
+	dir := t.TempDir()
+
+	rsaKey, err := rsa.GenerateKey(rand.Reader, 2048)
+	if err != nil {
+		t.Fatalf("rsa.GenerateKey() error = %v", err)
+	}
+	writePrivateKey(t, dir, "2024-01.pem", rsaKey)
+
+	oldKeys, err := LoadKeySet(dir, "")
+	if err != nil {
+		t.Fatalf("LoadKeySet() error = %v", err)
+	}
+	oldPair, err := (&Authenticator{keys: oldKeys}).GenerateTokenPair("user-1", "user@example.com", RoleUser, "session-1")
+	if err != nil {
+		t.Fatalf("GenerateTokenPair() error = %v", err)
+	}
+
+	// Rotate: a newer Ed25519 key signs, the RSA key stays for verification.
+	_, edKey, err := ed25519.GenerateKey(rand.Reader)
+	if err != nil {
+		t.Fatalf("ed25519.GenerateKey() error = %v", err)
+	}
+	writePrivateKey(t, dir, "2024-02.pem", edKey)
+
+	keys, err := LoadKeySet(dir, "")
+	if err != nil {
+		t.Fatalf("LoadKeySet() error = %v", err)
+	}
+	signing, err := keys.SigningKey()
+	if err != nil {
+		t.Fatalf("SigningKey() error = %v", err)
+	}
+	if signing.ID != "2024-02" {
+		t.Errorf("Expected active key 2024-02, got %v", signing.ID)
+	}
+
+	a := &Authenticator{keys: keys}
+	if _, err := a.ValidateToken(oldPair.AccessToken, TokenTypeAccess); err != nil {
+		t.Errorf("Expected token signed by the previous key to verify, got %v", err)
+	}
+
+	newPair, err := a.GenerateTokenPair("user-1", "user@example.com", RoleUser, "session-1")
+	if err != nil {
+		t.Fatalf("GenerateTokenPair() error = %v", err)
+	}
+	if _, err := a.ValidateToken(newPair.AccessToken, TokenTypeAccess); err != nil {
+		t.Errorf("Expected token signed by the active key to verify, got %v", err)
+	}
+	if _, err := (&Authenticator{keys: oldKeys}).ValidateToken(newPair.AccessToken, TokenTypeAccess); err == nil {
+		t.Error("Expected token signed by an unknown key to be rejected")
+	}
+}
+
+func TestLoadKeySetPublicKeyCannotSign(t *testing.T) {
+	dir := t.TempDir()
+
+	public, _, err := ed25519.GenerateKey(rand.Reader)
+	if err != nil {
+		t.Fatalf("ed25519.GenerateKey() error = %v", err)
+	}
+	der, err := x509.MarshalPKIXPublicKey(public)
+	if err != nil {
+		t.Fatalf("MarshalPKIXPublicKey() error = %v", err)
+	}
+	writePEM(t, dir, "retired.pem", "PUBLIC KEY", der)
+
+	if _, err := LoadKeySet(dir, ""); err == nil {
+		t.Error("Expected an error when no private key is available")
+	}
+	if _, err := LoadKeySet(dir, "retired"); err == nil {
+		t.Error("Expected an error when activating a public-only key")
+	}
+}
+
+func TestJWKSHandler(t *testing.T) {
+	keys, err := GenerateKeySet()
+	if err != nil {
+		t.Fatalf("GenerateKeySet() error = %v", err)
+	}
+
+	rec := httptest.NewRecorder()
+	JWKSHandler(keys).ServeHTTP(rec, httptest.NewRequest("GET", JWKSPath, nil))
+
+	var set JWKS
+	if err := json.NewDecoder(rec.Body).Decode(&set); err != nil {
+		t.Fatalf("Decode() error = %v", err)
+	}
+	if len(set.Keys) != 1 {
+		t.Fatalf("Expected 1 key, got %d", len(set.Keys))
+	}
+
+	jwk := set.Keys[0]
+	if jwk.KeyID != "ephemeral" || jwk.KeyType != "OKP" || jwk.Algorithm != "EdDSA" || jwk.X == "" {
+		t.Errorf("Unexpected JWK %+v", jwk)
+	}
+}
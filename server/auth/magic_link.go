@@ -0,0 +1,170 @@
+package auth
+
+import (
+	"context"
+	"fmt"
+	"net/url"
+	"strings"
+	"time"
+
+	"grpc-server/ent"
+	"grpc-server/ent/user"
+	"grpc-server/mail"
+)
+
+const magicLinkExpiry = 15 * time.Minute
+
+// sendMagicLink emails a sign-in link to email. Addresses without an account
+// get a link that creates one if Config.MagicLinkSignup allows it, and
+// nothing otherwise, so callers cannot tell them apart.
+func (a *Authenticator) sendMagicLink(ctx context.Context, email string) error {
+	u, err := a.client.User.
+		Query().
+		Where(emailIs(email)).
+		Only(ctx)
+	switch {
+	case err == nil:
+		return a.mailMagicLink(ctx, u.ID, u.Email, u.Name)
+	case ent.IsNotFound(err):
+		if !a.config.MagicLinkSignup {
+			return nil
+		}
+		// Links for new accounts carry no user ID; consumeMagicLink
+		// creates the account from the address.
+		return a.mailMagicLink(ctx, "", email, "")
+	default:
+		return fmt.Errorf("failed to query user: %w", err)
+	}
+}
+
+// mailMagicLink sends email a link signing into userID, or into a new
+// account if userID is empty.
+func (a *Authenticator) mailMagicLink(ctx context.Context, userID, email, name string) error {
+	token, err := a.generateToken(Claims{
+		UserID:    userID,
+		Email:     email,
+		TokenType: TokenTypeMagicLink,
+	}, magicLinkExpiry)
+	if err != nil {
+		return fmt.Errorf("failed to generate magic link token: %w", err)
+	}
+
+	greeting := "Hi"
+	if name != "" {
+		greeting += " " + name
+	}
+	link := a.config.AppURL + "/magic-link?token=" + url.QueryEscape(token)
+	err = a.config.Mailer.Send(ctx, mail.Message{
+		To:      email,
+		Subject: "Your sign-in link",
+		Body: fmt.Sprintf("%s,\n\n"+
+			"Open the link below to sign in:\n\n%s\n\n"+
+			"The link expires in %d minutes and can only be used once. If you did not ask for it, you can ignore this email.\n",
+			greeting, link, int(magicLinkExpiry.Minutes())),
+	})
+	if err != nil {
+		return fmt.Errorf("failed to send magic link email: %w", err)
+	}
+	return nil
+}
+
+// consumeMagicLink spends token and returns the user it signs in. Opening
+// the link proves the user controls the address, so it is marked verified.
+func (a *Authenticator) consumeMagicLink(ctx context.Context, token string) (*ent.User, error) {
+	claims, err := a.consumeToken(ctx, token, TokenTypeMagicLink)
+	if err != nil {
+		return nil, err
+	}
+	auditFromContext(ctx).setEmail(claims.Email)
+
+	var u *ent.User
+	if claims.UserID == "" {
+		u, err = a.magicLinkUser(ctx, claims.Email)
+	} else {
+		u, err = a.client.User.Get(ctx, claims.UserID)
+		if ent.IsNotFound(err) {
+			return nil, fmt.Errorf("%w: user no longer exists", ErrInvalidToken)
+		}
+	}
+	if err != nil {
+		return nil, err
+	}
+	auditFromContext(ctx).setUser(u.ID)
+
+	// The link only vouches for the address it was sent to.
+	if !strings.EqualFold(u.Email, claims.Email) {
+		return nil, fmt.Errorf("%w: email address has changed", ErrInvalidToken)
+	}
+
+	if u.EmailVerifiedAt == nil {
+		return a.claimMagicLinkUser(ctx, u.ID)
+	}
+	return u, nil
+}
+
+// claimMagicLinkUser verifies the address of userID for whoever opened the
+// link, taking the account from whoever registered it without doing so.
+func (a *Authenticator) claimMagicLinkUser(ctx context.Context, userID string) (*ent.User, error) {
+	tx, err := a.client.Tx(ctx)
+	if err != nil {
+		return nil, fmt.Errorf("failed to start transaction: %w", err)
+	}
+
+	u, err := tx.User.
+		Query().
+		Where(user.IDEQ(userID)).
+		ForUpdate().
+		Only(ctx)
+	if err != nil {
+		_ = tx.Rollback()
+		return nil, fmt.Errorf("failed to query user: %w", err)
+	}
+	// Someone else may have verified the address meanwhile.
+	if u.EmailVerifiedAt == nil {
+		u, err = a.claimAddressTx(ctx, tx, u)
+		if err != nil {
+			_ = tx.Rollback()
+			return nil, err
+		}
+	}
+
+	if err := tx.Commit(); err != nil {
+		return nil, fmt.Errorf("failed to commit transaction: %w", err)
+	}
+	return u.Unwrap(), nil
+}
+
+// magicLinkUser returns the user with email, creating them if they don't
+// exist yet. Someone may have registered the address since the link was sent,
+// in which case the link signs into that account.
+func (a *Authenticator) magicLinkUser(ctx context.Context, email string) (*ent.User, error) {
+	if !a.config.MagicLinkSignup {
+		return nil, fmt.Errorf("%w: sign-up is disabled", ErrInvalidToken)
+	}
+
+	// Users created here sign in with links; they can set a password later
+	// through the password reset flow. Opening the link verified the address.
+	passwordHash, err := a.unusablePasswordHash()
+	if err != nil {
+		return nil, err
+	}
+	name, _, _ := strings.Cut(email, "@")
+
+	u, err := a.client.User.
+		Create().
+		SetEmail(email).
+		SetName(name).
+		SetPasswordHash(passwordHash).
+		SetEmailVerifiedAt(time.Now()).
+		Save(ctx)
+	if ent.IsConstraintError(err) {
+		u, err = a.client.User.
+			Query().
+			Where(emailIs(email)).
+			Only(ctx)
+	}
+	if err != nil {
+		return nil, fmt.Errorf("failed to create user: %w", err)
+	}
+	return u, nil
+}
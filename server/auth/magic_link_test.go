@@ -0,0 +1,97 @@
+package auth
+
+import (
+	"context"
+	"net/url"
+	"regexp"
+	"slices"
+	"strings"
+	"testing"
+	"time"
+
+	"grpc-server/ent/user"
+	"grpc-server/mail"
+)
+
+func TestMailMagicLink(t *testing.T) {
+	tests := []struct {
+		name   string
+		userID string
+	}{
+		{"existing user", "user-1"},
+		{"new account", ""},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			a := newTestAuthenticator(t)
+			mailer := mail.NewMemoryMailer()
+			a.config = Config{Mailer: mailer, AppURL: "http://app.test"}
+
+			if err := a.mailMagicLink(context.Background(), tt.userID, "user@example.com", "User"); err != nil {
+				t.Fatalf("mailMagicLink() error = %v", err)
+			}
+
+			messages := mailer.Messages()
+			if len(messages) != 1 {
+				t.Fatalf("Expected 1 message, got %d", len(messages))
+			}
+			match := regexp.MustCompile(`http://app\.test/magic-link\?token=(\S+)`).FindStringSubmatch(messages[0].Body)
+			if match == nil {
+				t.Fatalf("Expected a sign-in link in %q", messages[0].Body)
+			}
+			token, err := url.QueryUnescape(match[1])
+			if err != nil {
+				t.Fatalf("QueryUnescape() error = %v", err)
+			}
+
+			claims, err := a.ValidateToken(token, TokenTypeMagicLink)
+			if err != nil {
+				t.Fatalf("ValidateToken() error = %v", err)
+			}
+			if claims.UserID != tt.userID || claims.Email != "user@example.com" {
+				t.Errorf("Unexpected claims %+v", claims)
+			}
+			if claims.ExpiresAt.After(time.Now().Add(magicLinkExpiry)) {
+				t.Errorf("Expected the link to expire within %v, got %v", magicLinkExpiry, claims.ExpiresAt)
+			}
+
+			for _, other := range []TokenType{TokenTypeAccess, TokenTypeEmailVerification, TokenTypeMFAChallenge} {
+				if _, err := a.ValidateToken(token, other); err == nil {
+					t.Errorf("Expected a magic link token to be rejected as a %s token", other)
+				}
+			}
+		})
+	}
+}
+
+func TestConsumeMagicLinkClaimsUnverifiedAccount(t *testing.T) {
+	s, drv := newTestServer(t)
+	a := s.authenticator
+	oldHash := userResult(t, a, "squatter-password")
+	drv.results = []fakeResult{oldHash, oldHash, oldHash, oldHash}
+
+	token, err := a.generateToken(Claims{
+		UserID:    "user-1",
+		Email:     "user@example.com",
+		TokenType: TokenTypeMagicLink,
+	}, magicLinkExpiry)
+	if err != nil {
+		t.Fatalf("generateToken() error = %v", err)
+	}
+	if _, err := a.consumeMagicLink(context.Background(), token); err != nil {
+		t.Fatalf("consumeMagicLink() error = %v", err)
+	}
+
+	updates := drv.find(`UPDATE "users"`)
+	if len(updates) == 0 {
+		t.Fatal("Expected the user to be updated")
+	}
+	hashColumn := slices.Index(user.Columns, user.FieldPasswordHash)
+	if !strings.Contains(updates[0].query, `"password_hash"`) || slices.Contains(updates[0].args, oldHash.rows[0][hashColumn]) {
+		t.Error("Expected the password set before verification to be replaced")
+	}
+	if len(drv.find(`UPDATE "sessions"`)) == 0 {
+		t.Error("Expected the sessions of the account to be revoked")
+	}
+}
@@ -0,0 +1,56 @@
+package auth
+
+import (
+	"context"
+	"log"
+)
+
+const (
+	mailWorkers   = 4
+	mailQueueSize = 256
+)
+
+// mailJob is an email to send in the background, e.g. the lookup and the
+// message of a password reset.
+type mailJob struct {
+	ctx  context.Context
+	name string
+	send func(context.Context) error
+}
+
+// mailQueue sends emails with a fixed number of workers, so a flood of
+// requests cannot pile up goroutines or connections to the mail server.
+type mailQueue struct {
+	jobs chan mailJob
+}
+
+func newMailQueue(size int) *mailQueue {
+	return &mailQueue{jobs: make(chan mailJob, size)}
+}
+
+// enqueue schedules send to run with ctx, without its cancellation, and
+// reports whether it was queued. A full queue drops the email rather than
+// hold up the request; name prefixes the log messages about it.
+func (q *mailQueue) enqueue(ctx context.Context, name string, send func(context.Context) error) bool {
+	select {
+	case q.jobs <- mailJob{ctx: context.WithoutCancel(ctx), name: name, send: send}:
+		return true
+	default:
+		log.Printf("%s: mail queue is full, dropping email", name)
+		return false
+	}
+}
+
+// run sends queued emails until ctx is done.
+func (q *mailQueue) run(ctx context.Context) {
+	for {
+		select {
+		case <-ctx.Done():
+			return
+		case job := <-q.jobs:
+			if err := job.send(job.ctx); err != nil {
+				log.Printf("%s: %v", job.name, err)
+			}
+		}
+	}
+}
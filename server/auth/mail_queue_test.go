@@ -0,0 +1,45 @@
+package auth
+
+import (
+	"context"
+	"testing"
+	"time"
+)
+
+func TestMailQueueDropsWhenFull(t *testing.T) {
+	q := newMailQueue(1)
+	send := func(context.Context) error { return nil }
+
+	if !q.enqueue(context.Background(), "test", send) {
+		t.Fatalf("Expected the first email to be queued")
+	}
+	if q.enqueue(context.Background(), "test", send) {
+		t.Errorf("Expected an email to be dropped when the queue is full")
+	}
+}
+
+func TestMailQueueRun(t *testing.T) {
+	q := newMailQueue(mailQueueSize)
+	ctx, cancel := context.WithCancel(context.Background())
+	defer cancel()
+	go q.run(ctx)
+
+	// The request may be over by the time the email is sent.
+	requestCtx, cancelRequest := context.WithCancel(context.Background())
+	cancelRequest()
+
+	sent := make(chan error, 1)
+	q.enqueue(requestCtx, "test", func(ctx context.Context) error {
+		sent <- ctx.Err()
+		return nil
+	})
+
+	select {
+	case err := <-sent:
+		if err != nil {
+			t.Errorf("Expected the email to outlive its request, got %v", err)
+		}
+	case <-time.After(time.Second):
+		t.Fatalf("Expected the queued email to be sent")
+	}
+}
@@ -2,19 +2,57 @@ package auth
 
 import (
 	"grpc-server/ent"
+	"grpc-server/ent/authevent"
 	protoAuth "grpc-server/proto-generated/auth"
 	"grpc-server/proto-generated/user"
 
 	"google.golang.org/protobuf/types/known/timestamppb"
 )
 
-func entUserToProto(u *ent.User) *user.User {
-	return &user.User{
-		Id:        u.ID,
-		Email:     u.Email,
-		Name:      u.Name,
-		CreatedAt: timestamppb.New(u.CreatedAt),
-		UpdatedAt: timestamppb.New(u.UpdatedAt),
+// EntUserToProto converts a user to its proto message.
+func EntUserToProto(u *ent.User) *user.User {
+	pbUser := &user.User{
+		Id:          u.ID,
+		Email:       u.Email,
+		Name:        u.Name,
+		CreatedAt:   timestamppb.New(u.CreatedAt),
+		UpdatedAt:   timestamppb.New(u.UpdatedAt),
+		Role:        RoleToProto(Role(u.Role)),
+		DisplayName: u.DisplayName,
+		Bio:         u.Bio,
+		Locale:      u.Locale,
+		Timezone:    u.Timezone,
+		AvatarUrl:   u.AvatarURL,
+		Permissions: u.Permissions,
+	}
+	if u.EmailVerifiedAt != nil {
+		pbUser.EmailVerifiedAt = timestamppb.New(*u.EmailVerifiedAt)
+	}
+	return pbUser
+}
+
+// RoleToProto converts a role to its proto enum.
+func RoleToProto(role Role) user.Role {
+	switch role {
+	case RoleUser:
+		return user.Role_ROLE_USER
+	case RoleAdmin:
+		return user.Role_ROLE_ADMIN
+	default:
+		return user.Role_ROLE_UNSPECIFIED
+	}
+}
+
+// RoleFromProto converts a proto enum to a role. It returns an empty role
+// for values that don't name one.
+func RoleFromProto(role user.Role) Role {
+	switch role {
+	case user.Role_ROLE_USER:
+		return RoleUser
+	case user.Role_ROLE_ADMIN:
+		return RoleAdmin
+	default:
+		return ""
 	}
 }
 
@@ -25,3 +63,56 @@ func tokenPairToProto(tp *TokenPair) *protoAuth.TokenPair {
 		ExpiresAt:    timestamppb.New(tp.AccessTokenExpiry),
 	}
 }
+
+func entSessionToProto(s *ent.Session, currentID string) *protoAuth.Session {
+	return &protoAuth.Session{
+		Id:         s.ID,
+		UserAgent:  s.UserAgent,
+		IpAddress:  s.IPAddress,
+		CreatedAt:  timestamppb.New(s.CreatedAt),
+		LastSeenAt: timestamppb.New(s.LastSeenAt),
+		Current:    s.ID == currentID,
+	}
+}
+
+func entAuthEventToProto(e *ent.AuthEvent) *protoAuth.AuthEvent {
+	return &protoAuth.AuthEvent{
+		Id:        e.ID,
+		Type:      e.Type,
+		Procedure: e.Procedure,
+		UserId:    e.UserID,
+		ActorId:   e.ActorID,
+		Principal: e.Principal,
+		Email:     e.Email,
+		IpAddress: e.IPAddress,
+		UserAgent: e.UserAgent,
+		Outcome:   AuthEventOutcomeToProto(e.Outcome),
+		Reason:    e.Reason,
+		CreatedAt: timestamppb.New(e.CreatedAt),
+	}
+}
+
+// AuthEventOutcomeToProto converts an outcome to its proto enum.
+func AuthEventOutcomeToProto(outcome authevent.Outcome) protoAuth.AuthEvent_Outcome {
+	switch outcome {
+	case authevent.OutcomeSuccess:
+		return protoAuth.AuthEvent_OUTCOME_SUCCESS
+	case authevent.OutcomeFailure:
+		return protoAuth.AuthEvent_OUTCOME_FAILURE
+	default:
+		return protoAuth.AuthEvent_OUTCOME_UNSPECIFIED
+	}
+}
+
+// AuthEventOutcomeFromProto converts a proto enum to an outcome. It returns
+// an empty outcome for values that don't name one.
+func AuthEventOutcomeFromProto(outcome protoAuth.AuthEvent_Outcome) authevent.Outcome {
+	switch outcome {
+	case protoAuth.AuthEvent_OUTCOME_SUCCESS:
+		return authevent.OutcomeSuccess
+	case protoAuth.AuthEvent_OUTCOME_FAILURE:
+		return authevent.OutcomeFailure
+	default:
+		return ""
+	}
+}
@@ -0,0 +1,232 @@
+package auth
+
+import (
+	"context"
+	"crypto/rand"
+	"crypto/subtle"
+	"encoding/base32"
+	"fmt"
+	"strings"
+	"time"
+
+	"grpc-server/ent"
+	"grpc-server/ent/totpfactor"
+	"grpc-server/ent/user"
+	"grpc-server/pkg/otp"
+)
+
+const (
+	totpIssuer         = "grpc-server"
+	totpSkew           = 1
+	mfaChallengeExpiry = 5 * time.Minute
+	recoveryCodeCount  = 10
+	recoveryCodeBytes  = 8
+)
+
+// mfaEnabled reports whether userID has a confirmed authenticator app.
+func (a *Authenticator) mfaEnabled(ctx context.Context, userID string) (bool, error) {
+	enabled, err := a.client.TOTPFactor.
+		Query().
+		Where(
+			totpfactor.HasUserWith(user.IDEQ(userID)),
+			totpfactor.ConfirmedAtNotNil(),
+		).
+		Exist(ctx)
+	if err != nil {
+		return false, fmt.Errorf("failed to check MFA: %w", err)
+	}
+	return enabled, nil
+}
+
+// generateMFAChallenge returns the token Login hands out in place of a token
+// pair when the password was right but a second factor is still needed.
+func (a *Authenticator) generateMFAChallenge(u *ent.User) (string, error) {
+	return a.generateToken(Claims{
+		UserID:    u.ID,
+		Email:     u.Email,
+		TokenType: TokenTypeMFAChallenge,
+	}, mfaChallengeExpiry)
+}
+
+// enrollTOTP replaces any unconfirmed enrollment of u with a new secret.
+func (a *Authenticator) enrollTOTP(ctx context.Context, u *ent.User) (secret, uri string, err error) {
+	enabled, err := a.mfaEnabled(ctx, u.ID)
+	if err != nil {
+		return "", "", err
+	}
+	if enabled {
+		return "", "", ErrMFAAlreadyEnabled
+	}
+
+	secret, err = otp.GenerateSecret()
+	if err != nil {
+		return "", "", err
+	}
+
+	tx, err := a.client.Tx(ctx)
+	if err != nil {
+		return "", "", fmt.Errorf("failed to start transaction: %w", err)
+	}
+
+	_, err = tx.TOTPFactor.
+		Delete().
+		Where(totpfactor.HasUserWith(user.IDEQ(u.ID))).
+		Exec(ctx)
+	if err == nil {
+		err = tx.TOTPFactor.
+			Create().
+			SetSecret(secret).
+			SetUser(u).
+			Exec(ctx)
+	}
+	if err != nil {
+		_ = tx.Rollback()
+		return "", "", fmt.Errorf("failed to store TOTP enrollment: %w", err)
+	}
+
+	if err := tx.Commit(); err != nil {
+		return "", "", fmt.Errorf("failed to commit transaction: %w", err)
+	}
+
+	return secret, otp.URI(totpIssuer, u.Email, secret), nil
+}
+
+// confirmTOTP enables the pending enrollment of userID if code is valid and
+// returns freshly generated recovery codes.
+func (a *Authenticator) confirmTOTP(ctx context.Context, userID, code string) ([]string, error) {
+	var recoveryCodes []string
+
+	err := a.withTOTPFactor(ctx, userID, func(tx *ent.Tx, factor *ent.TOTPFactor) error {
+		if factor.ConfirmedAt != nil {
+			return ErrMFAAlreadyEnabled
+		}
+
+		step, ok := otp.Validate(factor.Secret, code, time.Now(), totpSkew)
+		if !ok {
+			return ErrInvalidMFACode
+		}
+
+		codes, hashes, err := generateRecoveryCodes()
+		if err != nil {
+			return err
+		}
+		recoveryCodes = codes
+
+		return tx.TOTPFactor.
+			UpdateOne(factor).
+			SetConfirmedAt(time.Now()).
+			SetLastUsedStep(step).
+			SetRecoveryCodeHashes(hashes).
+			Exec(ctx)
+	})
+	if err != nil {
+		return nil, err
+	}
+	return recoveryCodes, nil
+}
+
+// verifySecondFactor checks code against the confirmed enrollment of
+// userID. A TOTP code is accepted once per time step and a recovery code is
+// spent on use.
+func (a *Authenticator) verifySecondFactor(ctx context.Context, userID, code string) error {
+	return a.withTOTPFactor(ctx, userID, func(tx *ent.Tx, factor *ent.TOTPFactor) error {
+		if factor.ConfirmedAt == nil {
+			return ErrMFANotEnabled
+		}
+		return useSecondFactor(ctx, tx, factor, code)
+	})
+}
+
+// disableTOTP removes the enrollment of userID after checking code.
+func (a *Authenticator) disableTOTP(ctx context.Context, userID, code string) error {
+	return a.withTOTPFactor(ctx, userID, func(tx *ent.Tx, factor *ent.TOTPFactor) error {
+		if factor.ConfirmedAt == nil {
+			return ErrMFANotEnabled
+		}
+		if err := useSecondFactor(ctx, tx, factor, code); err != nil {
+			return err
+		}
+		return tx.TOTPFactor.DeleteOne(factor).Exec(ctx)
+	})
+}
+
+func useSecondFactor(ctx context.Context, tx *ent.Tx, factor *ent.TOTPFactor, code string) error {
+	if step, ok := otp.Validate(factor.Secret, code, time.Now(), totpSkew); ok {
+		if step <= factor.LastUsedStep {
+			return ErrInvalidMFACode
+		}
+		return tx.TOTPFactor.
+			UpdateOne(factor).
+			SetLastUsedStep(step).
+			Exec(ctx)
+	}
+
+	codeHash := hashToken(normalizeRecoveryCode(code))
+	for i, hash := range factor.RecoveryCodeHashes {
+		if subtle.ConstantTimeCompare([]byte(hash), []byte(codeHash)) == 1 {
+			remaining := append(append([]string{}, factor.RecoveryCodeHashes[:i]...), factor.RecoveryCodeHashes[i+1:]...)
+			return tx.TOTPFactor.
+				UpdateOne(factor).
+				SetRecoveryCodeHashes(remaining).
+				Exec(ctx)
+		}
+	}
+
+	return ErrInvalidMFACode
+}
+
+// withTOTPFactor runs fn in a transaction holding a lock on the enrollment
+// of userID, so concurrent requests cannot both spend the same code.
+func (a *Authenticator) withTOTPFactor(ctx context.Context, userID string, fn func(*ent.Tx, *ent.TOTPFactor) error) error {
+	tx, err := a.client.Tx(ctx)
+	if err != nil {
+		return fmt.Errorf("failed to start transaction: %w", err)
+	}
+
+	factor, err := tx.TOTPFactor.
+		Query().
+		Where(totpfactor.HasUserWith(user.IDEQ(userID))).
+		ForUpdate().
+		Only(ctx)
+	if err != nil {
+		_ = tx.Rollback()
+		if ent.IsNotFound(err) {
+			return ErrMFANotEnabled
+		}
+		return fmt.Errorf("failed to query TOTP enrollment: %w", err)
+	}
+
+	if err := fn(tx, factor); err != nil {
+		_ = tx.Rollback()
+		return err
+	}
+
+	if err := tx.Commit(); err != nil {
+		return fmt.Errorf("failed to commit transaction: %w", err)
+	}
+	return nil
+}
+
+// generateRecoveryCodes returns codes to show the user once and the hashes
+// to store in their place.
+func generateRecoveryCodes() (codes, hashes []string, err error) {
+	for i := 0; i < recoveryCodeCount; i++ {
+		b := make([]byte, recoveryCodeBytes)
+		if _, err := rand.Read(b); err != nil {
+			return nil, nil, fmt.Errorf("failed to generate recovery code: %w", err)
+		}
+		// Base32 avoids characters that are easy to mistype; ten of them
+		// carry 50 bits of entropy.
+		encoded := strings.ToLower(base32.StdEncoding.EncodeToString(b))
+		code := encoded[:5] + "-" + encoded[5:10]
+		codes = append(codes, code)
+		hashes = append(hashes, hashToken(normalizeRecoveryCode(code)))
+	}
+	return codes, hashes, nil
+}
+
+// normalizeRecoveryCode makes recovery codes forgiving of case and dashes.
+func normalizeRecoveryCode(code string) string {
+	code = strings.ToLower(strings.TrimSpace(code))
+	return strings.NewReplacer("-", "", "_", "", " ", "").Replace(code)
+}
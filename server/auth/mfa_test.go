@@ -0,0 +1,49 @@
+package auth
+
+import "testing"
+
+func TestGenerateRecoveryCodes(t *testing.T) {
+	codes, hashes, err := generateRecoveryCodes()
+	if err != nil {
+		t.Fatalf("Failed to generate recovery codes: %v", err)
+	}
+	if len(codes) != recoveryCodeCount || len(hashes) != recoveryCodeCount {
+		t.Fatalf("Expected %d codes and hashes, got %d and %d", recoveryCodeCount, len(codes), len(hashes))
+	}
+
+	seen := make(map[string]bool)
+	for i, code := range codes {
+		if len(code) != 11 || code[5] != '-' {
+			t.Errorf("Expected code in xxxxx-xxxxx format, got %q", code)
+		}
+		if seen[code] {
+			t.Errorf("Expected unique codes, got %q twice", code)
+		}
+		seen[code] = true
+
+		if hashes[i] == code {
+			t.Errorf("Expected code to be stored hashed")
+		}
+		if hashToken(normalizeRecoveryCode(code)) != hashes[i] {
+			t.Errorf("Expected hash of %q to match stored hash", code)
+		}
+	}
+}
+
+func TestNormalizeRecoveryCode(t *testing.T) {
+	tests := []struct {
+		input    string
+		expected string
+	}{
+		{"abcde-fghij", "abcdefghij"},
+		{"ABCDE-FGHIJ", "abcdefghij"},
+		{" abcde fghij ", "abcdefghij"},
+		{"abcdefghij", "abcdefghij"},
+	}
+
+	for _, tt := range tests {
+		if got := normalizeRecoveryCode(tt.input); got != tt.expected {
+			t.Errorf("Expected normalizeRecoveryCode(%q) = %q, got %q", tt.input, tt.expected, got)
+		}
+	}
+}
@@ -2,19 +2,55 @@ package auth
 
 import (
 	"context"
+	"errors"
+	"fmt"
+	"log"
 	"net/http"
 	"strings"
 )
 
 type contextKey string
 
-const UserIDContextKey contextKey = "user_id"
+const (
+	UserIDContextKey    contextKey = "user_id"
+	SessionIDContextKey contextKey = "session_id"
+	APIKeyIDContextKey  contextKey = "api_key_id"
+	RoleContextKey      contextKey = "role"
+	// PermissionsContextKey holds the []Permission the user holds on top
+	// of their role.
+	PermissionsContextKey contextKey = "permissions"
+	ScopesContextKey      contextKey = "scopes"
+	ActorIDContextKey     contextKey = "actor_id"
+	// ServicePrincipalContextKey holds the *ServicePrincipal of a request
+	// made with a client certificate.
+	ServicePrincipalContextKey contextKey = "service_principal"
+)
 
-// Middleware validates JWT tokens and adds user information to the request context
-func Middleware(next http.Handler) http.Handler {
+// Middleware validates JWT tokens and adds user information to the request context.
+// Requests made with a client certificate also carry the service principal
+// it maps to; a service may add a user token to act on that user's behalf,
+// and otherwise acts as its owner, if it has one.
+func (a *Authenticator) Middleware(next http.Handler) http.Handler {
 	return http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		principal, err := a.servicePrincipal(r)
+		if err != nil {
+			a.recordAuthenticationFailure(r, err)
+			http.Error(w, "Unknown client certificate", http.StatusUnauthorized)
+			return
+		}
+		if principal != nil {
+			r = r.WithContext(context.WithValue(r.Context(), ServicePrincipalContextKey, principal))
+		}
+
 		authHeader := r.Header.Get("Authorization")
 		if authHeader == "" {
+			if a.config.Cookies.Enabled && hasSessionCookie(r) {
+				a.serveCookie(w, r, next)
+				return
+			}
+			if principal != nil && principal.Owner != "" {
+				r = r.WithContext(contextWithServiceOwner(r.Context(), principal))
+			}
 			// Allow unauthenticated requests to pass through
 			// Individual handlers can check if user is authenticated
 			next.ServeHTTP(w, r)
@@ -23,29 +59,149 @@ func Middleware(next http.Handler) http.Handler {
 
 		parts := strings.Split(authHeader, " ")
 		if len(parts) != 2 || parts[0] != "Bearer" {
+			a.recordAuthenticationFailure(r, fmt.Errorf("invalid authorization header format"))
 			http.Error(w, "Invalid authorization header format", http.StatusUnauthorized)
 			return
 		}
 
 		token := parts[1]
-		claims, err := ValidateToken(token)
+		if isAPIKey(token) {
+			a.serveAPIKey(w, r, next, token)
+			return
+		}
+
+		claims, err := a.ValidateAccessToken(token)
 		if err != nil {
+			a.recordAuthenticationFailure(r, err)
 			http.Error(w, "Invalid or expired token", http.StatusUnauthorized)
 			return
 		}
 
-		// Add user ID to context
-		ctx := context.WithValue(r.Context(), UserIDContextKey, claims.UserID)
-		next.ServeHTTP(w, r.WithContext(ctx))
+		next.ServeHTTP(w, r.WithContext(contextWithClaims(r.Context(), claims)))
+	})
+}
+
+// contextWithClaims adds the user ID, session ID and role of claims to ctx.
+func contextWithClaims(ctx context.Context, claims *Claims) context.Context {
+	ctx = context.WithValue(ctx, UserIDContextKey, claims.UserID)
+	ctx = context.WithValue(ctx, SessionIDContextKey, claims.SessionID)
+	ctx = context.WithValue(ctx, RoleContextKey, claims.Role)
+	ctx = context.WithValue(ctx, PermissionsContextKey, claims.Permissions)
+	if claims.Actor != nil {
+		ctx = context.WithValue(ctx, ActorIDContextKey, claims.Actor.Subject)
+	}
+	return ctx
+}
+
+// serveCookie authenticates a browser request by its session cookies. The
+// browser attaches them to requests from any site, so calls that may change
+// state must also prove they come from our client with the CSRF token.
+func (a *Authenticator) serveCookie(w http.ResponseWriter, r *http.Request, next http.Handler) {
+	if err := checkCSRF(r); err != nil {
+		a.recordAuthenticationFailure(r, err)
+		http.Error(w, "Invalid CSRF token", http.StatusForbidden)
+		return
+	}
+
+	cookie, err := r.Cookie(AccessTokenCookie)
+	if err != nil {
+		// Only the refresh token is left; the client has to call
+		// RefreshToken, which reads it from its own cookie.
+		next.ServeHTTP(w, r)
+		return
+	}
+
+	// An expired cookie is routine for a browser coming back, so it is
+	// treated like a missing one instead of failing calls that don't need
+	// a user, such as RefreshToken.
+	claims, err := a.ValidateAccessToken(cookie.Value)
+	if err != nil {
+		next.ServeHTTP(w, r)
+		return
+	}
+	next.ServeHTTP(w, r.WithContext(contextWithClaims(r.Context(), claims)))
+}
+
+// serveAPIKey authenticates a request made with an API key. The interceptor
+// then checks the key's scopes against the procedure.
+func (a *Authenticator) serveAPIKey(w http.ResponseWriter, r *http.Request, next http.Handler, secret string) {
+	key, err := a.authenticateAPIKey(r.Context(), secret)
+	if err != nil {
+		if errors.Is(err, ErrInvalidAPIKey) {
+			a.recordAuthenticationFailure(r, err)
+			http.Error(w, "Invalid or expired API key", http.StatusUnauthorized)
+			return
+		}
+		log.Printf("api key: %v", err)
+		http.Error(w, "Failed to authenticate API key", http.StatusInternalServerError)
+		return
+	}
+
+	// API keys act with the permissions of a regular user, whatever the
+	// role of their owner, so a leaked key can't be used to administer.
+	ctx := context.WithValue(r.Context(), UserIDContextKey, key.Edges.User.ID)
+	ctx = context.WithValue(ctx, APIKeyIDContextKey, key.ID)
+	ctx = context.WithValue(ctx, RoleContextKey, RoleUser)
+	ctx = context.WithValue(ctx, ScopesContextKey, key.Scopes)
+	next.ServeHTTP(w, r.WithContext(ctx))
+}
+
+// recordAuthenticationFailure records a request rejected for its credentials.
+func (a *Authenticator) recordAuthenticationFailure(r *http.Request, err error) {
+	a.recordAuthEvent(r.Context(), authEvent{
+		Type:      AuthEventAuthenticate,
+		Procedure: r.URL.Path,
+		Client:    httpSessionInfo(r),
+		Err:       err,
 	})
 }
 
-// GetUserIDFromContext retrieves the user ID from the context
+// GetUserIDFromContext retrieves the user ID from the context. When an admin
+// impersonates a user, this is the impersonated user; GetActorIDFromContext
+// returns the admin.
 func GetUserIDFromContext(ctx context.Context) (string, bool) {
 	userID, ok := ctx.Value(UserIDContextKey).(string)
 	return userID, ok
 }
 
+// GetActorIDFromContext retrieves the admin acting as the user when the
+// request was made with an impersonation token
+func GetActorIDFromContext(ctx context.Context) (string, bool) {
+	actorID, ok := ctx.Value(ActorIDContextKey).(string)
+	return actorID, ok && actorID != ""
+}
+
+// GetSessionIDFromContext retrieves the session the request's token belongs to
+func GetSessionIDFromContext(ctx context.Context) (string, bool) {
+	sessionID, ok := ctx.Value(SessionIDContextKey).(string)
+	return sessionID, ok && sessionID != ""
+}
+
+// GetAPIKeyIDFromContext retrieves the API key the request was made with
+func GetAPIKeyIDFromContext(ctx context.Context) (string, bool) {
+	keyID, ok := ctx.Value(APIKeyIDContextKey).(string)
+	return keyID, ok
+}
+
+// GetScopesFromContext retrieves the scopes of the API key the request was made with
+func GetScopesFromContext(ctx context.Context) ([]string, bool) {
+	scopes, ok := ctx.Value(ScopesContextKey).([]string)
+	return scopes, ok
+}
+
+// GetRoleFromContext retrieves the role of the authenticated user
+func GetRoleFromContext(ctx context.Context) (Role, bool) {
+	role, ok := ctx.Value(RoleContextKey).(Role)
+	return role, ok
+}
+
+// GetPermissionsFromContext retrieves the permissions the user holds on top
+// of their role
+func GetPermissionsFromContext(ctx context.Context) ([]Permission, bool) {
+	permissions, ok := ctx.Value(PermissionsContextKey).([]Permission)
+	return permissions, ok
+}
+
 // RequireAuth is a helper to check if a user is authenticated
 func RequireAuth(ctx context.Context) (string, error) {
 	userID, ok := GetUserIDFromContext(ctx)
@@ -0,0 +1,267 @@
+package auth
+
+import (
+	"crypto/ecdsa"
+	"crypto/elliptic"
+	"crypto/rand"
+	"crypto/tls"
+	"crypto/x509"
+	"crypto/x509/pkix"
+	"math/big"
+	"net"
+	"net/http"
+	"net/http/httptest"
+	"net/url"
+	"testing"
+	"time"
+
+	"grpc-server/proto-generated/authz"
+)
+
+// testCA issues certificates for the mutual TLS tests.
+type testCA struct {
+	cert *x509.Certificate
+	key  *ecdsa.PrivateKey
+}
+
+func newTestCA(t *testing.T) *testCA {
+	t.Helper()
+	key, err := ecdsa.GenerateKey(elliptic.P256(), rand.Reader)
+	if err != nil {
+		t.Fatalf("GenerateKey() error = %v", err)
+	}
+	template := &x509.Certificate{
+		SerialNumber:          big.NewInt(1),
+		Subject:               pkix.Name{CommonName: "test CA"},
+		NotBefore:             time.Now().Add(-time.Hour),
+		NotAfter:              time.Now().Add(time.Hour),
+		KeyUsage:              x509.KeyUsageCertSign,
+		BasicConstraintsValid: true,
+		IsCA:                  true,
+	}
+	der, err := x509.CreateCertificate(rand.Reader, template, template, &key.PublicKey, key)
+	if err != nil {
+		t.Fatalf("CreateCertificate() error = %v", err)
+	}
+	cert, err := x509.ParseCertificate(der)
+	if err != nil {
+		t.Fatalf("ParseCertificate() error = %v", err)
+	}
+	return &testCA{cert: cert, key: key}
+}
+
+func (ca *testCA) pool() *x509.CertPool {
+	pool := x509.NewCertPool()
+	pool.AddCert(ca.cert)
+	return pool
+}
+
+// issue returns a certificate for template's names, signed by ca.
+func (ca *testCA) issue(t *testing.T, template *x509.Certificate) tls.Certificate {
+	t.Helper()
+	key, err := ecdsa.GenerateKey(elliptic.P256(), rand.Reader)
+	if err != nil {
+		t.Fatalf("GenerateKey() error = %v", err)
+	}
+	serial, err := rand.Int(rand.Reader, big.NewInt(1<<62))
+	if err != nil {
+		t.Fatalf("rand.Int() error = %v", err)
+	}
+	template.SerialNumber = serial
+	template.NotBefore = time.Now().Add(-time.Hour)
+	template.NotAfter = time.Now().Add(time.Hour)
+	template.KeyUsage = x509.KeyUsageDigitalSignature
+	template.ExtKeyUsage = []x509.ExtKeyUsage{x509.ExtKeyUsageServerAuth, x509.ExtKeyUsageClientAuth}
+
+	der, err := x509.CreateCertificate(rand.Reader, template, ca.cert, &key.PublicKey, ca.key)
+	if err != nil {
+		t.Fatalf("CreateCertificate() error = %v", err)
+	}
+	return tls.Certificate{Certificate: [][]byte{der}, PrivateKey: key}
+}
+
+func spiffeCert(id string) *x509.Certificate {
+	uri, _ := url.Parse(id)
+	return &x509.Certificate{URIs: []*url.URL{uri}}
+}
+
+// newMutualTLSServer serves handler with MutualTLSConfig, trusting client
+// certificates issued by clientCA, and returns a client for it that presents
+// clientCert, if any.
+func newMutualTLSServer(t *testing.T, handler http.Handler, clientCA *testCA, clientCert *tls.Certificate) (*httptest.Server, *http.Client) {
+	t.Helper()
+	serverCA := newTestCA(t)
+	serverCert := serverCA.issue(t, &x509.Certificate{IPAddresses: []net.IP{net.IPv4(127, 0, 0, 1)}})
+
+	server := httptest.NewUnstartedServer(handler)
+	server.TLS = MutualTLSConfig(serverCert, clientCA.pool())
+	server.StartTLS()
+	t.Cleanup(server.Close)
+
+	clientConfig := &tls.Config{RootCAs: serverCA.pool()}
+	if clientCert != nil {
+		clientConfig.Certificates = []tls.Certificate{*clientCert}
+	}
+	return server, &http.Client{Transport: &http.Transport{TLSClientConfig: clientConfig}}
+}
+
+func TestServicePrincipalFromClientCertificate(t *testing.T) {
+	ca := newTestCA(t)
+	a := newTestAuthenticator(t)
+	a.config.ServicePrincipals = []ServicePrincipal{
+		{ID: "spiffe://example.org/ns/prod/sa/reporter", Scopes: []string{"items:read"}},
+		{ID: "indexer.internal", Scopes: []string{"items:read", "items:write"}},
+	}
+
+	tests := []struct {
+		name          string
+		cert          *x509.Certificate
+		wantPrincipal string
+		wantErr       bool
+	}{
+		{"spiffe id", spiffeCert("spiffe://example.org/ns/prod/sa/reporter"), "spiffe://example.org/ns/prod/sa/reporter", false},
+		{"dns name", &x509.Certificate{DNSNames: []string{"indexer.internal"}}, "indexer.internal", false},
+		{"unknown service", spiffeCert("spiffe://example.org/ns/prod/sa/other"), "", true},
+		{"no certificate", nil, "", false},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			var clientCert *tls.Certificate
+			if tt.cert != nil {
+				cert := ca.issue(t, tt.cert)
+				clientCert = &cert
+			}
+
+			var principal *ServicePrincipal
+			var principalErr error
+			server, client := newMutualTLSServer(t, http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+				principal, principalErr = a.servicePrincipal(r)
+			}), ca, clientCert)
+
+			res, err := client.Get(server.URL)
+			if err != nil {
+				t.Fatalf("GET error = %v", err)
+			}
+			res.Body.Close()
+
+			if (principalErr != nil) != tt.wantErr {
+				t.Fatalf("servicePrincipal() error = %v, wantErr %v", principalErr, tt.wantErr)
+			}
+			gotPrincipal := ""
+			if principal != nil {
+				gotPrincipal = principal.ID
+			}
+			if gotPrincipal != tt.wantPrincipal {
+				t.Errorf("Expected principal %q, got %q", tt.wantPrincipal, gotPrincipal)
+			}
+		})
+	}
+}
+
+func TestMutualTLSRejectsUntrustedCertificate(t *testing.T) {
+	trusted, untrusted := newTestCA(t), newTestCA(t)
+	cert := untrusted.issue(t, spiffeCert("spiffe://example.org/ns/prod/sa/reporter"))
+
+	server, client := newMutualTLSServer(t, http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {}), trusted, &cert)
+
+	if res, err := client.Get(server.URL); err == nil {
+		res.Body.Close()
+		t.Errorf("Expected the handshake to fail for a certificate from another CA")
+	}
+}
+
+func TestMiddlewareAddsServicePrincipal(t *testing.T) {
+	ca := newTestCA(t)
+	a := newTestAuthenticator(t)
+	a.revocations = NewRevocationStore(nil)
+	a.config.ServicePrincipals = []ServicePrincipal{
+		{ID: "spiffe://example.org/ns/prod/sa/reporter", Scopes: []string{"items:read"}},
+	}
+	pair, err := a.GenerateTokenPair("user-1", "user@example.com", RoleUser, "session-1")
+	if err != nil {
+		t.Fatalf("GenerateTokenPair() error = %v", err)
+	}
+	cert := ca.issue(t, spiffeCert("spiffe://example.org/ns/prod/sa/reporter"))
+
+	var gotPrincipal, gotUserID string
+	server, client := newMutualTLSServer(t, a.Middleware(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		if principal, ok := GetServicePrincipalFromContext(r.Context()); ok {
+			gotPrincipal = principal.ID
+		}
+		gotUserID, _ = GetUserIDFromContext(r.Context())
+	})), ca, &cert)
+
+	// The service calls on behalf of a user by adding the user's token.
+	req, _ := http.NewRequest(http.MethodGet, server.URL, nil)
+	req.Header.Set("Authorization", "Bearer "+pair.AccessToken)
+	res, err := client.Do(req)
+	if err != nil {
+		t.Fatalf("GET error = %v", err)
+	}
+	res.Body.Close()
+
+	if res.StatusCode != http.StatusOK {
+		t.Fatalf("Expected status 200, got %d", res.StatusCode)
+	}
+	if gotPrincipal != "spiffe://example.org/ns/prod/sa/reporter" {
+		t.Errorf("Expected the service principal in the context, got %q", gotPrincipal)
+	}
+	if gotUserID != "user-1" {
+		t.Errorf("Expected user-1 in the context, got %q", gotUserID)
+	}
+}
+
+func TestServicePrincipalValidate(t *testing.T) {
+	tests := []struct {
+		name      string
+		principal ServicePrincipal
+		wantErr   bool
+	}{
+		{"read only", ServicePrincipal{ID: "reporter", Scopes: []string{"items:read"}}, false},
+		{"write with owner", ServicePrincipal{ID: "indexer", Scopes: []string{"items:read", "items:write"}, Owner: "user-1"}, false},
+		{"write without owner", ServicePrincipal{ID: "indexer", Scopes: []string{"items:write"}}, true},
+		{"unknown scope", ServicePrincipal{ID: "indexer", Scopes: []string{"users:write"}, Owner: "user-1"}, true},
+		{"no scopes", ServicePrincipal{ID: "indexer"}, true},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			if err := tt.principal.Validate(); (err != nil) != tt.wantErr {
+				t.Errorf("Validate() error = %v, wantErr %v", err, tt.wantErr)
+			}
+		})
+	}
+}
+
+func TestMiddlewareActsAsServiceOwner(t *testing.T) {
+	ca := newTestCA(t)
+	a := newTestAuthenticator(t)
+	a.revocations = NewRevocationStore(nil)
+	a.config.ServicePrincipals = []ServicePrincipal{
+		{ID: "indexer.internal", Scopes: []string{"items:read", "items:write"}, Owner: "owner-1"},
+	}
+	cert := ca.issue(t, &x509.Certificate{DNSNames: []string{"indexer.internal"}})
+
+	var gotUserID string
+	var gotRole Role
+	var ruleErr error
+	server, client := newMutualTLSServer(t, a.Middleware(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		gotUserID, _ = GetUserIDFromContext(r.Context())
+		gotRole, _ = GetRoleFromContext(r.Context())
+		ruleErr = checkRule(r.Context(), &authz.AuthRule{Scopes: []string{"items:write"}})
+	})), ca, &cert)
+
+	res, err := client.Get(server.URL)
+	if err != nil {
+		t.Fatalf("GET error = %v", err)
+	}
+	res.Body.Close()
+
+	if gotUserID != "owner-1" || gotRole != RoleUser {
+		t.Errorf("Expected the service to act as its owner, got user %q with role %q", gotUserID, gotRole)
+	}
+	if ruleErr != nil {
+		t.Errorf("Expected the service to be allowed to write items, got %v", ruleErr)
+	}
+}
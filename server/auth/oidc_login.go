@@ -0,0 +1,345 @@
+package auth
+
+import (
+	"context"
+	"crypto/subtle"
+	"fmt"
+	"log"
+	"net/http"
+	"net/url"
+	"strings"
+	"time"
+
+	"grpc-server/ent"
+	"grpc-server/ent/identity"
+
+	"github.com/golang-jwt/jwt/v5"
+	"github.com/google/uuid"
+)
+
+const (
+	// OIDCPathPrefix is where the federated login handlers are mounted:
+	// GET {prefix}{provider}/login starts a login and
+	// GET {prefix}{provider}/callback is the provider's redirect target.
+	OIDCPathPrefix = "/auth/oidc/"
+
+	oidcStateCookie = "oidc_state"
+	oidcStateExpiry = 10 * time.Minute
+	// oidcAppCallbackPath is where the web client picks up the result of a
+	// federated login from the URL fragment.
+	oidcAppCallbackPath = "/auth/callback"
+
+	TokenTypeOIDCState TokenType = "oidc_state"
+)
+
+// oidcState is kept in a short-lived cookie between the redirect to the
+// provider and the callback. Its jti doubles as the state parameter.
+type oidcState struct {
+	TokenType TokenType `json:"token_type"`
+	Provider  string    `json:"provider"`
+	Nonce     string    `json:"nonce"`
+	Verifier  string    `json:"verifier"`
+	jwt.RegisteredClaims
+}
+
+// OIDCHandler serves the browser side of federated login.
+func (a *Authenticator) OIDCHandler() http.Handler {
+	mux := http.NewServeMux()
+	mux.HandleFunc("GET "+OIDCPathPrefix+"{provider}/login", a.oidcLogin)
+	mux.HandleFunc("GET "+OIDCPathPrefix+"{provider}/callback", a.oidcCallback)
+	return mux
+}
+
+// oidcLogin redirects the browser to the provider's authorization endpoint.
+func (a *Authenticator) oidcLogin(w http.ResponseWriter, r *http.Request) {
+	provider, ok := a.oidcProviders[r.PathValue("provider")]
+	if !ok {
+		http.NotFound(w, r)
+		return
+	}
+
+	state, err := a.newOIDCState(provider.config.Name)
+	if err != nil {
+		log.Printf("oidc login: %v", err)
+		http.Error(w, "Failed to start login", http.StatusInternalServerError)
+		return
+	}
+
+	authURL, err := provider.authCodeURL(r.Context(), state.ID, state.Nonce, pkceChallenge(state.Verifier))
+	if err != nil {
+		log.Printf("oidc login: %v", err)
+		http.Error(w, "Identity provider is unavailable", http.StatusBadGateway)
+		return
+	}
+
+	cookie, err := a.signToken(state)
+	if err != nil {
+		log.Printf("oidc login: %v", err)
+		http.Error(w, "Failed to start login", http.StatusInternalServerError)
+		return
+	}
+
+	http.SetCookie(w, &http.Cookie{
+		Name:     oidcStateCookie,
+		Value:    cookie,
+		Path:     OIDCPathPrefix + provider.config.Name,
+		MaxAge:   int(oidcStateExpiry.Seconds()),
+		HttpOnly: true,
+		Secure:   strings.HasPrefix(provider.config.RedirectURL, "https://"),
+		// Lax lets the cookie ride along on the provider's top-level
+		// redirect back to us.
+		SameSite: http.SameSiteLaxMode,
+	})
+	http.Redirect(w, r, authURL, http.StatusFound)
+}
+
+// oidcCallback finishes a federated login and hands the result to the web
+// client in the URL fragment, which never reaches a server log.
+func (a *Authenticator) oidcCallback(w http.ResponseWriter, r *http.Request) {
+	provider, ok := a.oidcProviders[r.PathValue("provider")]
+	if !ok {
+		http.NotFound(w, r)
+		return
+	}
+
+	http.SetCookie(w, &http.Cookie{
+		Name:   oidcStateCookie,
+		Path:   OIDCPathPrefix + provider.config.Name,
+		MaxAge: -1,
+	})
+
+	query := r.URL.Query()
+	if providerErr := query.Get("error"); providerErr != "" {
+		a.redirectToApp(w, r, url.Values{"error": {providerErr}})
+		return
+	}
+
+	result, err := a.completeOIDCLogin(w, r, provider, query.Get("state"), query.Get("code"))
+	if err != nil {
+		log.Printf("oidc callback: %v", err)
+		a.redirectToApp(w, r, url.Values{"error": {"login_failed"}})
+		return
+	}
+	a.redirectToApp(w, r, result)
+}
+
+func (a *Authenticator) completeOIDCLogin(w http.ResponseWriter, r *http.Request, provider *oidcProvider, stateParam, code string) (url.Values, error) {
+	ctx := r.Context()
+
+	cookie, err := r.Cookie(oidcStateCookie)
+	if err != nil {
+		return nil, fmt.Errorf("missing state cookie")
+	}
+	state := &oidcState{}
+	if err := a.parseToken(cookie.Value, state); err != nil {
+		return nil, err
+	}
+	if state.TokenType != TokenTypeOIDCState || state.Provider != provider.config.Name {
+		return nil, fmt.Errorf("state cookie is for another login")
+	}
+	if subtle.ConstantTimeCompare([]byte(state.ID), []byte(stateParam)) != 1 {
+		return nil, fmt.Errorf("state does not match")
+	}
+	if code == "" {
+		return nil, fmt.Errorf("missing authorization code")
+	}
+
+	if err := a.revocations.Consume(ctx, state.ID, state.ExpiresAt.Time); err != nil {
+		return nil, err
+	}
+
+	idClaims, err := provider.exchange(ctx, code, state.Verifier, state.Nonce)
+	if err != nil {
+		return nil, err
+	}
+
+	u, err := a.federatedUser(ctx, provider.config.Name, idClaims)
+	if err != nil {
+		return nil, err
+	}
+
+	mfaEnabled, err := a.mfaEnabled(ctx, u.ID)
+	if err != nil {
+		return nil, err
+	}
+	if mfaEnabled {
+		challenge, err := a.generateMFAChallenge(u)
+		if err != nil {
+			return nil, err
+		}
+		return url.Values{"mfa_token": {challenge}}, nil
+	}
+
+	tokenPair, err := a.startSession(ctx, a.client, u, httpSessionInfo(r))
+	if err != nil {
+		return nil, err
+	}
+	return a.deliverTokensToApp(w, tokenPair)
+}
+
+func (a *Authenticator) redirectToApp(w http.ResponseWriter, r *http.Request, fragment url.Values) {
+	http.Redirect(w, r, a.config.AppURL+oidcAppCallbackPath+"#"+fragment.Encode(), http.StatusFound)
+}
+
+func (a *Authenticator) newOIDCState(provider string) (*oidcState, error) {
+	nonce, err := randomToken(32)
+	if err != nil {
+		return nil, err
+	}
+	verifier, err := randomToken(32)
+	if err != nil {
+		return nil, err
+	}
+
+	now := time.Now()
+	return &oidcState{
+		TokenType: TokenTypeOIDCState,
+		Provider:  provider,
+		Nonce:     nonce,
+		Verifier:  verifier,
+		RegisteredClaims: jwt.RegisteredClaims{
+			ID:        uuid.New().String(),
+			Issuer:    tokenIssuer,
+			Audience:  jwt.ClaimStrings{tokenAudience},
+			ExpiresAt: jwt.NewNumericDate(now.Add(oidcStateExpiry)),
+			IssuedAt:  jwt.NewNumericDate(now),
+			NotBefore: jwt.NewNumericDate(now),
+		},
+	}, nil
+}
+
+// federatedUser returns the user the provider account belongs to. Unknown
+// accounts are linked to the user with the same email address, or to a new
+// user, but only if the provider has verified that address.
+func (a *Authenticator) federatedUser(ctx context.Context, provider string, claims *idTokenClaims) (*ent.User, error) {
+	claims.Email = a.NormalizeEmail(claims.Email)
+
+	linked, err := a.client.Identity.
+		Query().
+		Where(
+			identity.ProviderEQ(provider),
+			identity.SubjectEQ(claims.Subject),
+		).
+		WithUser().
+		Only(ctx)
+	if err == nil {
+		if err := linked.Update().SetEmail(claims.Email).SetLastUsedAt(time.Now()).Exec(ctx); err != nil {
+			log.Printf("oidc: failed to update identity: %v", err)
+		}
+		return linked.Edges.User, nil
+	}
+	if !ent.IsNotFound(err) {
+		return nil, fmt.Errorf("failed to query identity: %w", err)
+	}
+
+	if claims.Email == "" || !claims.EmailVerified {
+		return nil, ErrEmailNotVerified
+	}
+
+	tx, err := a.client.Tx(ctx)
+	if err != nil {
+		return nil, fmt.Errorf("failed to start transaction: %w", err)
+	}
+
+	u, err := a.linkIdentityTx(ctx, tx, provider, claims)
+	if err != nil {
+		_ = tx.Rollback()
+		return nil, err
+	}
+
+	if err := tx.Commit(); err != nil {
+		return nil, fmt.Errorf("failed to commit transaction: %w", err)
+	}
+	return u.Unwrap(), nil
+}
+
+func (a *Authenticator) linkIdentityTx(ctx context.Context, tx *ent.Tx, provider string, claims *idTokenClaims) (*ent.User, error) {
+	u, err := tx.User.
+		Query().
+		Where(emailIs(claims.Email)).
+		ForUpdate().
+		Only(ctx)
+	switch {
+	case ent.IsNotFound(err):
+		u, err = a.createFederatedUser(ctx, tx, claims)
+		if err != nil {
+			return nil, err
+		}
+	case err != nil:
+		return nil, fmt.Errorf("failed to query user: %w", err)
+	case u.EmailVerifiedAt == nil:
+		u, err = a.claimAddressTx(ctx, tx, u)
+		if err != nil {
+			return nil, err
+		}
+	}
+
+	err = tx.Identity.
+		Create().
+		SetProvider(provider).
+		SetSubject(claims.Subject).
+		SetEmail(claims.Email).
+		SetUser(u).
+		Exec(ctx)
+	if err != nil {
+		return nil, fmt.Errorf("failed to link identity: %w", err)
+	}
+	return u, nil
+}
+
+func (a *Authenticator) createFederatedUser(ctx context.Context, tx *ent.Tx, claims *idTokenClaims) (*ent.User, error) {
+	name := claims.Name
+	if name == "" {
+		name, _, _ = strings.Cut(claims.Email, "@")
+	}
+
+	// Federated users sign in through their provider; they can set a
+	// password later through the password reset flow.
+	passwordHash, err := a.unusablePasswordHash()
+	if err != nil {
+		return nil, err
+	}
+
+	u, err := tx.User.
+		Create().
+		SetEmail(claims.Email).
+		SetName(name).
+		SetPasswordHash(passwordHash).
+		SetEmailVerifiedAt(time.Now()).
+		Save(ctx)
+	if err != nil {
+		return nil, fmt.Errorf("failed to create user: %w", err)
+	}
+	return u, nil
+}
+
+// claimAddressTx marks the address of the unverified user u verified for
+// whoever just proved they own it. Whoever set the password of an unverified
+// account never did, so they must not keep access to it.
+func (a *Authenticator) claimAddressTx(ctx context.Context, tx *ent.Tx, u *ent.User) (*ent.User, error) {
+	passwordHash, err := a.unusablePasswordHash()
+	if err != nil {
+		return nil, err
+	}
+	u, err = tx.User.
+		UpdateOne(u).
+		SetPasswordHash(passwordHash).
+		SetEmailVerifiedAt(time.Now()).
+		Save(ctx)
+	if err != nil {
+		return nil, fmt.Errorf("failed to update user: %w", err)
+	}
+	if err := a.revokeUserSessions(ctx, tx.Client(), u.ID); err != nil {
+		return nil, err
+	}
+	return u, nil
+}
+
+// unusablePasswordHash returns the hash of a random password nobody knows.
+func (a *Authenticator) unusablePasswordHash() (string, error) {
+	password, err := randomToken(32)
+	if err != nil {
+		return "", err
+	}
+	return a.passwords.Hash(password)
+}
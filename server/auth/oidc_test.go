@@ -0,0 +1,363 @@
+package auth
+
+import (
+	"context"
+	"crypto/rand"
+	"crypto/rsa"
+	"encoding/json"
+	"net/http"
+	"net/http/httptest"
+	"net/url"
+	"slices"
+	"strings"
+	"sync"
+	"testing"
+	"time"
+
+	"github.com/golang-jwt/jwt/v5"
+
+	"grpc-server/ent/identity"
+)
+
+const (
+	mockClientID     = "test-client"
+	mockClientSecret = "test-secret"
+	mockRedirectURL  = "http://server.test/auth/oidc/mock/callback"
+)
+
+// mockIdP is an in-process OpenID Connect provider that implements the
+// authorization code flow with PKCE.
+type mockIdP struct {
+	*httptest.Server
+	key *Key
+
+	mu    sync.Mutex
+	codes map[string]mockAuthorization
+	// claims are put into every ID token the provider issues.
+	claims idTokenClaims
+}
+
+type mockAuthorization struct {
+	challenge string
+	nonce     string
+}
+
+func newMockIdP(t *testing.T) *mockIdP {
+	t.Helper()
+
+	private, err := rsa.GenerateKey(rand.Reader, 2048)
+	if err != nil {
+		t.Fatalf("Failed to generate key: %v", err)
+	}
+	key, err := newKey("idp-key", private)
+	if err != nil {
+		t.Fatalf("newKey() error = %v", err)
+	}
+
+	idp := &mockIdP{
+		key:   key,
+		codes: make(map[string]mockAuthorization),
+		claims: idTokenClaims{
+			Email:         "user@example.com",
+			EmailVerified: true,
+			Name:          "User",
+		},
+	}
+
+	mux := http.NewServeMux()
+	mux.HandleFunc("GET /.well-known/openid-configuration", func(w http.ResponseWriter, r *http.Request) {
+		_ = json.NewEncoder(w).Encode(oidcDiscovery{
+			Issuer:                idp.URL,
+			AuthorizationEndpoint: idp.URL + "/authorize",
+			TokenEndpoint:         idp.URL + "/token",
+			JWKSURI:               idp.URL + "/jwks",
+		})
+	})
+	mux.Handle("GET /jwks", JWKSHandler(mustKeySet(t, key)))
+	mux.HandleFunc("GET /authorize", idp.authorize)
+	mux.HandleFunc("POST /token", idp.token)
+	idp.Server = httptest.NewServer(mux)
+	t.Cleanup(idp.Close)
+
+	idp.claims.Subject = "subject-1"
+	return idp
+}
+
+func mustKeySet(t *testing.T, key *Key) *KeySet {
+	t.Helper()
+	ks, err := NewKeySet(key)
+	if err != nil {
+		t.Fatalf("NewKeySet() error = %v", err)
+	}
+	return ks
+}
+
+func (idp *mockIdP) authorize(w http.ResponseWriter, r *http.Request) {
+	query := r.URL.Query()
+	if query.Get("client_id") != mockClientID || query.Get("code_challenge_method") != "S256" {
+		http.Error(w, "invalid request", http.StatusBadRequest)
+		return
+	}
+
+	code, _ := randomToken(16)
+	idp.mu.Lock()
+	idp.codes[code] = mockAuthorization{
+		challenge: query.Get("code_challenge"),
+		nonce:     query.Get("nonce"),
+	}
+	idp.mu.Unlock()
+
+	redirect := query.Get("redirect_uri") + "?" + url.Values{
+		"code":  {code},
+		"state": {query.Get("state")},
+	}.Encode()
+	http.Redirect(w, r, redirect, http.StatusFound)
+}
+
+func (idp *mockIdP) token(w http.ResponseWriter, r *http.Request) {
+	clientID, secret, ok := r.BasicAuth()
+	if !ok || clientID != mockClientID || secret != mockClientSecret {
+		http.Error(w, `{"error":"invalid_client"}`, http.StatusUnauthorized)
+		return
+	}
+
+	idp.mu.Lock()
+	authorization, ok := idp.codes[r.FormValue("code")]
+	delete(idp.codes, r.FormValue("code"))
+	idp.mu.Unlock()
+
+	if !ok || pkceChallenge(r.FormValue("code_verifier")) != authorization.challenge {
+		http.Error(w, `{"error":"invalid_grant"}`, http.StatusBadRequest)
+		return
+	}
+
+	claims := idp.claims
+	claims.Nonce = authorization.nonce
+	_ = json.NewEncoder(w).Encode(map[string]string{
+		"id_token": idp.sign(claims, time.Hour),
+	})
+}
+
+func (idp *mockIdP) sign(claims idTokenClaims, expiry time.Duration) string {
+	now := time.Now()
+	if claims.Issuer == "" {
+		claims.Issuer = idp.URL
+	}
+	if claims.Audience == nil {
+		claims.Audience = jwt.ClaimStrings{mockClientID}
+	}
+	claims.IssuedAt = jwt.NewNumericDate(now)
+	claims.ExpiresAt = jwt.NewNumericDate(now.Add(expiry))
+
+	token := jwt.NewWithClaims(idp.key.Method, claims)
+	token.Header["kid"] = idp.key.ID
+	signed, err := token.SignedString(idp.key.Private)
+	if err != nil {
+		panic(err)
+	}
+	return signed
+}
+
+func newOIDCTestAuthenticator(t *testing.T, idp *mockIdP) *Authenticator {
+	t.Helper()
+	a := newTestAuthenticator(t)
+	a.config.AppURL = "http://app.test"
+	a.oidcProviders = map[string]*oidcProvider{
+		"mock": newOIDCProvider(OIDCProviderConfig{
+			Name:         "mock",
+			Issuer:       idp.URL,
+			ClientID:     mockClientID,
+			ClientSecret: mockClientSecret,
+			RedirectURL:  mockRedirectURL,
+		}),
+	}
+	return a
+}
+
+// startOIDCLogin runs the login handler and follows its redirect through the
+// mock provider, returning the state cookie and the callback URL.
+func startOIDCLogin(t *testing.T, a *Authenticator) (*http.Cookie, *url.URL) {
+	t.Helper()
+
+	rec := httptest.NewRecorder()
+	a.OIDCHandler().ServeHTTP(rec, httptest.NewRequest(http.MethodGet, "/auth/oidc/mock/login", nil))
+	if rec.Code != http.StatusFound {
+		t.Fatalf("Expected status %d, got %d: %s", http.StatusFound, rec.Code, rec.Body.String())
+	}
+
+	cookies := rec.Result().Cookies()
+	if len(cookies) != 1 || cookies[0].Name != oidcStateCookie || !cookies[0].HttpOnly {
+		t.Fatalf("Expected an HttpOnly %s cookie, got %v", oidcStateCookie, cookies)
+	}
+
+	client := &http.Client{CheckRedirect: func(*http.Request, []*http.Request) error {
+		return http.ErrUseLastResponse
+	}}
+	resp, err := client.Get(rec.Header().Get("Location"))
+	if err != nil {
+		t.Fatalf("Failed to authorize: %v", err)
+	}
+	resp.Body.Close()
+	if resp.StatusCode != http.StatusFound {
+		t.Fatalf("Expected provider to redirect, got %s", resp.Status)
+	}
+
+	callback, err := url.Parse(resp.Header.Get("Location"))
+	if err != nil {
+		t.Fatalf("Failed to parse callback URL: %v", err)
+	}
+	return cookies[0], callback
+}
+
+func TestOIDCAuthorizationCodeFlow(t *testing.T) {
+	idp := newMockIdP(t)
+	a := newOIDCTestAuthenticator(t, idp)
+
+	cookie, callback := startOIDCLogin(t, a)
+	if !strings.HasPrefix(callback.String(), mockRedirectURL) {
+		t.Fatalf("Expected redirect to %s, got %s", mockRedirectURL, callback)
+	}
+
+	state := &oidcState{}
+	if err := a.parseToken(cookie.Value, state); err != nil {
+		t.Fatalf("Failed to parse state cookie: %v", err)
+	}
+	if callback.Query().Get("state") != state.ID {
+		t.Errorf("Expected state %q, got %q", state.ID, callback.Query().Get("state"))
+	}
+
+	provider := a.oidcProviders["mock"]
+	claims, err := provider.exchange(context.Background(), callback.Query().Get("code"), state.Verifier, state.Nonce)
+	if err != nil {
+		t.Fatalf("exchange() error = %v", err)
+	}
+	if claims.Subject != "subject-1" || claims.Email != "user@example.com" || !claims.EmailVerified {
+		t.Errorf("Unexpected ID token claims: %+v", claims)
+	}
+}
+
+func TestOIDCExchangeRejectsWrongVerifier(t *testing.T) {
+	idp := newMockIdP(t)
+	a := newOIDCTestAuthenticator(t, idp)
+
+	cookie, callback := startOIDCLogin(t, a)
+	state := &oidcState{}
+	if err := a.parseToken(cookie.Value, state); err != nil {
+		t.Fatalf("Failed to parse state cookie: %v", err)
+	}
+
+	provider := a.oidcProviders["mock"]
+	if _, err := provider.exchange(context.Background(), callback.Query().Get("code"), "wrong-verifier", state.Nonce); err == nil {
+		t.Error("Expected exchange with the wrong verifier to fail")
+	}
+}
+
+func TestOIDCCallbackRejectsStateMismatch(t *testing.T) {
+	idp := newMockIdP(t)
+	a := newOIDCTestAuthenticator(t, idp)
+
+	cookie, callback := startOIDCLogin(t, a)
+	query := callback.Query()
+	query.Set("state", "forged")
+
+	req := httptest.NewRequest(http.MethodGet, "/auth/oidc/mock/callback?"+query.Encode(), nil)
+	req.AddCookie(cookie)
+	rec := httptest.NewRecorder()
+	a.OIDCHandler().ServeHTTP(rec, req)
+
+	location := rec.Header().Get("Location")
+	if !strings.HasPrefix(location, "http://app.test/auth/callback#") || !strings.Contains(location, "error=login_failed") {
+		t.Errorf("Expected redirect to the app with an error, got %q", location)
+	}
+}
+
+func TestVerifyIDToken(t *testing.T) {
+	idp := newMockIdP(t)
+	a := newOIDCTestAuthenticator(t, idp)
+	provider := a.oidcProviders["mock"]
+
+	otherIdP := newMockIdP(t)
+	otherIdP.key.ID = idp.key.ID
+
+	valid := idp.claims
+	valid.Nonce = "nonce-1"
+
+	withIssuer := valid
+	withIssuer.Issuer = "http://evil.test"
+	withAudience := valid
+	withAudience.Audience = jwt.ClaimStrings{"other-client"}
+	withExtraAudience := valid
+	withExtraAudience.Audience = jwt.ClaimStrings{mockClientID, "other-client"}
+	withoutSubject := valid
+	withoutSubject.Subject = ""
+
+	tests := []struct {
+		name    string
+		token   string
+		nonce   string
+		wantErr bool
+	}{
+		{"valid", idp.sign(valid, time.Hour), "nonce-1", false},
+		{"wrong nonce", idp.sign(valid, time.Hour), "nonce-2", true},
+		{"expired", idp.sign(valid, -time.Hour), "nonce-1", true},
+		{"wrong issuer", idp.sign(withIssuer, time.Hour), "nonce-1", true},
+		{"wrong audience", idp.sign(withAudience, time.Hour), "nonce-1", true},
+		{"extra audience without azp", idp.sign(withExtraAudience, time.Hour), "nonce-1", true},
+		{"no subject", idp.sign(withoutSubject, time.Hour), "nonce-1", true},
+		{"signed by another key", otherIdP.sign(valid, time.Hour), "nonce-1", true},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			_, err := provider.verifyIDToken(context.Background(), tt.token, tt.nonce)
+			if (err != nil) != tt.wantErr {
+				t.Errorf("verifyIDToken() error = %v, wantErr %v", err, tt.wantErr)
+			}
+		})
+	}
+}
+
+func TestJWKToKeyRoundTrip(t *testing.T) {
+	idp := newMockIdP(t)
+
+	jwk, err := keyToJWK(idp.key)
+	if err != nil {
+		t.Fatalf("keyToJWK() error = %v", err)
+	}
+	key, err := jwkToKey(jwk)
+	if err != nil {
+		t.Fatalf("jwkToKey() error = %v", err)
+	}
+	if !key.Public.(*rsa.PublicKey).Equal(idp.key.Public) {
+		t.Error("Expected the parsed key to match the original")
+	}
+	if key.Private != nil {
+		t.Error("Expected a key parsed from a JWK to have no private half")
+	}
+}
+
+func TestFederatedUserNormalizesLinkedEmail(t *testing.T) {
+	s, drv := newTestServer(t)
+	now := time.Now()
+	drv.results = []fakeResult{
+		{
+			columns: append(append([]string(nil), identity.Columns...), identity.ForeignKeys...),
+			rows:    [][]any{{"identity-1", "mock", "subject-1", "user@example.com", now, now, "user-1"}},
+		},
+		userResult(t, s.authenticator, "password"),
+	}
+
+	claims := &idTokenClaims{Email: " User@EXAMPLE.com "}
+	claims.Subject = "subject-1"
+	if _, err := s.authenticator.federatedUser(context.Background(), "mock", claims); err != nil {
+		t.Fatalf("federatedUser() error = %v", err)
+	}
+
+	updates := drv.find(`UPDATE "identities"`)
+	if len(updates) != 1 {
+		t.Fatalf("identity updates = %d, want 1", len(updates))
+	}
+	if !slices.Contains(updates[0].args, any("User@example.com")) {
+		t.Errorf("identity update args = %v, want the normalized email", updates[0].args)
+	}
+}
@@ -1,21 +1,274 @@
 package auth
 
 import (
+	"crypto/rand"
+	"crypto/subtle"
+	"encoding/base64"
+	"errors"
 	"fmt"
+	"strings"
+	"sync"
+	"time"
 
+	"golang.org/x/crypto/argon2"
 	"golang.org/x/crypto/bcrypt"
 )
 
-const bcryptCost = 12
+// PasswordAlgorithm names a password hashing algorithm by its PHC identifier.
+type PasswordAlgorithm string
 
-func HashPassword(password string) (string, error) {
-	hashedBytes, err := bcrypt.GenerateFromPassword([]byte(password), bcryptCost)
-	if err != nil {
-		return "", fmt.Errorf("failed to hash password: %w", err)
+const (
+	PasswordAlgorithmArgon2id PasswordAlgorithm = "argon2id"
+	PasswordAlgorithmBcrypt   PasswordAlgorithm = "bcrypt"
+)
+
+// bcrypt ignores everything past this many bytes of a password.
+const bcryptMaxPasswordLength = 72
+
+// legacyBcryptCost is the cost of the bcrypt hashes made before argon2id
+// became the default. Users who have not signed in since still have them.
+const legacyBcryptCost = 12
+
+var errPasswordMismatch = errors.New("password does not match")
+
+// Argon2Params are the cost parameters of argon2id hashes.
+type Argon2Params struct {
+	// Memory is the amount of memory used, in KiB.
+	Memory      uint32
+	Iterations  uint32
+	Parallelism uint8
+	SaltLength  uint32
+	KeyLength   uint32
+}
+
+// DefaultArgon2Params follow the OWASP recommendation for argon2id.
+var DefaultArgon2Params = Argon2Params{
+	Memory:      19 * 1024,
+	Iterations:  2,
+	Parallelism: 1,
+	SaltLength:  16,
+	KeyLength:   32,
+}
+
+// PasswordConfig selects how new password hashes are made.
+type PasswordConfig struct {
+	Algorithm PasswordAlgorithm
+	Argon2    Argon2Params
+	// BcryptCost is used when Algorithm is bcrypt, and to time dummy
+	// verifications against bcrypt hashes otherwise.
+	BcryptCost int
+}
+
+// PasswordHasher hashes passwords into PHC strings
+// ($argon2id$v=19$m=...,t=...,p=...$salt$hash) and verifies them. It also
+// verifies bcrypt hashes in their usual $2a$ form, and reports hashes that
+// were made with another algorithm or other parameters so they can be
+// upgraded the next time the user signs in.
+type PasswordHasher struct {
+	config PasswordConfig
+
+	dummyOnce sync.Once
+	dummyHash string
+}
+
+// NewPasswordHasher returns a hasher that hashes new passwords as configured.
+// Zero parameters fall back to their defaults.
+func NewPasswordHasher(config PasswordConfig) (*PasswordHasher, error) {
+	if config.Algorithm == "" {
+		config.Algorithm = PasswordAlgorithmArgon2id
 	}
-	return string(hashedBytes), nil
+	if config.Argon2 == (Argon2Params{}) {
+		config.Argon2 = DefaultArgon2Params
+	}
+	if config.BcryptCost == 0 {
+		config.BcryptCost = 12
+	}
+
+	switch config.Algorithm {
+	case PasswordAlgorithmArgon2id:
+		p := config.Argon2
+		if p.Memory < 8*uint32(p.Parallelism) || p.Iterations < 1 || p.Parallelism < 1 {
+			return nil, fmt.Errorf("invalid argon2id parameters m=%d,t=%d,p=%d", p.Memory, p.Iterations, p.Parallelism)
+		}
+		if p.SaltLength < 8 || p.KeyLength < 16 {
+			return nil, fmt.Errorf("argon2id salt and key must be at least 8 and 16 bytes")
+		}
+	case PasswordAlgorithmBcrypt:
+		if config.BcryptCost < bcrypt.MinCost || config.BcryptCost > bcrypt.MaxCost {
+			return nil, fmt.Errorf("bcrypt cost must be between %d and %d", bcrypt.MinCost, bcrypt.MaxCost)
+		}
+	default:
+		return nil, fmt.Errorf("unknown password algorithm %q", config.Algorithm)
+	}
+
+	return &PasswordHasher{config: config}, nil
+}
+
+// DefaultPasswordHasher hashes passwords with argon2id and the default
+// parameters.
+func DefaultPasswordHasher() *PasswordHasher {
+	h, _ := NewPasswordHasher(PasswordConfig{})
+	return h
 }
 
-func VerifyPassword(hashedPassword, password string) error {
-	return bcrypt.CompareHashAndPassword([]byte(hashedPassword), []byte(password))
+// maxPasswordBytes is the longest password Hash accepts, or 0 if there is no
+// limit.
+func (h *PasswordHasher) maxPasswordBytes() int {
+	if h.config.Algorithm == PasswordAlgorithmBcrypt {
+		return bcryptMaxPasswordLength
+	}
+	return 0
+}
+
+// Hash returns the encoded hash of password.
+func (h *PasswordHasher) Hash(password string) (string, error) {
+	switch h.config.Algorithm {
+	case PasswordAlgorithmBcrypt:
+		// Refuse what bcrypt would silently truncate.
+		if len(password) > bcryptMaxPasswordLength {
+			return "", fmt.Errorf("password must not exceed %d bytes with bcrypt", bcryptMaxPasswordLength)
+		}
+		hashedBytes, err := bcrypt.GenerateFromPassword([]byte(password), h.config.BcryptCost)
+		if err != nil {
+			return "", fmt.Errorf("failed to hash password: %w", err)
+		}
+		return string(hashedBytes), nil
+	default:
+		return hashArgon2id(password, h.config.Argon2)
+	}
+}
+
+// Verify checks password against encoded. On a match, rehash reports whether
+// encoded should be replaced by a fresh Hash of the password.
+func (h *PasswordHasher) Verify(encoded, password string) (rehash bool, err error) {
+	switch {
+	case strings.HasPrefix(encoded, "$argon2id$"):
+		params, salt, key, err := decodeArgon2id(encoded)
+		if err != nil {
+			return false, err
+		}
+		derived := argon2.IDKey([]byte(password), salt, params.Iterations, params.Memory, params.Parallelism, uint32(len(key)))
+		if subtle.ConstantTimeCompare(derived, key) != 1 {
+			return false, errPasswordMismatch
+		}
+		return h.config.Algorithm != PasswordAlgorithmArgon2id || params != h.config.Argon2, nil
+
+	case isBcryptHash(encoded):
+		// Hashes are never made from longer passwords, so a longer one
+		// would only match through truncation.
+		if len(password) > bcryptMaxPasswordLength {
+			return false, errPasswordMismatch
+		}
+		if err := bcrypt.CompareHashAndPassword([]byte(encoded), []byte(password)); err != nil {
+			if errors.Is(err, bcrypt.ErrMismatchedHashAndPassword) {
+				return false, errPasswordMismatch
+			}
+			return false, fmt.Errorf("invalid bcrypt hash: %w", err)
+		}
+		if h.config.Algorithm != PasswordAlgorithmBcrypt {
+			return true, nil
+		}
+		cost, err := bcrypt.Cost([]byte(encoded))
+		return err != nil || cost != h.config.BcryptCost, nil
+
+	default:
+		return false, fmt.Errorf("unrecognized password hash")
+	}
+}
+
+// VerifyDummy spends as long as verifying a password against the slowest
+// kind of hash a user can have, for callers that have no hash to check but
+// must not reveal that. Legacy bcrypt hashes usually take several times
+// longer than argon2id ones, so matching only the current algorithm would
+// still give away accounts that were never upgraded.
+func (h *PasswordHasher) VerifyDummy(password string) {
+	h.dummyOnce.Do(func() {
+		h.dummyHash = h.slowestDummyHash()
+	})
+	// Verify rejects passwords too long for bcrypt without hashing them,
+	// which would take less time than an argon2id hash does.
+	if isBcryptHash(h.dummyHash) && len(password) > bcryptMaxPasswordLength {
+		password = password[:bcryptMaxPasswordLength]
+	}
+	_, _ = h.Verify(h.dummyHash, password)
+}
+
+// slowestDummyHash hashes a dummy password with argon2id and with bcrypt, as
+// configured or as legacy hashes were made, and returns the hash that takes
+// longest to verify.
+func (h *PasswordHasher) slowestDummyHash() string {
+	const password = "dummy password"
+
+	var hashes []string
+	if hash, err := hashArgon2id(password, h.config.Argon2); err == nil {
+		hashes = append(hashes, hash)
+	}
+	if hash, err := bcrypt.GenerateFromPassword([]byte(password), max(h.config.BcryptCost, legacyBcryptCost)); err == nil {
+		hashes = append(hashes, string(hash))
+	}
+
+	var slowest string
+	var slowestTime time.Duration
+	for _, hash := range hashes {
+		start := time.Now()
+		_, _ = h.Verify(hash, password)
+		if elapsed := time.Since(start); elapsed > slowestTime {
+			slowest, slowestTime = hash, elapsed
+		}
+	}
+	return slowest
+}
+
+func isBcryptHash(encoded string) bool {
+	return strings.HasPrefix(encoded, "$2a$") ||
+		strings.HasPrefix(encoded, "$2b$") ||
+		strings.HasPrefix(encoded, "$2y$")
+}
+
+func hashArgon2id(password string, params Argon2Params) (string, error) {
+	salt := make([]byte, params.SaltLength)
+	if _, err := rand.Read(salt); err != nil {
+		return "", fmt.Errorf("failed to generate salt: %w", err)
+	}
+	key := argon2.IDKey([]byte(password), salt, params.Iterations, params.Memory, params.Parallelism, params.KeyLength)
+
+	return fmt.Sprintf("$argon2id$v=%d$m=%d,t=%d,p=%d$%s$%s",
+		argon2.Version,
+		params.Memory, params.Iterations, params.Parallelism,
+		base64.RawStdEncoding.EncodeToString(salt),
+		base64.RawStdEncoding.EncodeToString(key),
+	), nil
+}
+
+func decodeArgon2id(encoded string) (Argon2Params, []byte, []byte, error) {
+	var params Argon2Params
+
+	// "", "argon2id", "v=19", "m=...,t=...,p=...", salt, hash
+	parts := strings.Split(encoded, "$")
+	if len(parts) != 6 {
+		return params, nil, nil, fmt.Errorf("invalid argon2id hash")
+	}
+
+	var version int
+	if _, err := fmt.Sscanf(parts[2], "v=%d", &version); err != nil || version != argon2.Version {
+		return params, nil, nil, fmt.Errorf("unsupported argon2id version %q", parts[2])
+	}
+	if _, err := fmt.Sscanf(parts[3], "m=%d,t=%d,p=%d", &params.Memory, &params.Iterations, &params.Parallelism); err != nil {
+		return params, nil, nil, fmt.Errorf("invalid argon2id parameters: %w", err)
+	}
+	if params.Iterations < 1 || params.Parallelism < 1 {
+		return params, nil, nil, fmt.Errorf("invalid argon2id parameters %q", parts[3])
+	}
+
+	salt, err := base64.RawStdEncoding.DecodeString(parts[4])
+	if err != nil {
+		return params, nil, nil, fmt.Errorf("invalid argon2id salt: %w", err)
+	}
+	key, err := base64.RawStdEncoding.DecodeString(parts[5])
+	if err != nil || len(key) == 0 {
+		return params, nil, nil, fmt.Errorf("invalid argon2id hash")
+	}
+	params.SaltLength = uint32(len(salt))
+	params.KeyLength = uint32(len(key))
+	return params, salt, key, nil
 }
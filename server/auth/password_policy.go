@@ -0,0 +1,216 @@
+package auth
+
+import (
+	"bufio"
+	"bytes"
+	"compress/gzip"
+	_ "embed"
+	"fmt"
+	"log"
+	"strings"
+	"sync"
+	"unicode"
+
+	protoAuth "grpc-server/proto-generated/auth"
+
+	"connectrpc.com/connect"
+)
+
+// commonPasswords lists common and breached passwords, one lowercase
+// password per line.
+//
+//go:embed common_passwords.txt.gz
+var commonPasswords []byte
+
+var loadBlocklist = sync.OnceValue(func() map[string]struct{} {
+	blocklist := make(map[string]struct{})
+
+	r, err := gzip.NewReader(bytes.NewReader(commonPasswords))
+	if err != nil {
+		log.Printf("password blocklist: %v", err)
+		return blocklist
+	}
+	scanner := bufio.NewScanner(r)
+	for scanner.Scan() {
+		if line := strings.TrimSpace(scanner.Text()); line != "" {
+			blocklist[line] = struct{}{}
+		}
+	}
+	if err := scanner.Err(); err != nil {
+		log.Printf("password blocklist: %v", err)
+	}
+	return blocklist
+})
+
+// PasswordPolicy decides which passwords users may choose. It does not apply
+// to Login, which only bounds the length of what it hashes.
+type PasswordPolicy struct {
+	MinLength int
+	MaxLength int
+	// MaxBytes bounds the UTF-8 length of the password. NewAuthenticator
+	// sets it to what the password hash accepts. 0 allows any.
+	MaxBytes int
+
+	RequireUpper  bool
+	RequireLower  bool
+	RequireDigit  bool
+	RequireSymbol bool
+
+	// MaxRepeated is the longest run of one character allowed. 0 allows any.
+	MaxRepeated int
+	// RejectPersonalInfo rejects passwords containing the user's email
+	// address or name.
+	RejectPersonalInfo bool
+	// RejectCommon rejects passwords on the built-in list of common and
+	// breached passwords.
+	RejectCommon bool
+}
+
+// DefaultPasswordPolicy favours length and the blocklist over character
+// classes, as NIST SP 800-63B recommends.
+func DefaultPasswordPolicy() PasswordPolicy {
+	return PasswordPolicy{
+		MinLength:          minPasswordLength,
+		MaxLength:          maxPasswordLength,
+		MaxRepeated:        3,
+		RejectPersonalInfo: true,
+		RejectCommon:       true,
+	}
+}
+
+// PasswordViolation is one rule a password breaks.
+type PasswordViolation struct {
+	Reason      string
+	Description string
+}
+
+// PasswordPolicyError lists every rule a password breaks.
+type PasswordPolicyError struct {
+	Violations []PasswordViolation
+}
+
+func (e *PasswordPolicyError) Error() string {
+	descriptions := make([]string, len(e.Violations))
+	for i, v := range e.Violations {
+		descriptions[i] = v.Description
+	}
+	return strings.Join(descriptions, "; ")
+}
+
+// Check returns a *PasswordPolicyError if password breaks the policy. email
+// and name belong to the user choosing it.
+func (p PasswordPolicy) Check(password, email, name string) error {
+	var violations []PasswordViolation
+	add := func(reason, format string, args ...any) {
+		violations = append(violations, PasswordViolation{
+			Reason:      reason,
+			Description: fmt.Sprintf(format, args...),
+		})
+	}
+
+	length := len([]rune(password))
+	if length < p.MinLength {
+		add("too_short", "password must be at least %d characters", p.MinLength)
+	}
+	if p.MaxLength > 0 && length > p.MaxLength {
+		add("too_long", "password must not exceed %d characters", p.MaxLength)
+	} else if p.MaxBytes > 0 && len(password) > p.MaxBytes {
+		add("too_long", "password must not exceed %d bytes", p.MaxBytes)
+	}
+
+	var hasUpper, hasLower, hasDigit, hasSymbol bool
+	for _, r := range password {
+		switch {
+		case unicode.IsUpper(r):
+			hasUpper = true
+		case unicode.IsLower(r):
+			hasLower = true
+		case unicode.IsDigit(r):
+			hasDigit = true
+		case !unicode.IsLetter(r) && !unicode.IsSpace(r):
+			hasSymbol = true
+		}
+	}
+	if p.RequireUpper && !hasUpper {
+		add("missing_uppercase", "password must contain an uppercase letter")
+	}
+	if p.RequireLower && !hasLower {
+		add("missing_lowercase", "password must contain a lowercase letter")
+	}
+	if p.RequireDigit && !hasDigit {
+		add("missing_digit", "password must contain a digit")
+	}
+	if p.RequireSymbol && !hasSymbol {
+		add("missing_symbol", "password must contain a symbol")
+	}
+
+	if p.MaxRepeated > 0 && longestRun(password) > p.MaxRepeated {
+		add("repeated_characters", "password must not repeat a character more than %d times in a row", p.MaxRepeated)
+	}
+
+	lower := strings.ToLower(password)
+	if p.RejectPersonalInfo && containsPersonalInfo(lower, email, name) {
+		add("contains_personal_info", "password must not contain your email address or name")
+	}
+	if p.RejectCommon {
+		if _, ok := loadBlocklist()[lower]; ok {
+			add("common_password", "password is too common")
+		}
+	}
+
+	if len(violations) > 0 {
+		return &PasswordPolicyError{Violations: violations}
+	}
+	return nil
+}
+
+func longestRun(s string) int {
+	longest, run := 0, 0
+	var prev rune
+	for i, r := range []rune(s) {
+		if i > 0 && r == prev {
+			run++
+		} else {
+			run = 1
+		}
+		prev = r
+		longest = max(longest, run)
+	}
+	return longest
+}
+
+// containsPersonalInfo reports whether the lowercased password contains the
+// local part of email or any word of name. Parts shorter than three
+// characters are too likely to match by chance.
+func containsPersonalInfo(password, email, name string) bool {
+	parts := strings.Fields(strings.ToLower(name))
+	if local, _, ok := strings.Cut(strings.ToLower(email), "@"); ok {
+		parts = append(parts, local)
+	}
+	for _, part := range parts {
+		if len([]rune(part)) >= 3 && strings.Contains(password, part) {
+			return true
+		}
+	}
+	return false
+}
+
+// passwordPolicyError reports the violations of field as a BadRequest detail.
+func passwordPolicyError(field string, err *PasswordPolicyError) *connect.Error {
+	connectErr := connect.NewError(connect.CodeInvalidArgument, err)
+
+	badRequest := &protoAuth.BadRequest{}
+	for _, v := range err.Violations {
+		badRequest.FieldViolations = append(badRequest.FieldViolations, &protoAuth.FieldViolation{
+			Field:       field,
+			Reason:      v.Reason,
+			Description: v.Description,
+		})
+	}
+	detail, detailErr := connect.NewErrorDetail(badRequest)
+	if detailErr == nil {
+		connectErr.AddDetail(detail)
+	}
+
+	return connectErr
+}
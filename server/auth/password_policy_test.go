@@ -0,0 +1,95 @@
+package auth
+
+import (
+	"errors"
+	"slices"
+	"testing"
+
+	protoAuth "grpc-server/proto-generated/auth"
+
+	"connectrpc.com/connect"
+)
+
+func violationReasons(err error) []string {
+	var policyErr *PasswordPolicyError
+	if !errors.As(err, &policyErr) {
+		return nil
+	}
+	reasons := make([]string, len(policyErr.Violations))
+	for i, v := range policyErr.Violations {
+		reasons[i] = v.Reason
+	}
+	return reasons
+}
+
+func TestPasswordPolicyCheck(t *testing.T) {
+	strict := DefaultPasswordPolicy()
+	strict.RequireUpper = true
+	strict.RequireLower = true
+	strict.RequireDigit = true
+	strict.RequireSymbol = true
+
+	tests := []struct {
+		name     string
+		policy   PasswordPolicy
+		password string
+		want     []string
+	}{
+		{"valid", DefaultPasswordPolicy(), "violet-kettle-drum", nil},
+		{"too short", DefaultPasswordPolicy(), "k3ttle", []string{"too_short"}},
+		{"common", DefaultPasswordPolicy(), "Password123", []string{"common_password"}},
+		{"repeated", DefaultPasswordPolicy(), "kettle-aaaa-drum", []string{"repeated_characters"}},
+		{"email", DefaultPasswordPolicy(), "jane.doe-kettle", []string{"contains_personal_info"}},
+		{"name", DefaultPasswordPolicy(), "kettle-DOE-drum", []string{"contains_personal_info"}},
+		{"character classes", strict, "violet-kettle-drum", []string{"missing_uppercase", "missing_digit"}},
+		{"all classes", strict, "Violet-Kettle-9", nil},
+		{"several rules", DefaultPasswordPolicy(), "jane111", []string{"too_short", "contains_personal_info"}},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			err := tt.policy.Check(tt.password, "jane.doe@example.com", "Jane Doe")
+			if got := violationReasons(err); !slices.Equal(got, tt.want) {
+				t.Errorf("Expected violations %v, got %v (%v)", tt.want, got, err)
+			}
+		})
+	}
+}
+
+func TestBlocklistIsLoaded(t *testing.T) {
+	blocklist := loadBlocklist()
+	if len(blocklist) < 100 {
+		t.Fatalf("Expected the embedded blocklist to load, got %d entries", len(blocklist))
+	}
+	if _, ok := blocklist["qwertyuiop"]; !ok {
+		t.Error("Expected qwertyuiop to be blocked")
+	}
+}
+
+func TestPasswordPolicyErrorDetail(t *testing.T) {
+	err := DefaultPasswordPolicy().Check("qwerty", "", "")
+	connectErr := passwordPolicyError("new_password", err.(*PasswordPolicyError))
+
+	if connectErr.Code() != connect.CodeInvalidArgument {
+		t.Errorf("Expected code %v, got %v", connect.CodeInvalidArgument, connectErr.Code())
+	}
+	if len(connectErr.Details()) != 1 {
+		t.Fatalf("Expected one error detail, got %d", len(connectErr.Details()))
+	}
+	value, detailErr := connectErr.Details()[0].Value()
+	if detailErr != nil {
+		t.Fatalf("Value() error = %v", detailErr)
+	}
+	badRequest, ok := value.(*protoAuth.BadRequest)
+	if !ok {
+		t.Fatalf("Expected a BadRequest detail, got %T", value)
+	}
+	if len(badRequest.FieldViolations) != 2 {
+		t.Fatalf("Expected 2 violations, got %v", badRequest.FieldViolations)
+	}
+	for _, v := range badRequest.FieldViolations {
+		if v.Field != "new_password" || v.Reason == "" || v.Description == "" {
+			t.Errorf("Unexpected violation %v", v)
+		}
+	}
+}
@@ -0,0 +1,183 @@
+package auth
+
+import (
+	"errors"
+	"strings"
+	"testing"
+
+	"grpc-server/database"
+
+	"golang.org/x/crypto/bcrypt"
+)
+
+// Cheap parameters keep the tests fast.
+var testArgon2Params = Argon2Params{
+	Memory:      64,
+	Iterations:  1,
+	Parallelism: 1,
+	SaltLength:  16,
+	KeyLength:   32,
+}
+
+func newTestPasswordHasher(t *testing.T, config PasswordConfig) *PasswordHasher {
+	t.Helper()
+	h, err := NewPasswordHasher(config)
+	if err != nil {
+		t.Fatalf("NewPasswordHasher() error = %v", err)
+	}
+	return h
+}
+
+func TestPasswordHasherArgon2id(t *testing.T) {
+	h := newTestPasswordHasher(t, PasswordConfig{Argon2: testArgon2Params})
+
+	encoded, err := h.Hash("correct horse battery staple")
+	if err != nil {
+		t.Fatalf("Hash() error = %v", err)
+	}
+	if !strings.HasPrefix(encoded, "$argon2id$v=19$m=64,t=1,p=1$") {
+		t.Errorf("Expected a PHC argon2id string, got %q", encoded)
+	}
+
+	rehash, err := h.Verify(encoded, "correct horse battery staple")
+	if err != nil {
+		t.Fatalf("Verify() error = %v", err)
+	}
+	if rehash {
+		t.Error("Expected a fresh hash not to need rehashing")
+	}
+
+	if _, err := h.Verify(encoded, "wrong password"); err == nil {
+		t.Error("Expected the wrong password to be rejected")
+	}
+}
+
+func TestPasswordHasherRehash(t *testing.T) {
+	argon2id := newTestPasswordHasher(t, PasswordConfig{Argon2: testArgon2Params})
+	stronger := testArgon2Params
+	stronger.Iterations = 2
+	strongerArgon2id := newTestPasswordHasher(t, PasswordConfig{Argon2: stronger})
+	bcryptHasher := newTestPasswordHasher(t, PasswordConfig{Algorithm: PasswordAlgorithmBcrypt, BcryptCost: bcrypt.MinCost})
+
+	legacy, err := bcrypt.GenerateFromPassword([]byte("password123"), bcrypt.MinCost)
+	if err != nil {
+		t.Fatalf("GenerateFromPassword() error = %v", err)
+	}
+	current, err := argon2id.Hash("password123")
+	if err != nil {
+		t.Fatalf("Hash() error = %v", err)
+	}
+
+	tests := []struct {
+		name    string
+		hasher  *PasswordHasher
+		encoded string
+		want    bool
+	}{
+		{"bcrypt to argon2id", argon2id, string(legacy), true},
+		{"bcrypt with the same cost", bcryptHasher, string(legacy), false},
+		{"argon2id with other parameters", strongerArgon2id, current, true},
+		{"argon2id to bcrypt", bcryptHasher, current, true},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			rehash, err := tt.hasher.Verify(tt.encoded, "password123")
+			if err != nil {
+				t.Fatalf("Verify() error = %v", err)
+			}
+			if rehash != tt.want {
+				t.Errorf("Expected rehash = %v, got %v", tt.want, rehash)
+			}
+		})
+	}
+}
+
+func TestPasswordHasherBcryptLength(t *testing.T) {
+	h := newTestPasswordHasher(t, PasswordConfig{Algorithm: PasswordAlgorithmBcrypt, BcryptCost: bcrypt.MinCost})
+	long := strings.Repeat("a", bcryptMaxPasswordLength)
+
+	encoded, err := h.Hash(long)
+	if err != nil {
+		t.Fatalf("Hash() error = %v", err)
+	}
+	if _, err := h.Hash(long + "b"); err == nil {
+		t.Error("Expected bcrypt to refuse a password it would truncate")
+	}
+	if _, err := h.Verify(encoded, long+"b"); err == nil {
+		t.Error("Expected a longer password not to match through truncation")
+	}
+
+	// argon2id uses the whole password.
+	argon2id := newTestPasswordHasher(t, PasswordConfig{Argon2: testArgon2Params})
+	encoded, err = argon2id.Hash(long + "b")
+	if err != nil {
+		t.Fatalf("Hash() error = %v", err)
+	}
+	if _, err := argon2id.Verify(encoded, long+"c"); err == nil {
+		t.Error("Expected passwords differing past 72 bytes not to match")
+	}
+}
+
+func TestBcryptCapsPasswordPolicy(t *testing.T) {
+	bcryptHasher := newTestPasswordHasher(t, PasswordConfig{Algorithm: PasswordAlgorithmBcrypt, BcryptCost: bcrypt.MinCost})
+	// Multibyte characters keep the password within MaxLength characters.
+	long := strings.Repeat("비밀번호", 7)
+
+	a := NewAuthenticator(&database.DB{}, Config{Passwords: bcryptHasher})
+	var policyErr *PasswordPolicyError
+	if err := a.policy.Check(long, "user@example.com", "User"); !errors.As(err, &policyErr) {
+		t.Errorf("Expected a password over %d bytes to break the policy with bcrypt, got %v", bcryptMaxPasswordLength, err)
+	}
+
+	a = NewAuthenticator(&database.DB{}, Config{Passwords: newTestPasswordHasher(t, PasswordConfig{Argon2: testArgon2Params})})
+	if err := a.policy.Check(long, "user@example.com", "User"); err != nil {
+		t.Errorf("Expected argon2id to accept the password, got %v", err)
+	}
+}
+
+func TestNewPasswordHasherRejectsInvalidConfig(t *testing.T) {
+	tests := []struct {
+		name   string
+		config PasswordConfig
+	}{
+		{"unknown algorithm", PasswordConfig{Algorithm: "md5"}},
+		{"bcrypt cost too high", PasswordConfig{Algorithm: PasswordAlgorithmBcrypt, BcryptCost: 40}},
+		{"no parallelism", PasswordConfig{Argon2: Argon2Params{Memory: 64, Iterations: 1, SaltLength: 16, KeyLength: 32}}},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			if _, err := NewPasswordHasher(tt.config); err == nil {
+				t.Error("Expected an error")
+			}
+		})
+	}
+}
+
+func TestVerifyRejectsMalformedHash(t *testing.T) {
+	h := DefaultPasswordHasher()
+	for _, encoded := range []string{"", "plaintext", "$argon2id$v=19$m=64,t=1,p=1$salt", "$argon2id$v=16$m=64,t=1,p=1$c2FsdHNhbHQ$aGFzaA"} {
+		if _, err := h.Verify(encoded, "password123"); err == nil {
+			t.Errorf("Expected %q to be rejected", encoded)
+		}
+	}
+}
+
+func TestVerifyDummyUsesSlowestHash(t *testing.T) {
+	// With cheap argon2id parameters, legacy bcrypt hashes are the slowest
+	// a user can have.
+	h := newTestPasswordHasher(t, PasswordConfig{Argon2: testArgon2Params})
+
+	h.VerifyDummy("some password")
+
+	if !isBcryptHash(h.dummyHash) {
+		t.Fatalf("Expected a bcrypt dummy hash, got %q", h.dummyHash)
+	}
+	if cost, err := bcrypt.Cost([]byte(h.dummyHash)); err != nil || cost != legacyBcryptCost {
+		t.Errorf("Expected the legacy bcrypt cost %d, got %d (%v)", legacyBcryptCost, cost, err)
+	}
+	if _, err := h.Verify(h.dummyHash, "some password"); err == nil {
+		t.Errorf("Expected the dummy hash not to match")
+	}
+}
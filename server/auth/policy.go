@@ -0,0 +1,145 @@
+package auth
+
+import (
+	"context"
+	"errors"
+	"fmt"
+	"slices"
+
+	"grpc-server/ent"
+
+	"connectrpc.com/connect"
+)
+
+// Role is the set of permissions a user holds.
+type Role string
+
+const (
+	RoleUser  Role = "user"
+	RoleAdmin Role = "admin"
+)
+
+// Permission allows an action beyond what every signed-in user may do with
+// their own resources.
+type Permission string
+
+const (
+	// PermissionManageAnyItem allows updating and deleting items of other users.
+	PermissionManageAnyItem Permission = "items:manage_any"
+	// PermissionManageUsers allows reading, changing and deleting other users.
+	PermissionManageUsers Permission = "users:manage"
+	// PermissionViewAuthEvents allows reading the authentication audit log.
+	PermissionViewAuthEvents Permission = "auth_events:read"
+	// PermissionImpersonate allows acting as another user.
+	PermissionImpersonate Permission = "users:impersonate"
+)
+
+var rolePermissions = map[Role][]Permission{
+	RoleUser: nil,
+	RoleAdmin: {
+		PermissionManageAnyItem,
+		PermissionManageUsers,
+		PermissionViewAuthEvents,
+		PermissionImpersonate,
+	},
+}
+
+// validPermissions are the permissions that can be granted to a user.
+var validPermissions = map[Permission]bool{
+	PermissionManageAnyItem:  true,
+	PermissionManageUsers:    true,
+	PermissionViewAuthEvents: true,
+	PermissionImpersonate:    true,
+}
+
+// ValidatePermissions checks that permissions are all known.
+func ValidatePermissions(permissions []Permission) error {
+	for _, perm := range permissions {
+		if !validPermissions[perm] {
+			return fmt.Errorf("unknown permission %q", perm)
+		}
+	}
+	return nil
+}
+
+// userPermissions returns the permissions u holds on top of their role.
+func userPermissions(u *ent.User) []Permission {
+	permissions := make([]Permission, len(u.Permissions))
+	for i, perm := range u.Permissions {
+		permissions[i] = Permission(perm)
+	}
+	return permissions
+}
+
+// ValidRole reports whether role is a known role.
+func ValidRole(role Role) bool {
+	_, ok := rolePermissions[role]
+	return ok
+}
+
+// HasPermission reports whether the caller's role, or the permissions the
+// caller holds on top of it, grant perm.
+func HasPermission(ctx context.Context, perm Permission) bool {
+	role, _ := GetRoleFromContext(ctx)
+	permissions, _ := GetPermissionsFromContext(ctx)
+	return slices.Contains(rolePermissions[role], perm) || slices.Contains(permissions, perm)
+}
+
+// Authorize requires an authenticated caller who holds perm.
+func Authorize(ctx context.Context, perm Permission) error {
+	if _, err := RequireAuth(ctx); err != nil {
+		return err
+	}
+	if !HasPermission(ctx, perm) {
+		return ErrPermissionDenied
+	}
+	return nil
+}
+
+// AuthorizeOwner requires the caller to be ownerID, or to hold perm over
+// every user's resources.
+func AuthorizeOwner(ctx context.Context, ownerID string, perm Permission) error {
+	userID, err := RequireAuth(ctx)
+	if err != nil {
+		return err
+	}
+	if userID == ownerID || HasPermission(ctx, perm) {
+		return nil
+	}
+	return ErrPermissionDenied
+}
+
+// AuthorizeGrant requires the caller to hold every permission of role and
+// permissions, so that nobody hands out more than they hold.
+func AuthorizeGrant(ctx context.Context, role Role, permissions []Permission) error {
+	for _, perm := range append(slices.Clone(rolePermissions[role]), permissions...) {
+		if !HasPermission(ctx, perm) {
+			return fmt.Errorf("%w: requires the %s permission", ErrPermissionDenied, perm)
+		}
+	}
+	return nil
+}
+
+// AuthorizeOver requires a caller acting on another user u to hold every
+// permission u holds. Managing a user can take over their account, e.g. by
+// changing its email address, so it must not reach users who hold more than
+// the caller.
+func AuthorizeOver(ctx context.Context, u *ent.User) error {
+	if callerID, _ := GetUserIDFromContext(ctx); callerID == u.ID {
+		return nil
+	}
+	return AuthorizeGrant(ctx, Role(u.Role), userPermissions(u))
+}
+
+// AuthorizationError turns an error from Authorize or AuthorizeOwner into
+// the matching connect error.
+func AuthorizationError(err error) *connect.Error {
+	switch {
+	case errors.Is(err, ErrUnauthorized):
+		return connect.NewError(connect.CodeUnauthenticated, err)
+	case errors.Is(err, ErrPermissionDenied):
+		return connect.NewError(connect.CodePermissionDenied, err)
+	default:
+		return connect.NewError(connect.CodeInternal, err)
+	}
+}
@@ -0,0 +1,128 @@
+package auth
+
+import (
+	"context"
+	"testing"
+
+	"grpc-server/ent"
+	"grpc-server/ent/user"
+
+	"connectrpc.com/connect"
+)
+
+func contextWithUser(userID string, role Role) context.Context {
+	ctx := context.WithValue(context.Background(), UserIDContextKey, userID)
+	return context.WithValue(ctx, RoleContextKey, role)
+}
+
+func TestAuthorize(t *testing.T) {
+	tests := []struct {
+		name string
+		ctx  context.Context
+		code connect.Code
+	}{
+		{"anonymous", context.Background(), connect.CodeUnauthenticated},
+		{"user", contextWithUser("user-1", RoleUser), connect.CodePermissionDenied},
+		{"admin", contextWithUser("admin-1", RoleAdmin), 0},
+		{"user granted the permission", contextWithPermissions(contextWithUser("user-1", RoleUser), PermissionManageUsers), 0},
+		{"user granted another permission", contextWithPermissions(contextWithUser("user-1", RoleUser), PermissionViewAuthEvents), connect.CodePermissionDenied},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			err := Authorize(tt.ctx, PermissionManageUsers)
+			if tt.code == 0 {
+				if err != nil {
+					t.Errorf("Expected no error, got %v", err)
+				}
+				return
+			}
+			if err == nil {
+				t.Fatalf("Expected %v, got no error", tt.code)
+			}
+			if got := AuthorizationError(err).Code(); got != tt.code {
+				t.Errorf("Expected %v, got %v", tt.code, got)
+			}
+		})
+	}
+}
+
+func TestAuthorizeOwner(t *testing.T) {
+	tests := []struct {
+		name    string
+		ctx     context.Context
+		ownerID string
+		wantErr bool
+	}{
+		{"owner", contextWithUser("user-1", RoleUser), "user-1", false},
+		{"other user", contextWithUser("user-2", RoleUser), "user-1", true},
+		{"admin", contextWithUser("admin-1", RoleAdmin), "user-1", false},
+		{"unowned", contextWithUser("user-1", RoleUser), "", true},
+		{"anonymous", context.Background(), "user-1", true},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			err := AuthorizeOwner(tt.ctx, tt.ownerID, PermissionManageAnyItem)
+			if (err != nil) != tt.wantErr {
+				t.Errorf("AuthorizeOwner() error = %v, wantErr %v", err, tt.wantErr)
+			}
+		})
+	}
+}
+
+func TestRoleProtoRoundTrip(t *testing.T) {
+	for role := range rolePermissions {
+		if got := RoleFromProto(RoleToProto(role)); got != role {
+			t.Errorf("Expected %q to round-trip, got %q", role, got)
+		}
+	}
+}
+
+func TestValidatePermissions(t *testing.T) {
+	if err := ValidatePermissions([]Permission{PermissionManageUsers, PermissionViewAuthEvents}); err != nil {
+		t.Errorf("Expected known permissions to be valid, got %v", err)
+	}
+	if err := ValidatePermissions([]Permission{"users:own"}); err == nil {
+		t.Error("Expected an unknown permission to be rejected")
+	}
+}
+
+func TestAuthorizeOver(t *testing.T) {
+	manager := contextWithPermissions(contextWithUser("manager-1", RoleUser), PermissionManageUsers)
+
+	tests := []struct {
+		name    string
+		ctx     context.Context
+		target  *ent.User
+		wantErr bool
+	}{
+		{"manager over user", manager, &ent.User{ID: "user-1", Role: user.Role(RoleUser)}, false},
+		{"manager over admin", manager, &ent.User{ID: "admin-1", Role: user.Role(RoleAdmin)}, true},
+		{"manager over another permission", manager, &ent.User{ID: "user-1", Role: user.Role(RoleUser), Permissions: []string{string(PermissionViewAuthEvents)}}, true},
+		{"manager over an equal", manager, &ent.User{ID: "manager-2", Role: user.Role(RoleUser), Permissions: []string{string(PermissionManageUsers)}}, false},
+		{"admin over admin", contextWithUser("admin-1", RoleAdmin), &ent.User{ID: "admin-2", Role: user.Role(RoleAdmin)}, false},
+		{"user over themselves", contextWithUser("user-1", RoleUser), &ent.User{ID: "user-1", Role: user.Role(RoleUser), Permissions: []string{string(PermissionManageUsers)}}, false},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			if err := AuthorizeOver(tt.ctx, tt.target); (err != nil) != tt.wantErr {
+				t.Errorf("AuthorizeOver() error = %v, wantErr %v", err, tt.wantErr)
+			}
+		})
+	}
+}
+
+func TestAuthorizeGrant(t *testing.T) {
+	manager := contextWithPermissions(contextWithUser("manager-1", RoleUser), PermissionManageUsers)
+	if err := AuthorizeGrant(manager, RoleUser, []Permission{PermissionManageUsers}); err != nil {
+		t.Errorf("Expected a manager to grant what they hold, got %v", err)
+	}
+	if err := AuthorizeGrant(manager, RoleAdmin, nil); err == nil {
+		t.Error("Expected a manager to be refused the admin role")
+	}
+	if err := AuthorizeGrant(manager, RoleUser, []Permission{PermissionImpersonate}); err == nil {
+		t.Error("Expected a manager to be refused a permission they don't hold")
+	}
+}
@@ -0,0 +1,216 @@
+package auth
+
+import (
+	"context"
+	"errors"
+	"fmt"
+	"time"
+
+	"grpc-server/ent"
+	"grpc-server/ent/refreshtoken"
+	"grpc-server/ent/session"
+	"grpc-server/ent/user"
+)
+
+// issueTokenPair generates a token pair for u and persists the refresh token
+// as the newest member of familyID, which is the ID of the session.
+func (a *Authenticator) issueTokenPair(ctx context.Context, client *ent.Client, u *ent.User, familyID string) (*TokenPair, error) {
+	tokenPair, err := a.GenerateTokenPair(u.ID, u.Email, Role(u.Role), familyID, userPermissions(u)...)
+	if err != nil {
+		return nil, err
+	}
+
+	err = client.RefreshToken.
+		Create().
+		SetTokenHash(hashToken(tokenPair.RefreshToken)).
+		SetFamilyID(familyID).
+		SetExpiresAt(tokenPair.RefreshTokenExpiry).
+		SetUserID(u.ID).
+		Exec(ctx)
+	if err != nil {
+		return nil, fmt.Errorf("failed to store refresh token: %w", err)
+	}
+
+	return tokenPair, nil
+}
+
+// rotateRefreshToken exchanges a stored refresh token for a new pair in the
+// same family. Presenting a token that was already rotated out revokes the
+// whole family, since it means the token has been copied.
+func (a *Authenticator) rotateRefreshToken(ctx context.Context, client *ent.Client, token string, info sessionInfo) (*TokenPair, error) {
+	tx, err := client.Tx(ctx)
+	if err != nil {
+		return nil, fmt.Errorf("failed to start transaction: %w", err)
+	}
+
+	tokenPair, err := a.rotateRefreshTokenTx(ctx, tx, token, info)
+	if err != nil && !errors.Is(err, ErrRefreshTokenReused) {
+		_ = tx.Rollback()
+		return nil, err
+	}
+
+	// A detected reuse still commits so the family revocation sticks.
+	if commitErr := tx.Commit(); commitErr != nil {
+		return nil, fmt.Errorf("failed to commit transaction: %w", commitErr)
+	}
+
+	return tokenPair, err
+}
+
+func (a *Authenticator) rotateRefreshTokenTx(ctx context.Context, tx *ent.Tx, token string, info sessionInfo) (*TokenPair, error) {
+	now := time.Now()
+
+	stored, err := tx.RefreshToken.
+		Query().
+		Where(refreshtoken.TokenHashEQ(hashToken(token))).
+		WithUser().
+		Only(ctx)
+	if err != nil {
+		if ent.IsNotFound(err) {
+			return nil, ErrInvalidRefreshToken
+		}
+		return nil, fmt.Errorf("failed to query refresh token: %w", err)
+	}
+
+	if err := checkRefreshToken(stored, now); err != nil {
+		if errors.Is(err, ErrRefreshTokenReused) {
+			if err := a.revokeTokenFamily(ctx, tx.Client(), stored.FamilyID, now); err != nil {
+				return nil, err
+			}
+		}
+		return nil, err
+	}
+
+	// The RevokedAtIsNil guard makes concurrent rotations of the same token
+	// race on the row: only one of them can claim it, the other is reuse.
+	n, err := tx.RefreshToken.
+		Update().
+		Where(
+			refreshtoken.IDEQ(stored.ID),
+			refreshtoken.RevokedAtIsNil(),
+		).
+		SetRevokedAt(now).
+		Save(ctx)
+	if err != nil {
+		return nil, fmt.Errorf("failed to rotate refresh token: %w", err)
+	}
+	if n == 0 {
+		if err := a.revokeTokenFamily(ctx, tx.Client(), stored.FamilyID, now); err != nil {
+			return nil, err
+		}
+		return nil, ErrRefreshTokenReused
+	}
+
+	if err := touchSession(ctx, tx.Client(), stored.FamilyID, info, now); err != nil {
+		return nil, err
+	}
+
+	return a.issueTokenPair(ctx, tx.Client(), stored.Edges.User, stored.FamilyID)
+}
+
+// checkRefreshToken reports whether stored may be rotated at now. A token
+// that was rotated out or revoked is ErrRefreshTokenReused, and its family
+// must be revoked; an expired one is merely invalid.
+func checkRefreshToken(stored *ent.RefreshToken, now time.Time) error {
+	if stored.RevokedAt != nil {
+		return ErrRefreshTokenReused
+	}
+	if now.After(stored.ExpiresAt) {
+		return ErrInvalidRefreshToken
+	}
+	return nil
+}
+
+// revokeTokenFamily ends the session familyID: its refresh tokens are
+// revoked and the access tokens issued to it are rejected.
+func (a *Authenticator) revokeTokenFamily(ctx context.Context, client *ent.Client, familyID string, now time.Time) error {
+	err := client.RefreshToken.
+		Update().
+		Where(
+			refreshtoken.FamilyIDEQ(familyID),
+			refreshtoken.RevokedAtIsNil(),
+		).
+		SetRevokedAt(now).
+		Exec(ctx)
+	if err != nil {
+		return fmt.Errorf("failed to revoke token family: %w", err)
+	}
+
+	err = client.Session.
+		Update().
+		Where(
+			session.IDEQ(familyID),
+			session.RevokedAtIsNil(),
+		).
+		SetRevokedAt(now).
+		Exec(ctx)
+	if err != nil {
+		return fmt.Errorf("failed to revoke session: %w", err)
+	}
+
+	a.revocations.revokeSession(familyID, now)
+	return nil
+}
+
+// revokeUserSessions signs userID out everywhere: every refresh token is
+// revoked and every access token issued so far is rejected. The in-memory
+// revocation applies at once, even if client belongs to a transaction that
+// later rolls back; that only errs on the side of signing the user out.
+func (a *Authenticator) revokeUserSessions(ctx context.Context, client *ent.Client, userID string) error {
+	return a.revokeSessionsExcept(ctx, client, userID, "")
+}
+
+// revokeOtherSessions signs u out of every session but sessionID and issues
+// fresh tokens for it, since its current access token is revoked as well.
+func (a *Authenticator) revokeOtherSessions(ctx context.Context, client *ent.Client, u *ent.User, sessionID string, info sessionInfo) (*TokenPair, error) {
+	if err := a.revokeSessionsExcept(ctx, client, u.ID, sessionID); err != nil {
+		return nil, err
+	}
+
+	if sessionID == "" {
+		return a.startSession(ctx, client, u, info)
+	}
+	return a.issueTokenPair(ctx, client, u, sessionID)
+}
+
+// revokeSessionsExcept revokes every refresh token and access token of
+// userID, and marks every session but keep as ended.
+func (a *Authenticator) revokeSessionsExcept(ctx context.Context, client *ent.Client, userID, keep string) error {
+	now := time.Now()
+
+	err := client.RefreshToken.
+		Update().
+		Where(
+			refreshtoken.HasUserWith(user.IDEQ(userID)),
+			refreshtoken.RevokedAtIsNil(),
+		).
+		SetRevokedAt(now).
+		Exec(ctx)
+	if err != nil {
+		return fmt.Errorf("failed to revoke refresh tokens: %w", err)
+	}
+
+	err = client.Session.
+		Update().
+		Where(
+			session.HasUserWith(user.IDEQ(userID)),
+			session.IDNEQ(keep),
+			session.RevokedAtIsNil(),
+		).
+		SetRevokedAt(now).
+		Exec(ctx)
+	if err != nil {
+		return fmt.Errorf("failed to revoke sessions: %w", err)
+	}
+
+	err = client.User.
+		UpdateOneID(userID).
+		SetSessionsRevokedAt(now).
+		Exec(ctx)
+	if err != nil {
+		return fmt.Errorf("failed to revoke sessions: %w", err)
+	}
+
+	a.revocations.revokeUserBefore(userID, now)
+	return nil
+}
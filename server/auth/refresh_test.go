@@ -0,0 +1,88 @@
+package auth
+
+import (
+	"context"
+	"errors"
+	"testing"
+	"time"
+
+	"grpc-server/ent"
+)
+
+func TestCheckRefreshToken(t *testing.T) {
+	now := time.Now()
+	earlier := now.Add(-time.Minute)
+
+	tests := []struct {
+		name   string
+		stored *ent.RefreshToken
+		want   error
+	}{
+		{"valid", &ent.RefreshToken{ExpiresAt: now.Add(time.Hour)}, nil},
+		{"expired", &ent.RefreshToken{ExpiresAt: earlier}, ErrInvalidRefreshToken},
+		{"rotated out", &ent.RefreshToken{ExpiresAt: now.Add(time.Hour), RevokedAt: &earlier}, ErrRefreshTokenReused},
+		// A copied token is still reuse after it expires.
+		{"rotated out and expired", &ent.RefreshToken{ExpiresAt: earlier, RevokedAt: &earlier}, ErrRefreshTokenReused},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			if err := checkRefreshToken(tt.stored, now); !errors.Is(err, tt.want) {
+				t.Errorf("checkRefreshToken() error = %v, want %v", err, tt.want)
+			}
+		})
+	}
+}
+
+func TestRevokeTokenFamily(t *testing.T) {
+	a := newTestAuthenticator(t)
+	a.revocations = NewRevocationStore(nil)
+
+	family, err := a.GenerateTokenPair("user-1", "user@example.com", RoleUser, "session-1")
+	if err != nil {
+		t.Fatalf("GenerateTokenPair() error = %v", err)
+	}
+	other, err := a.GenerateTokenPair("user-1", "user@example.com", RoleUser, "session-2")
+	if err != nil {
+		t.Fatalf("GenerateTokenPair() error = %v", err)
+	}
+
+	client, drv := newFakeClient()
+	if err := a.revokeTokenFamily(context.Background(), client, "session-1", time.Now()); err != nil {
+		t.Fatalf("revokeTokenFamily() error = %v", err)
+	}
+
+	for _, table := range []string{`UPDATE "refresh_tokens"`, `UPDATE "sessions"`} {
+		if len(drv.find(table)) != 1 {
+			t.Errorf("Expected one %s statement, got %v", table, drv.statements)
+		}
+	}
+
+	if _, err := a.ValidateAccessToken(family.AccessToken); err == nil {
+		t.Errorf("Expected access tokens of the revoked family to be rejected")
+	}
+	if _, err := a.ValidateAccessToken(other.AccessToken); err != nil {
+		t.Errorf("Expected access tokens of other sessions to stay valid, got %v", err)
+	}
+}
+
+func TestRevokeTokenFamilyFailure(t *testing.T) {
+	a := newTestAuthenticator(t)
+	a.revocations = NewRevocationStore(nil)
+
+	pair, err := a.GenerateTokenPair("user-1", "user@example.com", RoleUser, "session-1")
+	if err != nil {
+		t.Fatalf("GenerateTokenPair() error = %v", err)
+	}
+
+	client, drv := newFakeClient()
+	drv.err = errFakeDatabase
+	if err := a.revokeTokenFamily(context.Background(), client, "session-1", time.Now()); err == nil {
+		t.Fatalf("Expected revokeTokenFamily() to fail when the database does")
+	}
+
+	// The in-memory copy only follows revocations that were written.
+	if _, err := a.ValidateAccessToken(pair.AccessToken); err != nil {
+		t.Errorf("Expected the access token to stay valid, got %v", err)
+	}
+}
@@ -0,0 +1,33 @@
+package auth
+
+import (
+	"net"
+	"net/http"
+
+	"connectrpc.com/connect"
+)
+
+// clientIP returns the IP address of the peer that sent the request.
+func clientIP(peer connect.Peer) string {
+	host, _, err := net.SplitHostPort(peer.Addr)
+	if err != nil {
+		return peer.Addr
+	}
+	return host
+}
+
+// connectSessionInfo describes the client that sent req.
+func connectSessionInfo(req connect.AnyRequest) sessionInfo {
+	return sessionInfo{
+		UserAgent: req.Header().Get("User-Agent"),
+		IPAddress: clientIP(req.Peer()),
+	}
+}
+
+// httpSessionInfo describes the client that sent r.
+func httpSessionInfo(r *http.Request) sessionInfo {
+	return sessionInfo{
+		UserAgent: r.UserAgent(),
+		IPAddress: clientIP(connect.Peer{Addr: r.RemoteAddr}),
+	}
+}
@@ -0,0 +1,137 @@
+package auth
+
+import (
+	"context"
+	"fmt"
+	"net/url"
+	"time"
+
+	"grpc-server/ent"
+	"grpc-server/ent/passwordresettoken"
+	"grpc-server/ent/user"
+	"grpc-server/mail"
+)
+
+const (
+	passwordResetExpiry     = time.Hour
+	passwordResetTokenBytes = 32
+)
+
+// sendPasswordReset emails a reset link to email if it belongs to a user.
+// Unknown addresses are silently ignored so callers cannot tell them apart.
+func (a *Authenticator) sendPasswordReset(ctx context.Context, email string) error {
+	u, err := a.client.User.
+		Query().
+		Where(emailIs(email)).
+		Only(ctx)
+	if err != nil {
+		if ent.IsNotFound(err) {
+			return nil
+		}
+		return fmt.Errorf("failed to query user: %w", err)
+	}
+
+	token, err := randomToken(passwordResetTokenBytes)
+	if err != nil {
+		return err
+	}
+
+	err = a.client.PasswordResetToken.
+		Create().
+		SetTokenHash(hashToken(token)).
+		SetExpiresAt(time.Now().Add(passwordResetExpiry)).
+		SetUser(u).
+		Exec(ctx)
+	if err != nil {
+		return fmt.Errorf("failed to store password reset token: %w", err)
+	}
+
+	link := a.config.AppURL + "/reset-password?token=" + url.QueryEscape(token)
+	err = a.config.Mailer.Send(ctx, mail.Message{
+		To:      u.Email,
+		Subject: "Reset your password",
+		Body: fmt.Sprintf("Hi %s,\n\n"+
+			"Someone asked to reset the password for your account. Open the link below to choose a new one:\n\n%s\n\n"+
+			"The link expires in %d minutes and can only be used once. If you did not ask for this, you can ignore this email.\n",
+			u.Name, link, int(passwordResetExpiry.Minutes())),
+	})
+	if err != nil {
+		return fmt.Errorf("failed to send password reset email: %w", err)
+	}
+	return nil
+}
+
+// resetPassword spends token, sets password on its user and signs that user
+// out everywhere. It returns a *PasswordPolicyError if the user may not
+// choose password.
+func (a *Authenticator) resetPassword(ctx context.Context, token, password string) error {
+	tx, err := a.client.Tx(ctx)
+	if err != nil {
+		return fmt.Errorf("failed to start transaction: %w", err)
+	}
+
+	if err := a.resetPasswordTx(ctx, tx, token, password); err != nil {
+		_ = tx.Rollback()
+		return err
+	}
+
+	if err := tx.Commit(); err != nil {
+		return fmt.Errorf("failed to commit transaction: %w", err)
+	}
+	return nil
+}
+
+func (a *Authenticator) resetPasswordTx(ctx context.Context, tx *ent.Tx, token, password string) error {
+	now := time.Now()
+
+	stored, err := tx.PasswordResetToken.
+		Query().
+		Where(passwordresettoken.TokenHashEQ(hashToken(token))).
+		WithUser().
+		ForUpdate().
+		Only(ctx)
+	if err != nil {
+		if ent.IsNotFound(err) {
+			return ErrInvalidToken
+		}
+		return fmt.Errorf("failed to query password reset token: %w", err)
+	}
+
+	if stored.UsedAt != nil || now.After(stored.ExpiresAt) {
+		return ErrInvalidToken
+	}
+
+	u := stored.Edges.User
+	auditFromContext(ctx).setUser(u.ID)
+	if err := a.policy.Check(password, u.Email, u.Name); err != nil {
+		return err
+	}
+	passwordHash, err := a.passwords.Hash(password)
+	if err != nil {
+		return err
+	}
+
+	userID := u.ID
+	err = tx.User.
+		UpdateOneID(userID).
+		SetPasswordHash(passwordHash).
+		Exec(ctx)
+	if err != nil {
+		return fmt.Errorf("failed to update password: %w", err)
+	}
+
+	// Spend every outstanding link, not just this one.
+	err = tx.PasswordResetToken.
+		Update().
+		Where(
+			passwordresettoken.HasUserWith(user.IDEQ(userID)),
+			passwordresettoken.UsedAtIsNil(),
+		).
+		SetUsedAt(now).
+		Exec(ctx)
+	if err != nil {
+		return fmt.Errorf("failed to spend password reset tokens: %w", err)
+	}
+
+	return a.revokeUserSessions(ctx, tx.Client(), userID)
+}
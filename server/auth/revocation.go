@@ -0,0 +1,234 @@
+package auth
+
+import (
+	"context"
+	"fmt"
+	"log"
+	"sync"
+	"time"
+
+	"grpc-server/ent"
+	"grpc-server/ent/revokedtoken"
+	"grpc-server/ent/session"
+	"grpc-server/ent/user"
+)
+
+// RevocationStore records the jti of access tokens that were revoked before
+// they expired, sessions that were ended, and users whose tokens were all
+// revoked at once. Postgres is the source of truth; every instance keeps an
+// in-memory copy so token checks never hit the database. Revocations made by
+// other instances become visible on the next Sync.
+type RevocationStore struct {
+	client *ent.Client
+
+	mu      sync.RWMutex
+	revoked map[string]time.Time
+	// usersRevoked maps a user ID to the time before which all of that
+	// user's access tokens are rejected.
+	usersRevoked map[string]time.Time
+	// sessionsRevoked maps the ID of an ended session to when it ended.
+	sessionsRevoked map[string]time.Time
+}
+
+func NewRevocationStore(client *ent.Client) *RevocationStore {
+	return &RevocationStore{
+		client:          client,
+		revoked:         make(map[string]time.Time),
+		usersRevoked:    make(map[string]time.Time),
+		sessionsRevoked: make(map[string]time.Time),
+	}
+}
+
+// Revoke marks jti as revoked until expiresAt, after which the token is
+// rejected on its own and the entry can be pruned.
+func (s *RevocationStore) Revoke(ctx context.Context, jti string, expiresAt time.Time) error {
+	if err := s.insert(ctx, jti, expiresAt); err != nil && !ent.IsConstraintError(err) {
+		return fmt.Errorf("failed to revoke token: %w", err)
+	}
+	return nil
+}
+
+// Consume marks a single-use token as spent. It returns ErrTokenAlreadyUsed
+// if the token was consumed before, by this or any other instance.
+func (s *RevocationStore) Consume(ctx context.Context, jti string, expiresAt time.Time) error {
+	if err := s.insert(ctx, jti, expiresAt); err != nil {
+		if ent.IsConstraintError(err) {
+			return ErrTokenAlreadyUsed
+		}
+		return fmt.Errorf("failed to consume token: %w", err)
+	}
+	return nil
+}
+
+// insert records jti in the database and the in-memory copy. It returns a
+// constraint error if jti was already recorded.
+func (s *RevocationStore) insert(ctx context.Context, jti string, expiresAt time.Time) error {
+	err := s.client.RevokedToken.
+		Create().
+		SetID(jti).
+		SetExpiresAt(expiresAt).
+		Exec(ctx)
+	if err != nil && !ent.IsConstraintError(err) {
+		return err
+	}
+
+	s.mu.Lock()
+	s.revoked[jti] = expiresAt
+	s.mu.Unlock()
+
+	return err
+}
+
+// revokeUserBefore records a user-wide revocation that was already written
+// to the database.
+func (s *RevocationStore) revokeUserBefore(userID string, at time.Time) {
+	s.mu.Lock()
+	defer s.mu.Unlock()
+	if at.After(s.usersRevoked[userID]) {
+		s.usersRevoked[userID] = at
+	}
+}
+
+// revokeSession records the end of a session that was already written to
+// the database.
+func (s *RevocationStore) revokeSession(sessionID string, at time.Time) {
+	s.mu.Lock()
+	defer s.mu.Unlock()
+	if _, ok := s.sessionsRevoked[sessionID]; !ok {
+		s.sessionsRevoked[sessionID] = at
+	}
+}
+
+// IsRevoked reports whether the token with the given claims was revoked,
+// on its own, with its session, or together with every other token of its
+// user.
+func (s *RevocationStore) IsRevoked(claims *Claims) bool {
+	s.mu.RLock()
+	defer s.mu.RUnlock()
+
+	if _, ok := s.revoked[claims.ID]; ok {
+		return true
+	}
+	if _, ok := s.sessionsRevoked[claims.SessionID]; ok && claims.SessionID != "" {
+		return true
+	}
+
+	// Impersonation tokens also die with the admin's sessions, e.g. when
+	// the admin is demoted.
+	if claims.Actor != nil && s.revokedBefore(claims.Actor.Subject, claims) {
+		return true
+	}
+	return s.revokedBefore(claims.UserID, claims)
+}
+
+// revokedBefore reports whether claims were issued before all tokens of
+// userID were revoked. The caller must hold s.mu.
+func (s *RevocationStore) revokedBefore(userID string, claims *Claims) bool {
+	// iat has second precision, so compare against the revocation's second:
+	// tokens issued right after a revocation must stay valid.
+	revokedBefore, ok := s.usersRevoked[userID]
+	return ok && claims.IssuedAt != nil && claims.IssuedAt.Before(revokedBefore.Truncate(time.Second))
+}
+
+// Sync loads the unexpired entries from the database into the in-memory copy.
+func (s *RevocationStore) Sync(ctx context.Context) error {
+	entries, err := s.client.RevokedToken.
+		Query().
+		Where(revokedtoken.ExpiresAtGT(time.Now())).
+		All(ctx)
+	if err != nil {
+		return fmt.Errorf("failed to load revoked tokens: %w", err)
+	}
+
+	// Access tokens issued before a user-wide revocation that is older than
+	// their lifetime have expired on their own.
+	users, err := s.client.User.
+		Query().
+		Where(user.SessionsRevokedAtGT(time.Now().Add(-accessTokenExpiry))).
+		All(ctx)
+	if err != nil {
+		return fmt.Errorf("failed to load revoked sessions: %w", err)
+	}
+
+	sessions, err := s.client.Session.
+		Query().
+		Where(session.RevokedAtGT(time.Now().Add(-accessTokenExpiry))).
+		All(ctx)
+	if err != nil {
+		return fmt.Errorf("failed to load ended sessions: %w", err)
+	}
+
+	// Revocations are never undone, so merging cannot resurrect a token and
+	// keeps a concurrent local Revoke from being dropped.
+	s.mu.Lock()
+	for _, entry := range entries {
+		s.revoked[entry.ID] = entry.ExpiresAt
+	}
+	for _, u := range users {
+		if u.SessionsRevokedAt.After(s.usersRevoked[u.ID]) {
+			s.usersRevoked[u.ID] = *u.SessionsRevokedAt
+		}
+	}
+	for _, revoked := range sessions {
+		if _, ok := s.sessionsRevoked[revoked.ID]; !ok {
+			s.sessionsRevoked[revoked.ID] = *revoked.RevokedAt
+		}
+	}
+	s.mu.Unlock()
+
+	return nil
+}
+
+// Prune deletes entries whose tokens have expired.
+func (s *RevocationStore) Prune(ctx context.Context) error {
+	now := time.Now()
+
+	_, err := s.client.RevokedToken.
+		Delete().
+		Where(revokedtoken.ExpiresAtLTE(now)).
+		Exec(ctx)
+	if err != nil {
+		return fmt.Errorf("failed to prune revoked tokens: %w", err)
+	}
+
+	s.mu.Lock()
+	for jti, expiresAt := range s.revoked {
+		if !expiresAt.After(now) {
+			delete(s.revoked, jti)
+		}
+	}
+	for userID, revokedBefore := range s.usersRevoked {
+		if !revokedBefore.Add(accessTokenExpiry).After(now) {
+			delete(s.usersRevoked, userID)
+		}
+	}
+	for sessionID, revokedAt := range s.sessionsRevoked {
+		if !revokedAt.Add(accessTokenExpiry).After(now) {
+			delete(s.sessionsRevoked, sessionID)
+		}
+	}
+	s.mu.Unlock()
+
+	return nil
+}
+
+// RunSweeper syncs and prunes the store every interval until ctx is done.
+func (s *RevocationStore) RunSweeper(ctx context.Context, interval time.Duration) {
+	ticker := time.NewTicker(interval)
+	defer ticker.Stop()
+
+	for {
+		if err := s.Prune(ctx); err != nil {
+			log.Printf("revocation sweeper: %v", err)
+		}
+		if err := s.Sync(ctx); err != nil {
+			log.Printf("revocation sweeper: %v", err)
+		}
+
+		select {
+		case <-ctx.Done():
+			return
+		case <-ticker.C:
+		}
+	}
+}
@@ -0,0 +1,85 @@
+package auth
+
+import (
+	"testing"
+	"time"
+
+	"github.com/golang-jwt/jwt/v5"
+)
+
+func TestRevocationStoreIsRevokedByUser(t *testing.T) {
+	s := NewRevocationStore(nil)
+	revokedAt := time.Date(2024, 1, 1, 12, 0, 0, 500_000_000, time.UTC)
+	s.revokeUserBefore("user-1", revokedAt)
+
+	claims := func(userID string, issuedAt time.Time) *Claims {
+		return &Claims{
+			UserID: userID,
+			RegisteredClaims: jwt.RegisteredClaims{
+				ID:       "jti",
+				IssuedAt: jwt.NewNumericDate(issuedAt),
+			},
+		}
+	}
+
+	tests := []struct {
+		name   string
+		claims *Claims
+		want   bool
+	}{
+		{
+			name:   "issued before revocation",
+			claims: claims("user-1", revokedAt.Add(-time.Minute)),
+			want:   true,
+		},
+		{
+			name:   "issued in the same second",
+			claims: claims("user-1", revokedAt),
+			want:   false,
+		},
+		{
+			name:   "issued after revocation",
+			claims: claims("user-1", revokedAt.Add(time.Minute)),
+			want:   false,
+		},
+		{
+			name:   "other user",
+			claims: claims("user-2", revokedAt.Add(-time.Minute)),
+			want:   false,
+		},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			if got := s.IsRevoked(tt.claims); got != tt.want {
+				t.Errorf("IsRevoked() = %v, want %v", got, tt.want)
+			}
+		})
+	}
+}
+
+func TestRevocationStoreIsRevokedBySession(t *testing.T) {
+	s := NewRevocationStore(nil)
+	s.revokeSession("session-1", time.Now())
+
+	claims := func(sessionID string) *Claims {
+		return &Claims{
+			UserID:    "user-1",
+			SessionID: sessionID,
+			RegisteredClaims: jwt.RegisteredClaims{
+				ID:       "jti",
+				IssuedAt: jwt.NewNumericDate(time.Now()),
+			},
+		}
+	}
+
+	if !s.IsRevoked(claims("session-1")) {
+		t.Errorf("Expected token of an ended session to be revoked")
+	}
+	if s.IsRevoked(claims("session-2")) {
+		t.Errorf("Expected token of another session to stay valid")
+	}
+	if s.IsRevoked(claims("")) {
+		t.Errorf("Expected token without a session to stay valid")
+	}
+}
@@ -2,99 +2,221 @@ package auth
 
 import (
 	"context"
+	"errors"
 	"fmt"
+	"log"
 	"strings"
+	"time"
 
 	"grpc-server/database"
 	"grpc-server/ent"
-	"grpc-server/ent/user"
 	"grpc-server/proto-generated/auth"
 	"grpc-server/proto-generated/auth/authconnect"
 
 	"net/http"
 
 	"connectrpc.com/connect"
+	"github.com/golang-jwt/jwt/v5"
 	"google.golang.org/protobuf/types/known/timestamppb"
 )
 
-func Register(db *database.DB, mux *http.ServeMux) {
-	server := NewAuthServer(db)
-	path, handler := authconnect.NewAuthServiceHandler(server)
+func Register(db *database.DB, mux *http.ServeMux, authenticator *Authenticator) {
+	server := NewAuthServer(db, authenticator)
+	path, handler := authconnect.NewAuthServiceHandler(server, connect.WithInterceptors(
+		authenticator.Interceptor(),
+		authenticator.AuditInterceptor(),
+	))
 	mux.Handle(path, handler)
+	mux.Handle(JWKSPath, JWKSHandler(authenticator.keys))
+	mux.Handle(OIDCPathPrefix, authenticator.OIDCHandler())
 }
 
 type Server struct {
-	db *database.DB
+	db            *database.DB
+	authenticator *Authenticator
 }
 
-func NewAuthServer(db *database.DB) *Server {
+func NewAuthServer(db *database.DB, authenticator *Authenticator) *Server {
 	return &Server{
-		db: db,
+		db:            db,
+		authenticator: authenticator,
 	}
 }
 
 func (s *Server) Login(ctx context.Context, req *connect.Request[auth.LoginRequest]) (*connect.Response[auth.LoginResponse], error) {
-	if err := ValidateEmail(req.Msg.Email); err != nil {
+	email := s.authenticator.NormalizeEmail(req.Msg.Email)
+	auditFromContext(ctx).setEmail(email)
+	if err := ValidateEmail(email); err != nil {
 		return nil, connect.NewError(connect.CodeInvalidArgument, err)
 	}
 	if err := ValidatePassword(req.Msg.Password); err != nil {
 		return nil, connect.NewError(connect.CodeInvalidArgument, err)
 	}
-	entUser, err := s.db.Client.User.Query().Where(user.EmailEQ((req.Msg.Email))).Only(ctx)
+
+	ip := clientIP(req.Peer())
+	if err := s.authenticator.loginThrottle.Check(ctx, email, ip); err != nil {
+		var throttled *ThrottledError
+		if errors.As(err, &throttled) {
+			return nil, throttledError(throttled)
+		}
+		return nil, connect.NewError(connect.CodeInternal, err)
+	}
+
+	entUser, err := s.db.Client.User.Query().Where(emailIs(email)).Only(ctx)
 
 	if err != nil {
 		if ent.IsNotFound(err) {
-			return nil, connect.NewError(connect.CodeNotFound, fmt.Errorf("user not found"))
+			// Spend as long as a wrong password would, so the response
+			// time does not tell whether the email is registered.
+			s.authenticator.passwords.VerifyDummy(req.Msg.Password)
+			s.recordLoginFailure(ctx, email, ip)
+			return nil, connect.NewError(connect.CodeUnauthenticated, ErrInvalidCredentials)
 		}
-		return nil, connect.NewError(connect.CodeNotFound, fmt.Errorf("failed to query user : %w", err))
+		return nil, connect.NewError(connect.CodeInternal, fmt.Errorf("failed to query user : %w", err))
 	}
+	auditFromContext(ctx).setUser(entUser.ID)
 
-	if verifyErr := VerifyPassword(entUser.PasswordHash, req.Msg.Password); verifyErr != nil {
-		return nil, connect.NewError(connect.CodeUnauthenticated, fmt.Errorf("invalid password"))
+	rehash, verifyErr := s.authenticator.passwords.Verify(entUser.PasswordHash, req.Msg.Password)
+	if verifyErr != nil {
+		s.recordLoginFailure(ctx, email, ip)
+		return nil, connect.NewError(connect.CodeUnauthenticated, ErrInvalidCredentials)
+	}
+	if rehash {
+		s.authenticator.rehashPassword(ctx, entUser, req.Msg.Password)
+	}
+
+	if err := s.authenticator.loginThrottle.Reset(ctx, email); err != nil {
+		log.Printf("login: %v", err)
 	}
 
-	tokenPair, err := GenerateTokenPair(entUser.ID, entUser.Email)
+	return s.signIn(ctx, entUser, connectSessionInfo(req))
+}
+
+// signIn answers a successful first factor: with an MFA challenge if the
+// user has MFA enabled, and with a new session otherwise.
+func (s *Server) signIn(ctx context.Context, entUser *ent.User, client sessionInfo) (*connect.Response[auth.LoginResponse], error) {
+	mfaEnabled, err := s.authenticator.mfaEnabled(ctx, entUser.ID)
+	if err != nil {
+		return nil, connect.NewError(connect.CodeInternal, err)
+	}
+	if mfaEnabled {
+		challenge, err := s.authenticator.generateMFAChallenge(entUser)
+		if err != nil {
+			return nil, connect.NewError(connect.CodeInternal, fmt.Errorf("failed to generate MFA challenge: %w", err))
+		}
+		return connect.NewResponse(&auth.LoginResponse{
+			MfaRequired: true,
+			MfaToken:    challenge,
+		}), nil
+	}
+
+	tokenPair, err := s.authenticator.startSession(ctx, s.db.Client, entUser, client)
 	if err != nil {
 		return nil, connect.NewError(connect.CodeInternal, fmt.Errorf("failed to generated tokens : %w", err))
 	}
-	return connect.NewResponse(&auth.LoginResponse{
-		User: entUserToProto(entUser),
-		Tokens: &auth.TokenPair{
-			AccessToken:  tokenPair.AccessToken,
-			RefreshToken: tokenPair.RefreshToken,
-			ExpiresAt:    timestamppb.New(tokenPair.AccessTokenExpiry),
-		},
-	}), nil
+	res := connect.NewResponse(&auth.LoginResponse{
+		User: EntUserToProto(entUser),
+	})
+	res.Msg.Tokens, err = s.authenticator.deliverTokens(res.Header(), tokenPair)
+	if err != nil {
+		return nil, connect.NewError(connect.CodeInternal, err)
+	}
+	return res, nil
+}
+
+// throttleMail counts a request that emails email against the address and
+// the client IP, and rejects it once either has asked for too many. Every
+// request counts, whether or not the address is registered, so the limit
+// gives nothing away.
+func (s *Server) throttleMail(ctx context.Context, email, ip string) error {
+	if err := s.authenticator.mailThrottle.Check(ctx, email, ip); err != nil {
+		var throttled *ThrottledError
+		if errors.As(err, &throttled) {
+			return throttledError(throttled)
+		}
+		return connect.NewError(connect.CodeInternal, err)
+	}
+	if err := s.authenticator.mailThrottle.RecordFailure(ctx, email, ip); err != nil {
+		return connect.NewError(connect.CodeInternal, err)
+	}
+	return nil
+}
+
+// recordLoginFailure counts a failed login. Failing to record it must not
+// change the response, so errors are only logged.
+func (s *Server) recordLoginFailure(ctx context.Context, email, ip string) {
+	if err := s.authenticator.loginThrottle.RecordFailure(ctx, email, ip); err != nil {
+		log.Printf("login: %v", err)
+	}
 }
 
-func (s *Server) Logout(context.Context, *connect.Request[auth.LogoutRequest]) (*connect.Response[auth.LogoutResponse], error) {
-	return connect.NewResponse(&auth.LogoutResponse{}), nil
+func (s *Server) Logout(ctx context.Context, req *connect.Request[auth.LogoutRequest]) (*connect.Response[auth.LogoutResponse], error) {
+	res := connect.NewResponse(&auth.LogoutResponse{})
+	s.authenticator.clearSessionCookies(res.Header())
+
+	accessToken := req.Msg.AccessToken
+	if accessToken == "" {
+		accessToken = s.authenticator.cookieValue(req.Header(), AccessTokenCookie)
+	}
+
+	claims, err := s.authenticator.ValidateToken(accessToken, TokenTypeAccess)
+	if err != nil {
+		// An expired token is already unusable, so logging it out is a no-op.
+		if errors.Is(err, jwt.ErrTokenExpired) {
+			return res, nil
+		}
+		return nil, connect.NewError(connect.CodeInvalidArgument, fmt.Errorf("invalid access token: %w", err))
+	}
+	auditFromContext(ctx).setUser(claims.UserID)
+
+	if err := s.authenticator.RevokeAccessToken(ctx, claims); err != nil {
+		return nil, connect.NewError(connect.CodeInternal, fmt.Errorf("failed to revoke token: %w", err))
+	}
+
+	// Ending the session also revokes its refresh token.
+	if claims.SessionID != "" {
+		err := s.authenticator.revokeSession(ctx, claims.UserID, claims.SessionID)
+		if err != nil && !errors.Is(err, ErrSessionNotFound) {
+			return nil, connect.NewError(connect.CodeInternal, err)
+		}
+	}
+
+	return res, nil
 }
 
 func (s *Server) RefreshToken(ctx context.Context, req *connect.Request[auth.RefreshTokenRequest]) (*connect.Response[auth.RefreshTokenResponse], error) {
-	claims, err := ValidateToken(req.Msg.RefreshToken)
+	refreshToken := req.Msg.RefreshToken
+	if refreshToken == "" {
+		refreshToken = s.authenticator.cookieValue(req.Header(), RefreshTokenCookie)
+	}
 
+	claims, err := s.authenticator.ValidateToken(refreshToken, TokenTypeRefresh)
 	if err != nil {
 		return nil, connect.NewError(connect.CodeInvalidArgument, fmt.Errorf("failed to generated tokens : %w", err))
 	}
+	auditFromContext(ctx).setUser(claims.UserID)
 
-	tokenPair, err := GenerateTokenPair(claims.UserID, claims.Email)
+	tokenPair, err := s.authenticator.rotateRefreshToken(ctx, s.db.Client, refreshToken, connectSessionInfo(req))
 
 	if err != nil {
+		if errors.Is(err, ErrInvalidRefreshToken) || errors.Is(err, ErrRefreshTokenReused) {
+			return nil, connect.NewError(connect.CodeUnauthenticated, err)
+		}
 		return nil, connect.NewError(connect.CodeInternal, fmt.Errorf("failed to generated tokens : %w", err))
 	}
 
-	return connect.NewResponse(&auth.RefreshTokenResponse{
-		Tokens: tokenPairToProto(tokenPair),
-	}), nil
+	res := connect.NewResponse(&auth.RefreshTokenResponse{})
+	res.Msg.Tokens, err = s.authenticator.deliverTokens(res.Header(), tokenPair)
+	if err != nil {
+		return nil, connect.NewError(connect.CodeInternal, err)
+	}
+	return res, nil
 }
 
 func (s *Server) Register(ctx context.Context, req *connect.Request[auth.RegisterRequest]) (*connect.Response[auth.RegisterResponse], error) {
-	if err := ValidateEmail(req.Msg.Email); err != nil {
-		return nil, connect.NewError(connect.CodeInvalidArgument, err)
-	}
-
-	if err := ValidatePassword(req.Msg.Password); err != nil {
+	email := s.authenticator.NormalizeEmail(req.Msg.Email)
+	auditFromContext(ctx).setEmail(email)
+	if err := ValidateEmail(email); err != nil {
 		return nil, connect.NewError(connect.CodeInvalidArgument, err)
 	}
 
@@ -102,32 +224,522 @@ func (s *Server) Register(ctx context.Context, req *connect.Request[auth.Registe
 		return nil, connect.NewError(connect.CodeInvalidArgument, fmt.Errorf("name is required"))
 	}
 
-	hashedPassword, err := HashPassword(req.Msg.Password)
+	if err := s.authenticator.policy.Check(req.Msg.Password, email, req.Msg.Name); err != nil {
+		var policyErr *PasswordPolicyError
+		if errors.As(err, &policyErr) {
+			return nil, passwordPolicyError("password", policyErr)
+		}
+		return nil, connect.NewError(connect.CodeInternal, err)
+	}
+
+	// Every registration mails the address, whether or not it is taken.
+	if err := s.throttleMail(ctx, email, clientIP(req.Peer())); err != nil {
+		return nil, err
+	}
+
+	hashedPassword, err := s.authenticator.passwords.Hash(req.Msg.Password)
 	if err != nil {
 		return nil, connect.NewError(connect.CodeInternal, fmt.Errorf("failed to hash password: %w", err))
 	}
 
 	entUser, err := s.db.Client.User.
 		Create().
-		SetEmail(req.Msg.Email).
+		SetEmail(email).
 		SetName(req.Msg.Name).
 		SetPasswordHash(hashedPassword).
 		Save(ctx)
 
-	if err != nil {
-		if strings.Contains(err.Error(), "unique constraint") || strings.Contains(err.Error(), "UNIQUE constraint") {
-			return nil, connect.NewError(connect.CodeAlreadyExists, fmt.Errorf("user with this email already exists"))
+	// Answer the same way whether or not the address is taken, and mail its
+	// owner in the background so neither the response nor its timing gives
+	// the account away.
+	var notify func(context.Context) error
+	switch {
+	case err == nil:
+		auditFromContext(ctx).setUser(entUser.ID)
+		notify = func(ctx context.Context) error {
+			return s.authenticator.SendVerificationEmail(ctx, entUser)
+		}
+	case strings.Contains(err.Error(), "unique constraint") || strings.Contains(err.Error(), "UNIQUE constraint"):
+		auditFromContext(ctx).fail(fmt.Errorf("email is already registered"))
+		notify = func(ctx context.Context) error {
+			return s.authenticator.sendAccountExistsEmail(ctx, email)
 		}
+	default:
 		return nil, connect.NewError(connect.CodeInternal, fmt.Errorf("failed to create user: %w", err))
 	}
 
-	tokenPair, err := GenerateTokenPair(entUser.ID, entUser.Email)
+	s.authenticator.mail.enqueue(ctx, "register", notify)
+
+	return connect.NewResponse(&auth.RegisterResponse{}), nil
+}
+
+func (s *Server) SendVerificationEmail(ctx context.Context, req *connect.Request[auth.SendVerificationEmailRequest]) (*connect.Response[auth.SendVerificationEmailResponse], error) {
+	userID, err := RequireAuth(ctx)
+	if err != nil {
+		return nil, connect.NewError(connect.CodeUnauthenticated, err)
+	}
+
+	entUser, err := s.db.Client.User.Get(ctx, userID)
+	if err != nil {
+		if ent.IsNotFound(err) {
+			return nil, connect.NewError(connect.CodeNotFound, fmt.Errorf("user not found"))
+		}
+		return nil, connect.NewError(connect.CodeInternal, fmt.Errorf("failed to query user: %w", err))
+	}
+
+	if entUser.EmailVerifiedAt != nil {
+		return nil, connect.NewError(connect.CodeFailedPrecondition, fmt.Errorf("email address is already verified"))
+	}
+
+	if err := s.authenticator.SendVerificationEmail(ctx, entUser); err != nil {
+		return nil, connect.NewError(connect.CodeInternal, err)
+	}
+
+	return connect.NewResponse(&auth.SendVerificationEmailResponse{}), nil
+}
+
+func (s *Server) VerifyEmail(ctx context.Context, req *connect.Request[auth.VerifyEmailRequest]) (*connect.Response[auth.VerifyEmailResponse], error) {
+	claims, err := s.authenticator.consumeToken(ctx, req.Msg.Token, TokenTypeEmailVerification)
+	if err != nil {
+		switch {
+		case errors.Is(err, ErrInvalidToken):
+			return nil, connect.NewError(connect.CodeInvalidArgument, err)
+		case errors.Is(err, ErrTokenAlreadyUsed):
+			return nil, connect.NewError(connect.CodeFailedPrecondition, err)
+		}
+		return nil, connect.NewError(connect.CodeInternal, err)
+	}
+	auditFromContext(ctx).setUser(claims.UserID)
+
+	entUser, err := s.db.Client.User.Get(ctx, claims.UserID)
+	if err != nil {
+		if ent.IsNotFound(err) {
+			return nil, connect.NewError(connect.CodeNotFound, fmt.Errorf("user not found"))
+		}
+		return nil, connect.NewError(connect.CodeInternal, fmt.Errorf("failed to query user: %w", err))
+	}
+
+	// The link only vouches for the address it was sent to.
+	if !strings.EqualFold(entUser.Email, claims.Email) {
+		return nil, connect.NewError(connect.CodeFailedPrecondition, fmt.Errorf("verification link does not match the current email address"))
+	}
+
+	if entUser.EmailVerifiedAt == nil {
+		entUser, err = entUser.Update().
+			SetEmailVerifiedAt(time.Now()).
+			Save(ctx)
+		if err != nil {
+			return nil, connect.NewError(connect.CodeInternal, fmt.Errorf("failed to verify email: %w", err))
+		}
+	}
+
+	return connect.NewResponse(&auth.VerifyEmailResponse{
+		User: EntUserToProto(entUser),
+	}), nil
+}
+
+func (s *Server) RequestPasswordReset(ctx context.Context, req *connect.Request[auth.RequestPasswordResetRequest]) (*connect.Response[auth.RequestPasswordResetResponse], error) {
+	email := s.authenticator.NormalizeEmail(req.Msg.Email)
+	auditFromContext(ctx).setEmail(email)
+	if err := ValidateEmail(email); err != nil {
+		return nil, connect.NewError(connect.CodeInvalidArgument, err)
+	}
+
+	if err := s.throttleMail(ctx, email, clientIP(req.Peer())); err != nil {
+		return nil, err
+	}
+
+	// The lookup and the email run in the background so that neither the
+	// response nor its timing reveals whether the address is registered.
+	s.authenticator.mail.enqueue(ctx, "request password reset", func(ctx context.Context) error {
+		return s.authenticator.sendPasswordReset(ctx, email)
+	})
+
+	return connect.NewResponse(&auth.RequestPasswordResetResponse{}), nil
+}
+
+func (s *Server) ResetPassword(ctx context.Context, req *connect.Request[auth.ResetPasswordRequest]) (*connect.Response[auth.ResetPasswordResponse], error) {
+	if err := s.authenticator.resetPassword(ctx, req.Msg.Token, req.Msg.NewPassword); err != nil {
+		var policyErr *PasswordPolicyError
+		switch {
+		case errors.Is(err, ErrInvalidToken):
+			return nil, connect.NewError(connect.CodeInvalidArgument, fmt.Errorf("invalid or expired reset token"))
+		case errors.As(err, &policyErr):
+			return nil, passwordPolicyError("new_password", policyErr)
+		}
+		return nil, connect.NewError(connect.CodeInternal, err)
+	}
+
+	return connect.NewResponse(&auth.ResetPasswordResponse{}), nil
+}
+
+func (s *Server) ChangePassword(ctx context.Context, req *connect.Request[auth.ChangePasswordRequest]) (*connect.Response[auth.ChangePasswordResponse], error) {
+	userID, err := RequireAuth(ctx)
+	if err != nil {
+		return nil, connect.NewError(connect.CodeUnauthenticated, err)
+	}
+
+	entUser, err := s.db.Client.User.Get(ctx, userID)
+	if err != nil {
+		if ent.IsNotFound(err) {
+			return nil, connect.NewError(connect.CodeNotFound, fmt.Errorf("user not found"))
+		}
+		return nil, connect.NewError(connect.CodeInternal, fmt.Errorf("failed to query user: %w", err))
+	}
+
+	if err := s.authenticator.VerifyPassword(ctx, entUser, req.Msg.CurrentPassword, req.Peer()); err != nil {
+		return nil, err
+	}
+
+	if err := s.authenticator.policy.Check(req.Msg.NewPassword, entUser.Email, entUser.Name); err != nil {
+		var policyErr *PasswordPolicyError
+		if errors.As(err, &policyErr) {
+			return nil, passwordPolicyError("new_password", policyErr)
+		}
+		return nil, connect.NewError(connect.CodeInternal, err)
+	}
+
+	hashedPassword, err := s.authenticator.passwords.Hash(req.Msg.NewPassword)
+	if err != nil {
+		return nil, connect.NewError(connect.CodeInternal, fmt.Errorf("failed to hash password: %w", err))
+	}
+
+	tx, err := s.db.Client.Tx(ctx)
+	if err != nil {
+		return nil, connect.NewError(connect.CodeInternal, fmt.Errorf("failed to start transaction: %w", err))
+	}
+
+	err = tx.User.
+		UpdateOneID(userID).
+		SetPasswordHash(hashedPassword).
+		Exec(ctx)
+	if err != nil {
+		_ = tx.Rollback()
+		return nil, connect.NewError(connect.CodeInternal, fmt.Errorf("failed to update password: %w", err))
+	}
+
+	var tokenPair *TokenPair
+	if req.Msg.RevokeOtherSessions {
+		sessionID, _ := GetSessionIDFromContext(ctx)
+		tokenPair, err = s.authenticator.revokeOtherSessions(ctx, tx.Client(), entUser, sessionID, connectSessionInfo(req))
+		if err != nil {
+			_ = tx.Rollback()
+			return nil, connect.NewError(connect.CodeInternal, err)
+		}
+	}
+
+	if err := tx.Commit(); err != nil {
+		return nil, connect.NewError(connect.CodeInternal, fmt.Errorf("failed to commit transaction: %w", err))
+	}
+
+	res := connect.NewResponse(&auth.ChangePasswordResponse{})
+	if tokenPair != nil {
+		res.Msg.Tokens, err = s.authenticator.deliverTokens(res.Header(), tokenPair)
+		if err != nil {
+			return nil, connect.NewError(connect.CodeInternal, err)
+		}
+	}
+	return res, nil
+}
+
+func (s *Server) EnrollTOTP(ctx context.Context, req *connect.Request[auth.EnrollTOTPRequest]) (*connect.Response[auth.EnrollTOTPResponse], error) {
+	userID, err := RequireAuth(ctx)
+	if err != nil {
+		return nil, connect.NewError(connect.CodeUnauthenticated, err)
+	}
+
+	entUser, err := s.db.Client.User.Get(ctx, userID)
+	if err != nil {
+		if ent.IsNotFound(err) {
+			return nil, connect.NewError(connect.CodeNotFound, fmt.Errorf("user not found"))
+		}
+		return nil, connect.NewError(connect.CodeInternal, fmt.Errorf("failed to query user: %w", err))
+	}
+
+	secret, uri, err := s.authenticator.enrollTOTP(ctx, entUser)
+	if err != nil {
+		return nil, mfaError(err)
+	}
+
+	return connect.NewResponse(&auth.EnrollTOTPResponse{
+		Secret: secret,
+		Uri:    uri,
+	}), nil
+}
+
+func (s *Server) ConfirmTOTP(ctx context.Context, req *connect.Request[auth.ConfirmTOTPRequest]) (*connect.Response[auth.ConfirmTOTPResponse], error) {
+	userID, err := RequireAuth(ctx)
+	if err != nil {
+		return nil, connect.NewError(connect.CodeUnauthenticated, err)
+	}
+
+	recoveryCodes, err := s.authenticator.confirmTOTP(ctx, userID, req.Msg.Code)
+	if err != nil {
+		return nil, mfaError(err)
+	}
+
+	return connect.NewResponse(&auth.ConfirmTOTPResponse{
+		RecoveryCodes: recoveryCodes,
+	}), nil
+}
+
+func (s *Server) DisableTOTP(ctx context.Context, req *connect.Request[auth.DisableTOTPRequest]) (*connect.Response[auth.DisableTOTPResponse], error) {
+	userID, err := RequireAuth(ctx)
+	if err != nil {
+		return nil, connect.NewError(connect.CodeUnauthenticated, err)
+	}
+
+	if err := s.authenticator.disableTOTP(ctx, userID, req.Msg.Code); err != nil {
+		return nil, mfaError(err)
+	}
+
+	return connect.NewResponse(&auth.DisableTOTPResponse{}), nil
+}
+
+func (s *Server) VerifyMFA(ctx context.Context, req *connect.Request[auth.VerifyMFARequest]) (*connect.Response[auth.VerifyMFAResponse], error) {
+	claims, err := s.authenticator.ValidateToken(req.Msg.MfaToken, TokenTypeMFAChallenge)
+	if err != nil {
+		return nil, connect.NewError(connect.CodeUnauthenticated, fmt.Errorf("invalid MFA token: %w", err))
+	}
+	auditFromContext(ctx).setUser(claims.UserID)
+
+	// Codes are short enough to guess, so failures count towards the same
+	// throttle as wrong passwords.
+	ip := clientIP(req.Peer())
+	if err := s.authenticator.loginThrottle.Check(ctx, claims.Email, ip); err != nil {
+		var throttled *ThrottledError
+		if errors.As(err, &throttled) {
+			return nil, throttledError(throttled)
+		}
+		return nil, connect.NewError(connect.CodeInternal, err)
+	}
+
+	if err := s.authenticator.verifySecondFactor(ctx, claims.UserID, req.Msg.Code); err != nil {
+		if errors.Is(err, ErrInvalidMFACode) {
+			s.recordLoginFailure(ctx, claims.Email, ip)
+			return nil, connect.NewError(connect.CodeUnauthenticated, err)
+		}
+		return nil, mfaError(err)
+	}
+
+	if err := s.authenticator.revocations.Consume(ctx, claims.ID, claims.ExpiresAt.Time); err != nil {
+		if errors.Is(err, ErrTokenAlreadyUsed) {
+			return nil, connect.NewError(connect.CodeUnauthenticated, fmt.Errorf("invalid MFA token: %w", err))
+		}
+		return nil, connect.NewError(connect.CodeInternal, err)
+	}
+
+	if err := s.authenticator.loginThrottle.Reset(ctx, claims.Email); err != nil {
+		log.Printf("verify mfa: %v", err)
+	}
+
+	entUser, err := s.db.Client.User.Get(ctx, claims.UserID)
+	if err != nil {
+		if ent.IsNotFound(err) {
+			return nil, connect.NewError(connect.CodeNotFound, fmt.Errorf("user not found"))
+		}
+		return nil, connect.NewError(connect.CodeInternal, fmt.Errorf("failed to query user: %w", err))
+	}
+
+	tokenPair, err := s.authenticator.startSession(ctx, s.db.Client, entUser, connectSessionInfo(req))
 	if err != nil {
 		return nil, connect.NewError(connect.CodeInternal, fmt.Errorf("failed to generate tokens: %w", err))
 	}
 
-	return connect.NewResponse(&auth.RegisterResponse{
-		User:   entUserToProto(entUser),
-		Tokens: tokenPairToProto(tokenPair),
+	res := connect.NewResponse(&auth.VerifyMFAResponse{
+		User: EntUserToProto(entUser),
+	})
+	res.Msg.Tokens, err = s.authenticator.deliverTokens(res.Header(), tokenPair)
+	if err != nil {
+		return nil, connect.NewError(connect.CodeInternal, err)
+	}
+	return res, nil
+}
+
+func (s *Server) ListSessions(ctx context.Context, req *connect.Request[auth.ListSessionsRequest]) (*connect.Response[auth.ListSessionsResponse], error) {
+	userID, err := RequireAuth(ctx)
+	if err != nil {
+		return nil, connect.NewError(connect.CodeUnauthenticated, err)
+	}
+
+	sessions, err := s.authenticator.activeSessions(ctx, userID)
+	if err != nil {
+		return nil, connect.NewError(connect.CodeInternal, err)
+	}
+
+	currentID, _ := GetSessionIDFromContext(ctx)
+	pbSessions := make([]*auth.Session, len(sessions))
+	for i, session := range sessions {
+		pbSessions[i] = entSessionToProto(session, currentID)
+	}
+
+	return connect.NewResponse(&auth.ListSessionsResponse{
+		Sessions: pbSessions,
 	}), nil
 }
+
+func (s *Server) RevokeSession(ctx context.Context, req *connect.Request[auth.RevokeSessionRequest]) (*connect.Response[auth.RevokeSessionResponse], error) {
+	userID, err := RequireAuth(ctx)
+	if err != nil {
+		return nil, connect.NewError(connect.CodeUnauthenticated, err)
+	}
+
+	if err := s.authenticator.revokeSession(ctx, userID, req.Msg.Id); err != nil {
+		if errors.Is(err, ErrSessionNotFound) {
+			return nil, connect.NewError(connect.CodeNotFound, err)
+		}
+		return nil, connect.NewError(connect.CodeInternal, err)
+	}
+
+	return connect.NewResponse(&auth.RevokeSessionResponse{}), nil
+}
+
+func (s *Server) RevokeAllOtherSessions(ctx context.Context, req *connect.Request[auth.RevokeAllOtherSessionsRequest]) (*connect.Response[auth.RevokeAllOtherSessionsResponse], error) {
+	userID, err := RequireAuth(ctx)
+	if err != nil {
+		return nil, connect.NewError(connect.CodeUnauthenticated, err)
+	}
+
+	entUser, err := s.db.Client.User.Get(ctx, userID)
+	if err != nil {
+		if ent.IsNotFound(err) {
+			return nil, connect.NewError(connect.CodeNotFound, fmt.Errorf("user not found"))
+		}
+		return nil, connect.NewError(connect.CodeInternal, fmt.Errorf("failed to query user: %w", err))
+	}
+
+	tx, err := s.db.Client.Tx(ctx)
+	if err != nil {
+		return nil, connect.NewError(connect.CodeInternal, fmt.Errorf("failed to start transaction: %w", err))
+	}
+
+	sessionID, _ := GetSessionIDFromContext(ctx)
+	tokenPair, err := s.authenticator.revokeOtherSessions(ctx, tx.Client(), entUser, sessionID, connectSessionInfo(req))
+	if err != nil {
+		_ = tx.Rollback()
+		return nil, connect.NewError(connect.CodeInternal, err)
+	}
+
+	if err := tx.Commit(); err != nil {
+		return nil, connect.NewError(connect.CodeInternal, fmt.Errorf("failed to commit transaction: %w", err))
+	}
+
+	res := connect.NewResponse(&auth.RevokeAllOtherSessionsResponse{})
+	res.Msg.Tokens, err = s.authenticator.deliverTokens(res.Header(), tokenPair)
+	if err != nil {
+		return nil, connect.NewError(connect.CodeInternal, err)
+	}
+	return res, nil
+}
+
+// mfaError maps the errors of the MFA flows to connect codes.
+func mfaError(err error) error {
+	switch {
+	case errors.Is(err, ErrInvalidMFACode):
+		return connect.NewError(connect.CodeInvalidArgument, err)
+	case errors.Is(err, ErrMFAAlreadyEnabled), errors.Is(err, ErrMFANotEnabled):
+		return connect.NewError(connect.CodeFailedPrecondition, err)
+	default:
+		return connect.NewError(connect.CodeInternal, err)
+	}
+}
+
+func (s *Server) ListAuthEvents(ctx context.Context, req *connect.Request[auth.ListAuthEventsRequest]) (*connect.Response[auth.ListAuthEventsResponse], error) {
+	if err := Authorize(ctx, PermissionViewAuthEvents); err != nil {
+		return nil, AuthorizationError(err)
+	}
+
+	filter := AuthEventFilter{
+		UserID:    req.Msg.UserId,
+		ActorID:   req.Msg.ActorId,
+		Email:     req.Msg.Email,
+		IPAddress: req.Msg.IpAddress,
+		Type:      req.Msg.Type,
+		Outcome:   AuthEventOutcomeFromProto(req.Msg.Outcome),
+	}
+	if req.Msg.Since != nil {
+		filter.Since = req.Msg.Since.AsTime()
+	}
+	if req.Msg.Until != nil {
+		filter.Until = req.Msg.Until.AsTime()
+	}
+
+	events, nextPageToken, err := s.authenticator.listAuthEvents(ctx, filter, int(req.Msg.PageSize), req.Msg.PageToken)
+	if err != nil {
+		if errors.Is(err, ErrInvalidPageToken) {
+			return nil, connect.NewError(connect.CodeInvalidArgument, err)
+		}
+		return nil, connect.NewError(connect.CodeInternal, err)
+	}
+
+	pbEvents := make([]*auth.AuthEvent, len(events))
+	for i, event := range events {
+		pbEvents[i] = entAuthEventToProto(event)
+	}
+	return connect.NewResponse(&auth.ListAuthEventsResponse{
+		Events:        pbEvents,
+		NextPageToken: nextPageToken,
+	}), nil
+}
+
+func (s *Server) Impersonate(ctx context.Context, req *connect.Request[auth.ImpersonateRequest]) (*connect.Response[auth.ImpersonateResponse], error) {
+	if err := Authorize(ctx, PermissionImpersonate); err != nil {
+		return nil, AuthorizationError(err)
+	}
+	actorID, _ := GetUserIDFromContext(ctx)
+
+	// The event is about the user being impersonated, by the admin.
+	record := auditFromContext(ctx)
+	record.setUser(req.Msg.UserId)
+	record.setActor(actorID)
+
+	token, expiresAt, err := s.authenticator.Impersonate(ctx, actorID, req.Msg.UserId)
+	if err != nil {
+		switch {
+		case ent.IsNotFound(err):
+			return nil, connect.NewError(connect.CodeNotFound, fmt.Errorf("user not found"))
+		case errors.Is(err, ErrPermissionDenied):
+			return nil, connect.NewError(connect.CodePermissionDenied, err)
+		}
+		return nil, connect.NewError(connect.CodeInternal, err)
+	}
+
+	return connect.NewResponse(&auth.ImpersonateResponse{
+		AccessToken: token,
+		ExpiresAt:   timestamppb.New(expiresAt),
+	}), nil
+}
+
+func (s *Server) RequestMagicLink(ctx context.Context, req *connect.Request[auth.RequestMagicLinkRequest]) (*connect.Response[auth.RequestMagicLinkResponse], error) {
+	email := s.authenticator.NormalizeEmail(req.Msg.Email)
+	auditFromContext(ctx).setEmail(email)
+	if err := ValidateEmail(email); err != nil {
+		return nil, connect.NewError(connect.CodeInvalidArgument, err)
+	}
+
+	// With MagicLinkSignup any address gets an email, so the limit matters
+	// even more than for password resets.
+	if err := s.throttleMail(ctx, email, clientIP(req.Peer())); err != nil {
+		return nil, err
+	}
+
+	// Like password resets, the lookup and the email run in the background
+	// so the response does not reveal whether the address is registered.
+	s.authenticator.mail.enqueue(ctx, "request magic link", func(ctx context.Context) error {
+		return s.authenticator.sendMagicLink(ctx, email)
+	})
+
+	return connect.NewResponse(&auth.RequestMagicLinkResponse{}), nil
+}
+
+func (s *Server) ConsumeMagicLink(ctx context.Context, req *connect.Request[auth.ConsumeMagicLinkRequest]) (*connect.Response[auth.LoginResponse], error) {
+	entUser, err := s.authenticator.consumeMagicLink(ctx, req.Msg.Token)
+	if err != nil {
+		if errors.Is(err, ErrInvalidToken) || errors.Is(err, ErrTokenAlreadyUsed) {
+			return nil, connect.NewError(connect.CodeUnauthenticated, fmt.Errorf("invalid or expired sign-in link: %w", err))
+		}
+		return nil, connect.NewError(connect.CodeInternal, err)
+	}
+
+	return s.signIn(ctx, entUser, connectSessionInfo(req))
+}
@@ -0,0 +1,180 @@
+package auth
+
+import (
+	"context"
+	"errors"
+	"testing"
+	"time"
+
+	"grpc-server/database"
+	"grpc-server/ent/user"
+	"grpc-server/proto-generated/auth"
+
+	"connectrpc.com/connect"
+	"google.golang.org/protobuf/proto"
+)
+
+// newTestServer returns a Server backed by a fake database.
+func newTestServer(t *testing.T) (*Server, *fakeDriver) {
+	t.Helper()
+	client, drv := newFakeClient()
+	a := newTestAuthenticator(t)
+	a.client = client
+	a.policy = DefaultPasswordPolicy()
+	a.revocations = NewRevocationStore(client)
+	a.loginThrottle = NewLoginThrottler(client)
+	a.mailThrottle = newMailThrottler(client)
+	a.mail = newMailQueue(mailQueueSize)
+	return NewAuthServer(&database.DB{Client: client}, a), drv
+}
+
+// userResult answers a User query with a user whose password is password.
+func userResult(t *testing.T, a *Authenticator, password string) fakeResult {
+	t.Helper()
+	hash, err := a.passwords.Hash(password)
+	if err != nil {
+		t.Fatalf("Hash() error = %v", err)
+	}
+	values := map[string]any{
+		user.FieldID:           "user-1",
+		user.FieldEmail:        "user@example.com",
+		user.FieldName:         "User",
+		user.FieldPasswordHash: hash,
+		user.FieldRole:         string(RoleUser),
+		user.FieldCreatedAt:    time.Now(),
+		user.FieldUpdatedAt:    time.Now(),
+	}
+	row := make([]any, len(user.Columns))
+	for i, column := range user.Columns {
+		row[i] = values[column]
+		if row[i] == nil && column != user.FieldEmailVerifiedAt && column != user.FieldSessionsRevokedAt && column != user.FieldPermissions {
+			row[i] = ""
+		}
+	}
+	return fakeResult{columns: user.Columns, rows: [][]any{row}}
+}
+
+func TestChangePassword(t *testing.T) {
+	const currentPassword = "correct horse battery"
+	lockedUntil := time.Now().Add(time.Minute)
+
+	tests := []struct {
+		name     string
+		password string
+		locked   bool
+		code     connect.Code
+		// statements the request must make
+		want []string
+	}{
+		{
+			name:     "wrong password",
+			password: "wrong horse battery",
+			code:     connect.CodePermissionDenied,
+			want:     []string{`INSERT INTO "login_throttles"`},
+		},
+		{
+			name:     "locked",
+			password: currentPassword,
+			locked:   true,
+			code:     connect.CodeResourceExhausted,
+		},
+		{
+			name:     "correct password",
+			password: currentPassword,
+			want:     []string{`DELETE FROM "login_throttles"`, `UPDATE "users"`},
+		},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			s, drv := newTestServer(t)
+			drv.results = []fakeResult{userResult(t, s.authenticator, currentPassword)}
+			if tt.locked {
+				drv.results = append(drv.results, throttleResult(throttleRow("email:user@example.com", 10, time.Now(), lockedUntil)))
+			} else {
+				drv.results = append(drv.results, throttleResult())
+			}
+			if tt.code == 0 {
+				// UpdateOne reads the user back.
+				drv.results = append(drv.results, userResult(t, s.authenticator, currentPassword))
+			}
+
+			_, err := s.ChangePassword(contextWithUser("user-1", RoleUser), connect.NewRequest(&auth.ChangePasswordRequest{
+				CurrentPassword: tt.password,
+				NewPassword:     "a brand new passphrase",
+			}))
+
+			if tt.code == 0 {
+				if err != nil {
+					t.Fatalf("ChangePassword() error = %v", err)
+				}
+			} else if connect.CodeOf(err) != tt.code {
+				t.Fatalf("Expected code %v, got %v", tt.code, err)
+			}
+			for _, prefix := range tt.want {
+				if len(drv.find(prefix)) == 0 {
+					t.Errorf("Expected a statement starting with %s, got %v", prefix, drv.statements)
+				}
+			}
+			if tt.code != 0 && len(drv.find(`UPDATE "users"`)) > 0 {
+				t.Errorf("Expected the password to stay unchanged")
+			}
+		})
+	}
+}
+
+func TestChangePasswordUnauthenticated(t *testing.T) {
+	s, _ := newTestServer(t)
+	_, err := s.ChangePassword(context.Background(), connect.NewRequest(&auth.ChangePasswordRequest{}))
+	if connect.CodeOf(err) != connect.CodeUnauthenticated {
+		t.Errorf("Expected code %v, got %v", connect.CodeUnauthenticated, err)
+	}
+}
+
+func TestRegisterAnswersTheSameForTakenEmails(t *testing.T) {
+	register := func(t *testing.T, taken bool) (*connect.Response[auth.RegisterResponse], int, error) {
+		s, drv := newTestServer(t)
+		if taken {
+			drv.failures = map[string]error{
+				`INSERT INTO "users"`: errors.New(`ERROR: duplicate key value violates unique constraint "users_email_lower_key" (SQLSTATE 23505)`),
+			}
+		}
+		res, err := s.Register(context.Background(), connect.NewRequest(&auth.RegisterRequest{
+			Email:    "user@example.com",
+			Password: "correct horse battery",
+			Name:     "User",
+		}))
+		return res, len(s.authenticator.mail.jobs), err
+	}
+
+	newRes, newEmails, newErr := register(t, false)
+	takenRes, takenEmails, takenErr := register(t, true)
+
+	if newErr != nil || takenErr != nil {
+		t.Fatalf("Expected both registrations to succeed, got %v and %v", newErr, takenErr)
+	}
+	if !proto.Equal(newRes.Msg, takenRes.Msg) {
+		t.Errorf("Expected the same response, got %v and %v", newRes.Msg, takenRes.Msg)
+	}
+	// The owner of a taken address is told about the attempt instead.
+	if newEmails != 1 || takenEmails != 1 {
+		t.Errorf("Expected one email for each, got %d and %d", newEmails, takenEmails)
+	}
+}
+
+func TestRegisterThrottlesMail(t *testing.T) {
+	s, drv := newTestServer(t)
+	drv.results = []fakeResult{throttleResult(throttleRow("mail-email:user@example.com", 10, time.Now(), time.Now().Add(time.Minute)))}
+
+	_, err := s.Register(context.Background(), connect.NewRequest(&auth.RegisterRequest{
+		Email:    "user@example.com",
+		Password: "correct horse battery",
+		Name:     "User",
+	}))
+	if connect.CodeOf(err) != connect.CodeResourceExhausted {
+		t.Fatalf("Expected code %v, got %v", connect.CodeResourceExhausted, err)
+	}
+	if len(drv.find(`INSERT INTO "users"`)) > 0 || len(s.authenticator.mail.jobs) > 0 {
+		t.Errorf("Expected no account and no email once the address is locked out")
+	}
+}
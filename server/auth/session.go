@@ -0,0 +1,115 @@
+package auth
+
+import (
+	"context"
+	"fmt"
+	"time"
+	"unicode/utf8"
+
+	"grpc-server/ent"
+	"grpc-server/ent/session"
+	"grpc-server/ent/user"
+)
+
+// maxUserAgentLength bounds what a client can make us store.
+const maxUserAgentLength = 512
+
+// sessionInfo describes the client a session is used from.
+type sessionInfo struct {
+	UserAgent string
+	IPAddress string
+}
+
+// startSession records a new session for u and issues its first token pair.
+func (a *Authenticator) startSession(ctx context.Context, client *ent.Client, u *ent.User, info sessionInfo) (*TokenPair, error) {
+	s, err := client.Session.
+		Create().
+		SetUserAgent(truncate(info.UserAgent, maxUserAgentLength)).
+		SetIPAddress(info.IPAddress).
+		SetUserID(u.ID).
+		Save(ctx)
+	if err != nil {
+		return nil, fmt.Errorf("failed to create session: %w", err)
+	}
+
+	return a.issueTokenPair(ctx, client, u, s.ID)
+}
+
+// touchSession records that sessionID refreshed its tokens. Refresh tokens
+// issued before sessions were recorded have no session to update.
+func touchSession(ctx context.Context, client *ent.Client, sessionID string, info sessionInfo, now time.Time) error {
+	update := client.Session.
+		Update().
+		Where(session.IDEQ(sessionID)).
+		SetLastSeenAt(now)
+	if info.IPAddress != "" {
+		update.SetIPAddress(info.IPAddress)
+	}
+	if err := update.Exec(ctx); err != nil {
+		return fmt.Errorf("failed to update session: %w", err)
+	}
+	return nil
+}
+
+// activeSessions returns the sessions of userID that can still refresh
+// their tokens, most recently used first.
+func (a *Authenticator) activeSessions(ctx context.Context, userID string) ([]*ent.Session, error) {
+	sessions, err := a.client.Session.
+		Query().
+		Where(
+			session.HasUserWith(user.IDEQ(userID)),
+			session.RevokedAtIsNil(),
+			session.LastSeenAtGT(time.Now().Add(-refreshTokenExpiry)),
+		).
+		Order(ent.Desc(session.FieldLastSeenAt)).
+		All(ctx)
+	if err != nil {
+		return nil, fmt.Errorf("failed to list sessions: %w", err)
+	}
+	return sessions, nil
+}
+
+// revokeSession signs userID out of sessionID.
+func (a *Authenticator) revokeSession(ctx context.Context, userID, sessionID string) error {
+	tx, err := a.client.Tx(ctx)
+	if err != nil {
+		return fmt.Errorf("failed to start transaction: %w", err)
+	}
+
+	exists, err := tx.Session.
+		Query().
+		Where(
+			session.IDEQ(sessionID),
+			session.HasUserWith(user.IDEQ(userID)),
+			session.RevokedAtIsNil(),
+		).
+		ForUpdate().
+		Exist(ctx)
+	if err == nil && !exists {
+		err = ErrSessionNotFound
+	}
+	if err == nil {
+		err = a.revokeTokenFamily(ctx, tx.Client(), sessionID, time.Now())
+	}
+	if err != nil {
+		_ = tx.Rollback()
+		return err
+	}
+
+	if err := tx.Commit(); err != nil {
+		return fmt.Errorf("failed to commit transaction: %w", err)
+	}
+	return nil
+}
+
+// truncate cuts s to at most n bytes without splitting a UTF-8 character,
+// which Postgres would reject.
+func truncate(s string, n int) string {
+	if len(s) <= n {
+		return s
+	}
+	for n > 0 && !utf8.RuneStart(s[n]) {
+		n--
+	}
+	return s[:n]
+}
@@ -0,0 +1,36 @@
+package auth
+
+import (
+	"net/http/httptest"
+	"strings"
+	"testing"
+	"unicode/utf8"
+)
+
+func TestHTTPSessionInfo(t *testing.T) {
+	r := httptest.NewRequest("GET", "/auth/oidc/mock/callback", nil)
+	r.RemoteAddr = "192.0.2.1:54321"
+	r.Header.Set("User-Agent", "test-agent")
+
+	info := httpSessionInfo(r)
+	if info.IPAddress != "192.0.2.1" {
+		t.Errorf("Expected IP 192.0.2.1, got %q", info.IPAddress)
+	}
+	if info.UserAgent != "test-agent" {
+		t.Errorf("Expected user agent test-agent, got %q", info.UserAgent)
+	}
+}
+
+func TestTruncate(t *testing.T) {
+	long := strings.Repeat("a", maxUserAgentLength+10)
+	if got := truncate(long, maxUserAgentLength); len(got) != maxUserAgentLength {
+		t.Errorf("Expected length %d, got %d", maxUserAgentLength, len(got))
+	}
+	if got := truncate("short", maxUserAgentLength); got != "short" {
+		t.Errorf("Expected short strings to be kept, got %q", got)
+	}
+	multibyte := strings.Repeat("a", maxUserAgentLength-1) + "한글"
+	if got := truncate(multibyte, maxUserAgentLength); !utf8.ValidString(got) || len(got) != maxUserAgentLength-1 {
+		t.Errorf("Expected the cut to fall before the split character, got %q", got[maxUserAgentLength-8:])
+	}
+}
@@ -0,0 +1,347 @@
+package auth
+
+import (
+	"context"
+	"fmt"
+	"log"
+	"math"
+	"strconv"
+	"strings"
+	"time"
+
+	"grpc-server/ent"
+	"grpc-server/ent/loginthrottle"
+	protoAuth "grpc-server/proto-generated/auth"
+
+	"connectrpc.com/connect"
+	"google.golang.org/protobuf/types/known/durationpb"
+)
+
+// throttlePolicy describes how attempts against one key slow down and
+// eventually lock further attempts.
+type throttlePolicy struct {
+	prefix string
+	// freeAttempts failures are allowed before any delay is imposed.
+	freeAttempts int
+	// baseDelay is imposed after the first failure past freeAttempts and
+	// doubles with every further failure, up to maxDelay.
+	baseDelay time.Duration
+	maxDelay  time.Duration
+	// lockoutAfter failures lock the key for lockoutDuration.
+	lockoutAfter    int
+	lockoutDuration time.Duration
+	// resetAfter without failures starts the count over.
+	resetAfter time.Duration
+}
+
+var (
+	emailThrottlePolicy = throttlePolicy{
+		prefix:          "email:",
+		freeAttempts:    3,
+		baseDelay:       time.Second,
+		maxDelay:        5 * time.Minute,
+		lockoutAfter:    10,
+		lockoutDuration: 15 * time.Minute,
+		resetAfter:      time.Hour,
+	}
+	ipThrottlePolicy = throttlePolicy{
+		prefix:          "ip:",
+		freeAttempts:    10,
+		baseDelay:       time.Second,
+		maxDelay:        5 * time.Minute,
+		lockoutAfter:    50,
+		lockoutDuration: time.Hour,
+		resetAfter:      time.Hour,
+	}
+
+	// Every password reset or magic link request sends an email, so the
+	// limits are counted per request rather than per failure.
+	mailEmailThrottlePolicy = throttlePolicy{
+		prefix:          "mail-email:",
+		freeAttempts:    3,
+		baseDelay:       time.Minute,
+		maxDelay:        30 * time.Minute,
+		lockoutAfter:    10,
+		lockoutDuration: time.Hour,
+		resetAfter:      time.Hour,
+	}
+	mailIPThrottlePolicy = throttlePolicy{
+		prefix:          "mail-ip:",
+		freeAttempts:    20,
+		baseDelay:       10 * time.Second,
+		maxDelay:        10 * time.Minute,
+		lockoutAfter:    100,
+		lockoutDuration: time.Hour,
+		resetAfter:      time.Hour,
+	}
+)
+
+// throttleScope counts one kind of attempt per email and per client IP.
+type throttleScope struct {
+	// attempts names what is counted, for ThrottledError.
+	attempts string
+	email    throttlePolicy
+	ip       throttlePolicy
+}
+
+var (
+	loginThrottleScope = throttleScope{
+		attempts: "failed login attempts",
+		email:    emailThrottlePolicy,
+		ip:       ipThrottlePolicy,
+	}
+	mailThrottleScope = throttleScope{
+		attempts: "email requests",
+		email:    mailEmailThrottlePolicy,
+		ip:       mailIPThrottlePolicy,
+	}
+)
+
+const throttleSweepInterval = 10 * time.Minute
+
+// delay returns how long the key is locked after its nth consecutive failure.
+func (p throttlePolicy) delay(failures int) time.Duration {
+	if failures >= p.lockoutAfter {
+		return p.lockoutDuration
+	}
+	if failures <= p.freeAttempts {
+		return 0
+	}
+
+	delay := p.baseDelay
+	for i := p.freeAttempts + 1; i < failures && delay < p.maxDelay; i++ {
+		delay *= 2
+	}
+	return min(delay, p.maxDelay)
+}
+
+// ThrottledError reports that further attempts must wait.
+type ThrottledError struct {
+	RetryAfter time.Duration
+	attempts   string
+}
+
+func (e *ThrottledError) Error() string {
+	return fmt.Sprintf("too many %s, retry in %s", e.attempts, e.RetryAfter.Round(time.Second))
+}
+
+// LoginThrottler tracks failed logins per email and per client IP in the
+// database, so limits hold across every server instance.
+type LoginThrottler struct {
+	client *ent.Client
+	scope  throttleScope
+}
+
+func NewLoginThrottler(client *ent.Client) *LoginThrottler {
+	return &LoginThrottler{client: client, scope: loginThrottleScope}
+}
+
+// newMailThrottler returns a LoginThrottler that counts requests for emails
+// instead of failed logins, in the same table.
+func newMailThrottler(client *ent.Client) *LoginThrottler {
+	return &LoginThrottler{client: client, scope: mailThrottleScope}
+}
+
+type throttleKey struct {
+	policy throttlePolicy
+	key    string
+}
+
+// keys returns the keys an attempt counts against. An unknown client IP is
+// not throttled on its own.
+func (s throttleScope) keys(email, ip string) []throttleKey {
+	keys := []throttleKey{{
+		policy: s.email,
+		key:    s.email.prefix + strings.ToLower(strings.TrimSpace(email)),
+	}}
+	if ip != "" {
+		keys = append(keys, throttleKey{
+			policy: s.ip,
+			key:    s.ip.prefix + ip,
+		})
+	}
+	return keys
+}
+
+func (s throttleScope) keyNames(email, ip string) []string {
+	var names []string
+	for _, k := range s.keys(email, ip) {
+		names = append(names, k.key)
+	}
+	return names
+}
+
+// Check returns a *ThrottledError if the email or the IP is currently locked.
+func (t *LoginThrottler) Check(ctx context.Context, email, ip string) error {
+	now := time.Now()
+
+	rows, err := t.client.LoginThrottle.
+		Query().
+		Where(
+			loginthrottle.KeyIn(t.scope.keyNames(email, ip)...),
+			loginthrottle.LockedUntilGT(now),
+		).
+		All(ctx)
+	if err != nil {
+		return fmt.Errorf("failed to check login throttle: %w", err)
+	}
+
+	var retryAfter time.Duration
+	for _, row := range rows {
+		retryAfter = max(retryAfter, row.LockedUntil.Sub(now))
+	}
+	if retryAfter > 0 {
+		return &ThrottledError{RetryAfter: retryAfter, attempts: t.scope.attempts}
+	}
+	return nil
+}
+
+// RecordFailure counts a failed attempt against the email and the IP.
+func (t *LoginThrottler) RecordFailure(ctx context.Context, email, ip string) error {
+	for _, k := range t.scope.keys(email, ip) {
+		if err := t.recordFailure(ctx, k.policy, k.key); err != nil {
+			return err
+		}
+	}
+	return nil
+}
+
+func (t *LoginThrottler) recordFailure(ctx context.Context, policy throttlePolicy, key string) error {
+	var err error
+	// Two instances can race to create the first row for a key; the loser
+	// retries against the row the winner created.
+	for attempt := 0; attempt < 2; attempt++ {
+		err = t.recordFailureTx(ctx, policy, key)
+		if err == nil || !ent.IsConstraintError(err) {
+			break
+		}
+	}
+	if err != nil {
+		return fmt.Errorf("failed to record %s: %w", t.scope.attempts, err)
+	}
+	return nil
+}
+
+func (t *LoginThrottler) recordFailureTx(ctx context.Context, policy throttlePolicy, key string) error {
+	now := time.Now()
+
+	tx, err := t.client.Tx(ctx)
+	if err != nil {
+		return err
+	}
+
+	row, err := tx.LoginThrottle.
+		Query().
+		Where(loginthrottle.KeyEQ(key)).
+		ForUpdate().
+		Only(ctx)
+
+	switch {
+	case ent.IsNotFound(err):
+		create := tx.LoginThrottle.
+			Create().
+			SetKey(key).
+			SetFailureCount(1).
+			SetLastFailureAt(now)
+		if delay := policy.delay(1); delay > 0 {
+			create.SetLockedUntil(now.Add(delay))
+		}
+		err = create.Exec(ctx)
+	case err == nil:
+		failures := row.FailureCount + 1
+		if now.Sub(row.LastFailureAt) > policy.resetAfter {
+			failures = 1
+		}
+		update := tx.LoginThrottle.
+			UpdateOne(row).
+			SetFailureCount(failures).
+			SetLastFailureAt(now)
+		if delay := policy.delay(failures); delay > 0 {
+			update.SetLockedUntil(now.Add(delay))
+		} else {
+			update.ClearLockedUntil()
+		}
+		err = update.Exec(ctx)
+	}
+
+	if err != nil {
+		_ = tx.Rollback()
+		return err
+	}
+	return tx.Commit()
+}
+
+// Reset clears the failure count of email after a successful login. The IP
+// count is left to decay on its own: otherwise an attacker spraying
+// passwords from one IP could clear it by signing into their own account.
+func (t *LoginThrottler) Reset(ctx context.Context, email string) error {
+	_, err := t.client.LoginThrottle.
+		Delete().
+		Where(loginthrottle.KeyIn(t.scope.keyNames(email, "")...)).
+		Exec(ctx)
+	if err != nil {
+		return fmt.Errorf("failed to reset %s: %w", t.scope.attempts, err)
+	}
+	return nil
+}
+
+// Prune deletes counters that are neither locked nor recent enough to count.
+func (t *LoginThrottler) Prune(ctx context.Context) error {
+	now := time.Now()
+	resetAfter := max(t.scope.email.resetAfter, t.scope.ip.resetAfter)
+
+	_, err := t.client.LoginThrottle.
+		Delete().
+		Where(
+			loginthrottle.Or(
+				loginthrottle.KeyHasPrefix(t.scope.email.prefix),
+				loginthrottle.KeyHasPrefix(t.scope.ip.prefix),
+			),
+			loginthrottle.LastFailureAtLT(now.Add(-resetAfter)),
+			loginthrottle.Or(
+				loginthrottle.LockedUntilIsNil(),
+				loginthrottle.LockedUntilLT(now),
+			),
+		).
+		Exec(ctx)
+	if err != nil {
+		return fmt.Errorf("failed to prune %s: %w", t.scope.attempts, err)
+	}
+	return nil
+}
+
+// RunSweeper prunes stale counters every interval until ctx is done.
+func (t *LoginThrottler) RunSweeper(ctx context.Context, interval time.Duration) {
+	ticker := time.NewTicker(interval)
+	defer ticker.Stop()
+
+	for {
+		if err := t.Prune(ctx); err != nil {
+			log.Printf("throttle sweeper: %v", err)
+		}
+
+		select {
+		case <-ctx.Done():
+			return
+		case <-ticker.C:
+		}
+	}
+}
+
+// throttledError converts err into a ResourceExhausted error that carries
+// the retry delay both as an error detail and as a Retry-After header.
+func throttledError(err *ThrottledError) *connect.Error {
+	connectErr := connect.NewError(connect.CodeResourceExhausted, err)
+
+	detail, detailErr := connect.NewErrorDetail(&protoAuth.RetryInfo{
+		RetryDelay: durationpb.New(err.RetryAfter),
+	})
+	if detailErr == nil {
+		connectErr.AddDetail(detail)
+	}
+
+	seconds := int(math.Ceil(err.RetryAfter.Seconds()))
+	connectErr.Meta().Set("Retry-After", strconv.Itoa(seconds))
+
+	return connectErr
+}
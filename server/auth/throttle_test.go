@@ -0,0 +1,196 @@
+package auth
+
+import (
+	"context"
+	"errors"
+	"slices"
+	"testing"
+	"time"
+
+	"grpc-server/ent/loginthrottle"
+)
+
+func TestThrottlePolicyDelay(t *testing.T) {
+	policy := throttlePolicy{
+		freeAttempts:    3,
+		baseDelay:       time.Second,
+		maxDelay:        10 * time.Second,
+		lockoutAfter:    10,
+		lockoutDuration: 15 * time.Minute,
+	}
+
+	tests := []struct {
+		failures int
+		want     time.Duration
+	}{
+		{failures: 1, want: 0},
+		{failures: 3, want: 0},
+		{failures: 4, want: time.Second},
+		{failures: 5, want: 2 * time.Second},
+		{failures: 6, want: 4 * time.Second},
+		{failures: 8, want: 10 * time.Second},
+		{failures: 9, want: 10 * time.Second},
+		{failures: 10, want: 15 * time.Minute},
+		{failures: 1000, want: 15 * time.Minute},
+	}
+
+	for _, tt := range tests {
+		if got := policy.delay(tt.failures); got != tt.want {
+			t.Errorf("delay(%d) = %v, want %v", tt.failures, got, tt.want)
+		}
+	}
+}
+
+// throttleResult answers a LoginThrottle query with rows.
+func throttleResult(rows ...[]any) fakeResult {
+	return fakeResult{columns: loginthrottle.Columns, rows: rows}
+}
+
+func throttleRow(key string, failures int, lastFailureAt time.Time, lockedUntil any) []any {
+	return []any{"id-" + key, key, int64(failures), lastFailureAt, lockedUntil}
+}
+
+func TestLoginThrottlerRecordFailure(t *testing.T) {
+	now := time.Now()
+
+	t.Run("first failure", func(t *testing.T) {
+		client, drv := newFakeClient()
+		throttle := NewLoginThrottler(client)
+
+		if err := throttle.RecordFailure(context.Background(), " User@Example.com", "203.0.113.7"); err != nil {
+			t.Fatalf("RecordFailure() error = %v", err)
+		}
+
+		inserts := drv.find(`INSERT INTO "login_throttles"`)
+		if len(inserts) != 2 {
+			t.Fatalf("Expected a counter for the email and one for the IP, got %v", drv.statements)
+		}
+		for i, key := range []string{"email:user@example.com", "ip:203.0.113.7"} {
+			if !slices.Contains(inserts[i].args, any(key)) || !slices.Contains(inserts[i].args, any(1)) {
+				t.Errorf("Expected %s to start counting at 1, got %v", key, inserts[i].args)
+			}
+		}
+	})
+
+	t.Run("lockout", func(t *testing.T) {
+		client, drv := newFakeClient()
+		throttle := NewLoginThrottler(client)
+		key := "email:user@example.com"
+		drv.results = []fakeResult{
+			throttleResult(throttleRow(key, emailThrottlePolicy.lockoutAfter-1, now.Add(-time.Minute), nil)),
+			// UpdateOne reads the row back.
+			throttleResult(throttleRow(key, emailThrottlePolicy.lockoutAfter, now, now.Add(emailThrottlePolicy.lockoutDuration))),
+		}
+
+		if err := throttle.RecordFailure(context.Background(), "user@example.com", ""); err != nil {
+			t.Fatalf("RecordFailure() error = %v", err)
+		}
+
+		updates := drv.find(`UPDATE "login_throttles"`)
+		if len(updates) != 1 {
+			t.Fatalf("Expected the counter to be updated, got %v", drv.statements)
+		}
+		args := updates[0].args
+		if !slices.Contains(args, any(emailThrottlePolicy.lockoutAfter)) {
+			t.Errorf("Expected the count to reach %d, got %v", emailThrottlePolicy.lockoutAfter, args)
+		}
+		locked := slices.ContainsFunc(args, func(arg any) bool {
+			until, ok := arg.(time.Time)
+			return ok && until.After(now.Add(emailThrottlePolicy.lockoutDuration-time.Minute))
+		})
+		if !locked {
+			t.Errorf("Expected the email to be locked for %v, got %v", emailThrottlePolicy.lockoutDuration, args)
+		}
+	})
+
+	t.Run("stale count starts over", func(t *testing.T) {
+		client, drv := newFakeClient()
+		throttle := NewLoginThrottler(client)
+		key := "email:user@example.com"
+		drv.results = []fakeResult{
+			throttleResult(throttleRow(key, emailThrottlePolicy.lockoutAfter-1, now.Add(-2*emailThrottlePolicy.resetAfter), nil)),
+			throttleResult(throttleRow(key, 1, now, nil)),
+		}
+
+		if err := throttle.RecordFailure(context.Background(), "user@example.com", ""); err != nil {
+			t.Fatalf("RecordFailure() error = %v", err)
+		}
+
+		updates := drv.find(`UPDATE "login_throttles"`)
+		if len(updates) != 1 || !slices.Contains(updates[0].args, any(1)) {
+			t.Errorf("Expected the count to start over at 1, got %v", drv.statements)
+		}
+	})
+}
+
+func TestLoginThrottlerCheck(t *testing.T) {
+	now := time.Now()
+
+	t.Run("unlocked", func(t *testing.T) {
+		client, drv := newFakeClient()
+		throttle := NewLoginThrottler(client)
+
+		if err := throttle.Check(context.Background(), "user@example.com", "203.0.113.7"); err != nil {
+			t.Errorf("Check() error = %v", err)
+		}
+		selects := drv.find(`SELECT`)
+		if len(selects) != 1 || !slices.Contains(selects[0].args, any("email:user@example.com")) || !slices.Contains(selects[0].args, any("ip:203.0.113.7")) {
+			t.Errorf("Expected the email and the IP to be checked, got %v", drv.statements)
+		}
+	})
+
+	t.Run("locked", func(t *testing.T) {
+		client, drv := newFakeClient()
+		throttle := NewLoginThrottler(client)
+		drv.results = []fakeResult{throttleResult(
+			throttleRow("email:user@example.com", 5, now, now.Add(time.Minute)),
+			throttleRow("ip:203.0.113.7", 60, now, now.Add(time.Hour)),
+		)}
+
+		err := throttle.Check(context.Background(), "user@example.com", "203.0.113.7")
+		var throttled *ThrottledError
+		if !errors.As(err, &throttled) {
+			t.Fatalf("Expected a ThrottledError, got %v", err)
+		}
+		if throttled.RetryAfter < 59*time.Minute {
+			t.Errorf("Expected to wait out the longest lock, got %v", throttled.RetryAfter)
+		}
+	})
+}
+
+func TestLoginThrottlerResetKeepsIPCount(t *testing.T) {
+	client, drv := newFakeClient()
+	throttle := NewLoginThrottler(client)
+
+	if err := throttle.Reset(context.Background(), "User@Example.com"); err != nil {
+		t.Fatalf("Reset() error = %v", err)
+	}
+
+	deletes := drv.find(`DELETE FROM "login_throttles"`)
+	if len(deletes) != 1 {
+		t.Fatalf("Expected one DELETE, got %v", drv.statements)
+	}
+	if got := deletes[0].args; len(got) != 1 || got[0] != "email:user@example.com" {
+		t.Errorf("Expected only the email count to be cleared, got %v", got)
+	}
+}
+
+func TestMailThrottlerCountsSeparately(t *testing.T) {
+	client, drv := newFakeClient()
+	throttle := newMailThrottler(client)
+
+	if err := throttle.RecordFailure(context.Background(), "user@example.com", "203.0.113.7"); err != nil {
+		t.Fatalf("RecordFailure() error = %v", err)
+	}
+
+	inserts := drv.find(`INSERT INTO "login_throttles"`)
+	if len(inserts) != 2 {
+		t.Fatalf("Expected a counter for the email and one for the IP, got %v", drv.statements)
+	}
+	// Asking for emails must not lock the address out of logging in.
+	for i, key := range []string{"mail-email:user@example.com", "mail-ip:203.0.113.7"} {
+		if !slices.Contains(inserts[i].args, any(key)) {
+			t.Errorf("Expected the key %s, got %v", key, inserts[i].args)
+		}
+	}
+}
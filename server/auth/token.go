@@ -5,60 +5,118 @@ import (
 	"time"
 
 	"github.com/golang-jwt/jwt/v5"
+	"github.com/google/uuid"
 )
 
 const (
-	jwtSecretKey       = "temp"
+	tokenIssuer        = "grpc-server"
+	tokenAudience      = "grpc-server"
 	accessTokenExpiry  = 15 * time.Minute
 	refreshTokenExpiry = 7 * 24 * time.Hour
+	// Impersonation tokens can't be refreshed; support starts over when
+	// they expire.
+	impersonationTokenExpiry = 10 * time.Minute
+)
+
+// TokenType distinguishes what a token may be used for, so a refresh token
+// cannot be presented as a bearer token and vice versa.
+type TokenType string
+
+const (
+	TokenTypeAccess            TokenType = "access"
+	TokenTypeRefresh           TokenType = "refresh"
+	TokenTypeEmailVerification TokenType = "email_verification"
+	TokenTypeMFAChallenge      TokenType = "mfa_challenge"
+	TokenTypeMagicLink         TokenType = "magic_link"
 )
 
 type TokenPair struct {
-	AccessToken       string
-	RefreshToken      string
-	AccessTokenExpiry time.Time
+	AccessToken        string
+	RefreshToken       string
+	AccessTokenExpiry  time.Time
+	RefreshTokenExpiry time.Time
 }
 
 type Claims struct {
-	UserID string `json:"user_id"`
-	Email  string `json:"email"`
+	UserID    string    `json:"user_id"`
+	Email     string    `json:"email"`
+	TokenType TokenType `json:"token_type"`
+	// Role is the user's role when the token was issued.
+	Role Role `json:"role,omitempty"`
+	// Permissions are those the user held on top of the role when the
+	// token was issued.
+	Permissions []Permission `json:"perms,omitempty"`
+	// SessionID is the refresh token family the token belongs to.
+	SessionID string `json:"sid,omitempty"`
+	// Actor is set on impersonation tokens and names the admin acting as
+	// the user, as in RFC 8693.
+	Actor *Actor `json:"act,omitempty"`
 	jwt.RegisteredClaims
 }
 
-func GenerateTokenPair(userID, email string) (*TokenPair, error) {
+// Actor is the party acting on behalf of a token's subject.
+type Actor struct {
+	Subject string `json:"sub"`
+}
+
+func (a *Authenticator) GenerateTokenPair(userID, email string, role Role, sessionID string, permissions ...Permission) (*TokenPair, error) {
 	now := time.Now()
+	claims := Claims{
+		UserID:      userID,
+		Email:       email,
+		Role:        role,
+		Permissions: permissions,
+		SessionID:   sessionID,
+	}
 
-	accessToken, err := generateToken(userID, email, accessTokenExpiry)
+	claims.TokenType = TokenTypeAccess
+	accessToken, err := a.generateToken(claims, accessTokenExpiry)
 	if err != nil {
 		return nil, fmt.Errorf("failed to generate access token: %w", err)
 	}
 
-	refreshToken, err := generateToken(userID, email, refreshTokenExpiry)
+	claims.TokenType = TokenTypeRefresh
+	refreshToken, err := a.generateToken(claims, refreshTokenExpiry)
 	if err != nil {
 		return nil, fmt.Errorf("failed to generate refresh token: %w", err)
 	}
 
 	return &TokenPair{
-		AccessToken:       accessToken,
-		RefreshToken:      refreshToken,
-		AccessTokenExpiry: now.Add(accessTokenExpiry),
+		AccessToken:        accessToken,
+		RefreshToken:       refreshToken,
+		AccessTokenExpiry:  now.Add(accessTokenExpiry),
+		RefreshTokenExpiry: now.Add(refreshTokenExpiry),
 	}, nil
 }
 
-func generateToken(userID, email string, expiry time.Duration) (string, error) {
+// generateToken signs claims after filling in the registered claims.
+func (a *Authenticator) generateToken(claims Claims, expiry time.Duration) (string, error) {
 	now := time.Now()
-	claims := Claims{
-		UserID: userID,
-		Email:  email,
-		RegisteredClaims: jwt.RegisteredClaims{
-			ExpiresAt: jwt.NewNumericDate(now.Add(expiry)),
-			IssuedAt:  jwt.NewNumericDate(now),
-			NotBefore: jwt.NewNumericDate(now),
-		},
+	claims.RegisteredClaims = jwt.RegisteredClaims{
+		// The jti identifies the token for revocation and keeps tokens
+		// issued within the same second distinct.
+		ID:        uuid.New().String(),
+		Issuer:    tokenIssuer,
+		Subject:   claims.UserID,
+		Audience:  jwt.ClaimStrings{tokenAudience},
+		ExpiresAt: jwt.NewNumericDate(now.Add(expiry)),
+		IssuedAt:  jwt.NewNumericDate(now),
+		NotBefore: jwt.NewNumericDate(now),
 	}
 
-	token := jwt.NewWithClaims(jwt.SigningMethodHS256, claims)
-	tokenString, err := token.SignedString([]byte(jwtSecretKey))
+	return a.signToken(claims)
+}
+
+// signToken signs claims with the active key.
+func (a *Authenticator) signToken(claims jwt.Claims) (string, error) {
+	key, err := a.keys.SigningKey()
+	if err != nil {
+		return "", err
+	}
+
+	token := jwt.NewWithClaims(key.Method, claims)
+	token.Header["kid"] = key.ID
+	tokenString, err := token.SignedString(key.Private)
 	if err != nil {
 		return "", fmt.Errorf("failed to sign token: %w", err)
 	}
@@ -66,22 +124,59 @@ func generateToken(userID, email string, expiry time.Duration) (string, error) {
 	return tokenString, nil
 }
 
-func ValidateToken(tokenString string) (*Claims, error) {
-	token, err := jwt.ParseWithClaims(tokenString, &Claims{}, func(token *jwt.Token) (interface{}, error) {
-		if _, ok := token.Method.(*jwt.SigningMethodHMAC); !ok {
-			return nil, fmt.Errorf("unexpected signing method: %v", token.Header["alg"])
-		}
-		return []byte(jwtSecretKey), nil
-	})
+// ValidateToken verifies tokenString and checks that it is a token of the
+// expected type issued by this server.
+func (a *Authenticator) ValidateToken(tokenString string, expected TokenType) (*Claims, error) {
+	claims := &Claims{}
+	if err := a.parseToken(tokenString, claims); err != nil {
+		return nil, err
+	}
+
+	if claims.TokenType != expected {
+		return nil, fmt.Errorf("expected %s token, got %q", expected, claims.TokenType)
+	}
+
+	if claims.ID == "" {
+		return nil, fmt.Errorf("token has no id")
+	}
+
+	return claims, nil
+}
+
+// parseToken verifies the signature and registered claims of tokenString
+// and decodes it into claims.
+func (a *Authenticator) parseToken(tokenString string, claims jwt.Claims) error {
+	token, err := jwt.ParseWithClaims(tokenString, claims, a.verificationKey,
+		jwt.WithValidMethods([]string{jwt.SigningMethodRS256.Alg(), jwt.SigningMethodEdDSA.Alg()}),
+		jwt.WithExpirationRequired(),
+		jwt.WithIssuer(tokenIssuer),
+		jwt.WithAudience(tokenAudience),
+	)
+	if err != nil {
+		return fmt.Errorf("failed to parse token: %w", err)
+	}
+	if !token.Valid {
+		return fmt.Errorf("invalid token")
+	}
+	return nil
+}
 
+// verificationKey resolves the key named by the token's kid header and makes
+// sure the token was signed with that key's algorithm.
+func (a *Authenticator) verificationKey(token *jwt.Token) (interface{}, error) {
+	kid, _ := token.Header["kid"].(string)
+	if kid == "" {
+		return nil, fmt.Errorf("token has no key id")
+	}
+
+	key, err := a.keys.VerificationKey(kid)
 	if err != nil {
-		return nil, fmt.Errorf("failed to parse token: %w", err)
+		return nil, err
 	}
 
-	claims, ok := token.Claims.(*Claims)
-	if !ok || !token.Valid {
-		return nil, fmt.Errorf("invalid token")
+	if token.Method.Alg() != key.Method.Alg() {
+		return nil, fmt.Errorf("unexpected signing method: %v", token.Header["alg"])
 	}
 
-	return claims, nil
+	return key.Public, nil
 }
@@ -0,0 +1,158 @@
+package auth
+
+import (
+	"testing"
+	"time"
+)
+
+func newTestAuthenticator(t *testing.T) *Authenticator {
+	t.Helper()
+	keys, err := GenerateKeySet()
+	if err != nil {
+		t.Fatalf("GenerateKeySet() error = %v", err)
+	}
+	return &Authenticator{keys: keys, passwords: DefaultPasswordHasher()}
+}
+
+func TestValidateTokenType(t *testing.T) {
+	a := newTestAuthenticator(t)
+	pair, err := a.GenerateTokenPair("user-1", "user@example.com", RoleUser, "session-1")
+	if err != nil {
+		t.Fatalf("GenerateTokenPair() error = %v", err)
+	}
+
+	tests := []struct {
+		name     string
+		token    string
+		expected TokenType
+		wantErr  bool
+	}{
+		{
+			name:     "access token as access",
+			token:    pair.AccessToken,
+			expected: TokenTypeAccess,
+		},
+		{
+			name:     "refresh token as refresh",
+			token:    pair.RefreshToken,
+			expected: TokenTypeRefresh,
+		},
+		{
+			name:     "refresh token as access",
+			token:    pair.RefreshToken,
+			expected: TokenTypeAccess,
+			wantErr:  true,
+		},
+		{
+			name:     "access token as refresh",
+			token:    pair.AccessToken,
+			expected: TokenTypeRefresh,
+			wantErr:  true,
+		},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			claims, err := a.ValidateToken(tt.token, tt.expected)
+			if (err != nil) != tt.wantErr {
+				t.Fatalf("ValidateToken() error = %v, wantErr %v", err, tt.wantErr)
+			}
+			if tt.wantErr {
+				return
+			}
+			if claims.UserID != "user-1" {
+				t.Errorf("Expected user ID user-1, got %v", claims.UserID)
+			}
+			if claims.Issuer != tokenIssuer {
+				t.Errorf("Expected issuer %v, got %v", tokenIssuer, claims.Issuer)
+			}
+			if claims.ID == "" {
+				t.Error("Expected token to carry a jti")
+			}
+		})
+	}
+}
+
+func TestGenerateTokenPairUniqueIDs(t *testing.T) {
+	a := newTestAuthenticator(t)
+	first, err := a.GenerateTokenPair("user-1", "user@example.com", RoleUser, "session-1")
+	if err != nil {
+		t.Fatalf("GenerateTokenPair() error = %v", err)
+	}
+	second, err := a.GenerateTokenPair("user-1", "user@example.com", RoleUser, "session-1")
+	if err != nil {
+		t.Fatalf("GenerateTokenPair() error = %v", err)
+	}
+
+	if first.RefreshToken == second.RefreshToken {
+		t.Error("Expected refresh tokens issued in the same second to differ")
+	}
+}
+
+func TestTokenCarriesRole(t *testing.T) {
+	a := newTestAuthenticator(t)
+	pair, err := a.GenerateTokenPair("admin-1", "admin@example.com", RoleAdmin, "session-1")
+	if err != nil {
+		t.Fatalf("GenerateTokenPair() error = %v", err)
+	}
+
+	claims, err := a.ValidateToken(pair.AccessToken, TokenTypeAccess)
+	if err != nil {
+		t.Fatalf("ValidateToken() error = %v", err)
+	}
+	if claims.Role != RoleAdmin {
+		t.Errorf("Expected role %q, got %q", RoleAdmin, claims.Role)
+	}
+}
+
+func TestTokenCarriesPermissions(t *testing.T) {
+	a := newTestAuthenticator(t)
+	pair, err := a.GenerateTokenPair("user-1", "user@example.com", RoleUser, "session-1", PermissionViewAuthEvents)
+	if err != nil {
+		t.Fatalf("GenerateTokenPair() error = %v", err)
+	}
+
+	claims, err := a.ValidateToken(pair.AccessToken, TokenTypeAccess)
+	if err != nil {
+		t.Fatalf("ValidateToken() error = %v", err)
+	}
+	if len(claims.Permissions) != 1 || claims.Permissions[0] != PermissionViewAuthEvents {
+		t.Errorf("Expected permissions [%s], got %v", PermissionViewAuthEvents, claims.Permissions)
+	}
+}
+
+func TestImpersonationTokenCarriesActor(t *testing.T) {
+	a := newTestAuthenticator(t)
+	a.revocations = NewRevocationStore(nil)
+
+	token, err := a.generateToken(Claims{
+		UserID:    "user-1",
+		Email:     "user@example.com",
+		TokenType: TokenTypeAccess,
+		Role:      RoleUser,
+		Actor:     &Actor{Subject: "admin-1"},
+	}, impersonationTokenExpiry)
+	if err != nil {
+		t.Fatalf("generateToken() error = %v", err)
+	}
+
+	claims, err := a.ValidateAccessToken(token)
+	if err != nil {
+		t.Fatalf("ValidateAccessToken() error = %v", err)
+	}
+	if claims.UserID != "user-1" || claims.Actor == nil || claims.Actor.Subject != "admin-1" {
+		t.Errorf("Expected user-1 acted on by admin-1, got %+v", claims)
+	}
+	if claims.SessionID != "" {
+		t.Errorf("Expected no session, got %q", claims.SessionID)
+	}
+	if _, err := a.ValidateToken(token, TokenTypeRefresh); err == nil {
+		t.Error("Expected the impersonation token not to be accepted for a refresh")
+	}
+
+	// Revoking the admin's tokens ends the impersonation too.
+	a.revocations.revokeUserBefore("admin-1", time.Now().Add(2*time.Second))
+	if _, err := a.ValidateAccessToken(token); err == nil {
+		t.Error("Expected the impersonation token to die with the admin's sessions")
+	}
+}
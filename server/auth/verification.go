@@ -0,0 +1,88 @@
+package auth
+
+import (
+	"context"
+	"fmt"
+	"net/url"
+	"time"
+
+	"grpc-server/ent"
+	"grpc-server/mail"
+)
+
+const emailVerificationExpiry = 24 * time.Hour
+
+// SendVerificationEmail emails u a single-use link that confirms the
+// address the account currently has.
+func (a *Authenticator) SendVerificationEmail(ctx context.Context, u *ent.User) error {
+	token, err := a.generateToken(Claims{
+		UserID:    u.ID,
+		Email:     u.Email,
+		TokenType: TokenTypeEmailVerification,
+	}, emailVerificationExpiry)
+	if err != nil {
+		return fmt.Errorf("failed to generate verification token: %w", err)
+	}
+
+	link := a.config.AppURL + "/verify-email?token=" + url.QueryEscape(token)
+	err = a.config.Mailer.Send(ctx, mail.Message{
+		To:      u.Email,
+		Subject: "Verify your email address",
+		Body: fmt.Sprintf("Hi %s,\n\n"+
+			"Please confirm your email address by opening the link below:\n\n%s\n\n"+
+			"The link expires in %d hours. If you did not create an account, you can ignore this email.\n",
+			u.Name, link, int(emailVerificationExpiry.Hours())),
+	})
+	if err != nil {
+		return fmt.Errorf("failed to send verification email: %w", err)
+	}
+	return nil
+}
+
+// QueueVerificationEmail sends u the email of SendVerificationEmail in the
+// background, without holding up the request.
+func (a *Authenticator) QueueVerificationEmail(ctx context.Context, u *ent.User) {
+	a.mail.enqueue(ctx, "send verification email", func(ctx context.Context) error {
+		return a.SendVerificationEmail(ctx, u)
+	})
+}
+
+// sendAccountExistsEmail tells the owner of email that someone tried to
+// register it again, in place of the verification email a new account gets.
+func (a *Authenticator) sendAccountExistsEmail(ctx context.Context, email string) error {
+	u, err := a.client.User.
+		Query().
+		Where(emailIs(email)).
+		Only(ctx)
+	if err != nil {
+		return fmt.Errorf("failed to query user: %w", err)
+	}
+
+	err = a.config.Mailer.Send(ctx, mail.Message{
+		To:      u.Email,
+		Subject: "You already have an account",
+		Body: fmt.Sprintf("Hi %s,\n\n"+
+			"Someone tried to create an account with this email address, but you already have one. "+
+			"If it was you, sign in at %s, or ask for a password reset if you have forgotten your password.\n\n"+
+			"If it was not you, you can ignore this email; your account has not changed.\n",
+			u.Name, a.config.AppURL+"/sign-in"),
+	})
+	if err != nil {
+		return fmt.Errorf("failed to send account exists email: %w", err)
+	}
+	return nil
+}
+
+// consumeToken validates a single-use token of the given type and marks it
+// as spent, so a second presentation fails with ErrTokenAlreadyUsed.
+func (a *Authenticator) consumeToken(ctx context.Context, tokenString string, expected TokenType) (*Claims, error) {
+	claims, err := a.ValidateToken(tokenString, expected)
+	if err != nil {
+		return nil, fmt.Errorf("%w: %v", ErrInvalidToken, err)
+	}
+
+	if err := a.revocations.Consume(ctx, claims.ID, claims.ExpiresAt.Time); err != nil {
+		return nil, err
+	}
+	return claims, nil
+}
@@ -0,0 +1,51 @@
+package auth
+
+import (
+	"context"
+	"net/url"
+	"regexp"
+	"testing"
+
+	"grpc-server/ent"
+	"grpc-server/mail"
+)
+
+func TestSendVerificationEmail(t *testing.T) {
+	a := newTestAuthenticator(t)
+	mailer := mail.NewMemoryMailer()
+	a.config = Config{Mailer: mailer, AppURL: "http://app.test"}
+
+	u := &ent.User{ID: "user-1", Email: "user@example.com", Name: "User"}
+	if err := a.SendVerificationEmail(context.Background(), u); err != nil {
+		t.Fatalf("SendVerificationEmail() error = %v", err)
+	}
+
+	messages := mailer.Messages()
+	if len(messages) != 1 {
+		t.Fatalf("Expected 1 message, got %d", len(messages))
+	}
+	if messages[0].To != u.Email {
+		t.Errorf("Expected message to %v, got %v", u.Email, messages[0].To)
+	}
+
+	match := regexp.MustCompile(`http://app\.test/verify-email\?token=(\S+)`).FindStringSubmatch(messages[0].Body)
+	if match == nil {
+		t.Fatalf("Expected a verification link in %q", messages[0].Body)
+	}
+	token, err := url.QueryUnescape(match[1])
+	if err != nil {
+		t.Fatalf("QueryUnescape() error = %v", err)
+	}
+
+	claims, err := a.ValidateToken(token, TokenTypeEmailVerification)
+	if err != nil {
+		t.Fatalf("ValidateToken() error = %v", err)
+	}
+	if claims.UserID != u.ID || claims.Email != u.Email {
+		t.Errorf("Unexpected claims %+v", claims)
+	}
+
+	if _, err := a.ValidateToken(token, TokenTypeAccess); err == nil {
+		t.Error("Expected a verification token to be rejected as an access token")
+	}
+}
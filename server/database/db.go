@@ -2,10 +2,13 @@ package database
 
 import (
 	"context"
+	"database/sql"
 	"fmt"
 	"log"
 
 	"grpc-server/ent"
+	// Registers schema defaults, validators and hooks with the client.
+	_ "grpc-server/ent/runtime"
 
 	"entgo.io/ent/dialect"
 	entsql "entgo.io/ent/dialect/sql"
@@ -14,6 +17,7 @@ import (
 
 type DB struct {
 	Client *ent.Client
+	sql    *sql.DB
 }
 
 func New(connString string) (*DB, error) {
@@ -34,7 +38,7 @@ func New(connString string) (*DB, error) {
 
 	log.Println("Successfully connected to PostgreSQL with Ent")
 
-	return &DB{Client: client}, nil
+	return &DB{Client: client, sql: db}, nil
 }
 
 func (db *DB) Close() error {
@@ -0,0 +1,145 @@
+package database
+
+import (
+	"context"
+	"database/sql"
+	"errors"
+	"fmt"
+	"sort"
+	"strings"
+)
+
+// emailIndexName is the unique index that keeps two users from having
+// addresses that differ only by case.
+const emailIndexName = "users_email_lower_key"
+
+// EmailCollisionError lists accounts whose addresses are the same once
+// normalized. They have to be merged or renamed by hand before the
+// case-insensitive index can be created.
+type EmailCollisionError struct {
+	// Collisions maps a normalized address to the accounts that share it,
+	// each as "id <stored email>".
+	Collisions map[string][]string
+}
+
+func (e *EmailCollisionError) Error() string {
+	emails := make([]string, 0, len(e.Collisions))
+	for email := range e.Collisions {
+		emails = append(emails, email)
+	}
+	sort.Strings(emails)
+
+	var b strings.Builder
+	fmt.Fprintf(&b, "%d email addresses are used by more than one account:", len(emails))
+	for _, email := range emails {
+		fmt.Fprintf(&b, "\n  %s: %s", email, strings.Join(e.Collisions[email], ", "))
+	}
+	return b.String()
+}
+
+// NormalizeEmails rewrites stored addresses with normalize and then adds a
+// unique index on lower(email). fingerprint identifies normalize, e.g. the
+// email rules it applies, and is kept as the comment of the index: the
+// addresses are rewritten again whenever it changes, so that they match what
+// lookups normalize to. If two accounts end up with the same address it
+// changes nothing and returns an *EmailCollisionError.
+func (db *DB) NormalizeEmails(ctx context.Context, fingerprint string, normalize func(string) string) error {
+	current, err := emailIndexFingerprint(ctx, db.sql)
+	if err != nil {
+		return err
+	}
+	if current == fingerprint {
+		return nil
+	}
+
+	tx, err := db.sql.BeginTx(ctx, nil)
+	if err != nil {
+		return fmt.Errorf("failed to start transaction: %w", err)
+	}
+	if err := normalizeEmailsTx(ctx, tx, fingerprint, normalize); err != nil {
+		_ = tx.Rollback()
+		return err
+	}
+	if err := tx.Commit(); err != nil {
+		return fmt.Errorf("failed to commit transaction: %w", err)
+	}
+	return nil
+}
+
+// emailIndexFingerprint returns the fingerprint the addresses were last
+// normalized with, or "" if the index does not exist yet.
+func emailIndexFingerprint(ctx context.Context, db *sql.DB) (string, error) {
+	var fingerprint sql.NullString
+	err := db.QueryRowContext(ctx,
+		`SELECT obj_description(oid, 'pg_class') FROM pg_class WHERE relname = $1 AND relkind = 'i'`, emailIndexName,
+	).Scan(&fingerprint)
+	if err != nil && !errors.Is(err, sql.ErrNoRows) {
+		return "", fmt.Errorf("failed to look up email index: %w", err)
+	}
+	return fingerprint.String, nil
+}
+
+func normalizeEmailsTx(ctx context.Context, tx *sql.Tx, fingerprint string, normalize func(string) string) error {
+	// Keep new sign-ups out until the index is in place.
+	if _, err := tx.ExecContext(ctx, `LOCK TABLE users IN SHARE ROW EXCLUSIVE MODE`); err != nil {
+		return fmt.Errorf("failed to lock users: %w", err)
+	}
+
+	rows, err := tx.QueryContext(ctx, `SELECT id, email FROM users`)
+	if err != nil {
+		return fmt.Errorf("failed to query users: %w", err)
+	}
+	defer rows.Close()
+
+	type account struct{ id, email, normalized string }
+	var accounts []account
+	owners := make(map[string][]string)
+	for rows.Next() {
+		var a account
+		if err := rows.Scan(&a.id, &a.email); err != nil {
+			return fmt.Errorf("failed to scan user: %w", err)
+		}
+		a.normalized = normalize(a.email)
+		key := strings.ToLower(a.normalized)
+		owners[key] = append(owners[key], fmt.Sprintf("%s <%s>", a.id, a.email))
+		accounts = append(accounts, a)
+	}
+	if err := rows.Err(); err != nil {
+		return fmt.Errorf("failed to query users: %w", err)
+	}
+
+	collisions := make(map[string][]string)
+	for email, ids := range owners {
+		if len(ids) > 1 {
+			collisions[email] = ids
+		}
+	}
+	if len(collisions) > 0 {
+		return &EmailCollisionError{Collisions: collisions}
+	}
+
+	for _, a := range accounts {
+		if a.normalized == a.email {
+			continue
+		}
+		if _, err := tx.ExecContext(ctx, `UPDATE users SET email = $1 WHERE id = $2`, a.normalized, a.id); err != nil {
+			return fmt.Errorf("failed to normalize email of user %s: %w", a.id, err)
+		}
+	}
+
+	// Instances starting together wait for each other on the lock above;
+	// the later ones find the addresses normalized and the index in place.
+	if _, err := tx.ExecContext(ctx, `CREATE UNIQUE INDEX IF NOT EXISTS `+emailIndexName+` ON users (lower(email))`); err != nil {
+		return fmt.Errorf("failed to create email index: %w", err)
+	}
+	// COMMENT takes no parameters.
+	if _, err := tx.ExecContext(ctx, `COMMENT ON INDEX `+emailIndexName+` IS `+quoteLiteral(fingerprint)); err != nil {
+		return fmt.Errorf("failed to record email normalization: %w", err)
+	}
+	return nil
+}
+
+// quoteLiteral quotes s as an SQL string literal.
+func quoteLiteral(s string) string {
+	return "'" + strings.ReplaceAll(s, "'", "''") + "'"
+}
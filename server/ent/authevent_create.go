@@ -0,0 +1,414 @@
+// Code generated by ent, DO NOT EDIT.
+
+package ent
+
+import (
+	"context"
+	"errors"
+	"fmt"
+	"grpc-server/ent/authevent"
+	"time"
+
+	"entgo.io/ent/dialect/sql/sqlgraph"
+	"entgo.io/ent/schema/field"
+)
+
+// AuthEventCreate is the builder for creating a AuthEvent entity.
+type AuthEventCreate struct {
+	config
+	mutation *AuthEventMutation
+	hooks    []Hook
+}
+
+// SetType sets the "type" field.
+func (_c *AuthEventCreate) SetType(v string) *AuthEventCreate {
+	_c.mutation.SetType(v)
+	return _c
+}
+
+// SetProcedure sets the "procedure" field.
+func (_c *AuthEventCreate) SetProcedure(v string) *AuthEventCreate {
+	_c.mutation.SetProcedure(v)
+	return _c
+}
+
+// SetNillableProcedure sets the "procedure" field if the given value is not nil.
+func (_c *AuthEventCreate) SetNillableProcedure(v *string) *AuthEventCreate {
+	if v != nil {
+		_c.SetProcedure(*v)
+	}
+	return _c
+}
+
+// SetUserID sets the "user_id" field.
+func (_c *AuthEventCreate) SetUserID(v string) *AuthEventCreate {
+	_c.mutation.SetUserID(v)
+	return _c
+}
+
+// SetNillableUserID sets the "user_id" field if the given value is not nil.
+func (_c *AuthEventCreate) SetNillableUserID(v *string) *AuthEventCreate {
+	if v != nil {
+		_c.SetUserID(*v)
+	}
+	return _c
+}
+
+// SetActorID sets the "actor_id" field.
+func (_c *AuthEventCreate) SetActorID(v string) *AuthEventCreate {
+	_c.mutation.SetActorID(v)
+	return _c
+}
+
+// SetNillableActorID sets the "actor_id" field if the given value is not nil.
+func (_c *AuthEventCreate) SetNillableActorID(v *string) *AuthEventCreate {
+	if v != nil {
+		_c.SetActorID(*v)
+	}
+	return _c
+}
+
+// SetPrincipal sets the "principal" field.
+func (_c *AuthEventCreate) SetPrincipal(v string) *AuthEventCreate {
+	_c.mutation.SetPrincipal(v)
+	return _c
+}
+
+// SetNillablePrincipal sets the "principal" field if the given value is not nil.
+func (_c *AuthEventCreate) SetNillablePrincipal(v *string) *AuthEventCreate {
+	if v != nil {
+		_c.SetPrincipal(*v)
+	}
+	return _c
+}
+
+// SetEmail sets the "email" field.
+func (_c *AuthEventCreate) SetEmail(v string) *AuthEventCreate {
+	_c.mutation.SetEmail(v)
+	return _c
+}
+
+// SetNillableEmail sets the "email" field if the given value is not nil.
+func (_c *AuthEventCreate) SetNillableEmail(v *string) *AuthEventCreate {
+	if v != nil {
+		_c.SetEmail(*v)
+	}
+	return _c
+}
+
+// SetIPAddress sets the "ip_address" field.
+func (_c *AuthEventCreate) SetIPAddress(v string) *AuthEventCreate {
+	_c.mutation.SetIPAddress(v)
+	return _c
+}
+
+// SetNillableIPAddress sets the "ip_address" field if the given value is not nil.
+func (_c *AuthEventCreate) SetNillableIPAddress(v *string) *AuthEventCreate {
+	if v != nil {
+		_c.SetIPAddress(*v)
+	}
+	return _c
+}
+
+// SetUserAgent sets the "user_agent" field.
+func (_c *AuthEventCreate) SetUserAgent(v string) *AuthEventCreate {
+	_c.mutation.SetUserAgent(v)
+	return _c
+}
+
+// SetNillableUserAgent sets the "user_agent" field if the given value is not nil.
+func (_c *AuthEventCreate) SetNillableUserAgent(v *string) *AuthEventCreate {
+	if v != nil {
+		_c.SetUserAgent(*v)
+	}
+	return _c
+}
+
+// SetOutcome sets the "outcome" field.
+func (_c *AuthEventCreate) SetOutcome(v authevent.Outcome) *AuthEventCreate {
+	_c.mutation.SetOutcome(v)
+	return _c
+}
+
+// SetReason sets the "reason" field.
+func (_c *AuthEventCreate) SetReason(v string) *AuthEventCreate {
+	_c.mutation.SetReason(v)
+	return _c
+}
+
+// SetNillableReason sets the "reason" field if the given value is not nil.
+func (_c *AuthEventCreate) SetNillableReason(v *string) *AuthEventCreate {
+	if v != nil {
+		_c.SetReason(*v)
+	}
+	return _c
+}
+
+// SetCreatedAt sets the "created_at" field.
+func (_c *AuthEventCreate) SetCreatedAt(v time.Time) *AuthEventCreate {
+	_c.mutation.SetCreatedAt(v)
+	return _c
+}
+
+// SetNillableCreatedAt sets the "created_at" field if the given value is not nil.
+func (_c *AuthEventCreate) SetNillableCreatedAt(v *time.Time) *AuthEventCreate {
+	if v != nil {
+		_c.SetCreatedAt(*v)
+	}
+	return _c
+}
+
+// SetID sets the "id" field.
+func (_c *AuthEventCreate) SetID(v string) *AuthEventCreate {
+	_c.mutation.SetID(v)
+	return _c
+}
+
+// SetNillableID sets the "id" field if the given value is not nil.
+func (_c *AuthEventCreate) SetNillableID(v *string) *AuthEventCreate {
+	if v != nil {
+		_c.SetID(*v)
+	}
+	return _c
+}
+
+// Mutation returns the AuthEventMutation object of the builder.
+func (_c *AuthEventCreate) Mutation() *AuthEventMutation {
+	return _c.mutation
+}
+
+// Save creates the AuthEvent in the database.
+func (_c *AuthEventCreate) Save(ctx context.Context) (*AuthEvent, error) {
+	if err := _c.defaults(); err != nil {
+		return nil, err
+	}
+	return withHooks(ctx, _c.sqlSave, _c.mutation, _c.hooks)
+}
+
+// SaveX calls Save and panics if Save returns an error.
+func (_c *AuthEventCreate) SaveX(ctx context.Context) *AuthEvent {
+	v, err := _c.Save(ctx)
+	if err != nil {
+		panic(err)
+	}
+	return v
+}
+
+// Exec executes the query.
+func (_c *AuthEventCreate) Exec(ctx context.Context) error {
+	_, err := _c.Save(ctx)
+	return err
+}
+
+// ExecX is like Exec, but panics if an error occurs.
+func (_c *AuthEventCreate) ExecX(ctx context.Context) {
+	if err := _c.Exec(ctx); err != nil {
+		panic(err)
+	}
+}
+
+// defaults sets the default values of the builder before save.
+func (_c *AuthEventCreate) defaults() error {
+	if _, ok := _c.mutation.CreatedAt(); !ok {
+		if authevent.DefaultCreatedAt == nil {
+			return fmt.Errorf("ent: uninitialized authevent.DefaultCreatedAt (forgotten import ent/runtime?)")
+		}
+		v := authevent.DefaultCreatedAt()
+		_c.mutation.SetCreatedAt(v)
+	}
+	if _, ok := _c.mutation.ID(); !ok {
+		if authevent.DefaultID == nil {
+			return fmt.Errorf("ent: uninitialized authevent.DefaultID (forgotten import ent/runtime?)")
+		}
+		v := authevent.DefaultID()
+		_c.mutation.SetID(v)
+	}
+	return nil
+}
+
+// check runs all checks and user-defined validators on the builder.
+func (_c *AuthEventCreate) check() error {
+	if _, ok := _c.mutation.GetType(); !ok {
+		return &ValidationError{Name: "type", err: errors.New(`ent: missing required field "AuthEvent.type"`)}
+	}
+	if v, ok := _c.mutation.GetType(); ok {
+		if err := authevent.TypeValidator(v); err != nil {
+			return &ValidationError{Name: "type", err: fmt.Errorf(`ent: validator failed for field "AuthEvent.type": %w`, err)}
+		}
+	}
+	if _, ok := _c.mutation.Outcome(); !ok {
+		return &ValidationError{Name: "outcome", err: errors.New(`ent: missing required field "AuthEvent.outcome"`)}
+	}
+	if v, ok := _c.mutation.Outcome(); ok {
+		if err := authevent.OutcomeValidator(v); err != nil {
+			return &ValidationError{Name: "outcome", err: fmt.Errorf(`ent: validator failed for field "AuthEvent.outcome": %w`, err)}
+		}
+	}
+	if _, ok := _c.mutation.CreatedAt(); !ok {
+		return &ValidationError{Name: "created_at", err: errors.New(`ent: missing required field "AuthEvent.created_at"`)}
+	}
+	return nil
+}
+
+func (_c *AuthEventCreate) sqlSave(ctx context.Context) (*AuthEvent, error) {
+	if err := _c.check(); err != nil {
+		return nil, err
+	}
+	_node, _spec := _c.createSpec()
+	if err := sqlgraph.CreateNode(ctx, _c.driver, _spec); err != nil {
+		if sqlgraph.IsConstraintError(err) {
+			err = &ConstraintError{msg: err.Error(), wrap: err}
+		}
+		return nil, err
+	}
+	if _spec.ID.Value != nil {
+		if id, ok := _spec.ID.Value.(string); ok {
+			_node.ID = id
+		} else {
+			return nil, fmt.Errorf("unexpected AuthEvent.ID type: %T", _spec.ID.Value)
+		}
+	}
+	_c.mutation.id = &_node.ID
+	_c.mutation.done = true
+	return _node, nil
+}
+
+func (_c *AuthEventCreate) createSpec() (*AuthEvent, *sqlgraph.CreateSpec) {
+	var (
+		_node = &AuthEvent{config: _c.config}
+		_spec = sqlgraph.NewCreateSpec(authevent.Table, sqlgraph.NewFieldSpec(authevent.FieldID, field.TypeString))
+	)
+	if id, ok := _c.mutation.ID(); ok {
+		_node.ID = id
+		_spec.ID.Value = id
+	}
+	if value, ok := _c.mutation.GetType(); ok {
+		_spec.SetField(authevent.FieldType, field.TypeString, value)
+		_node.Type = value
+	}
+	if value, ok := _c.mutation.Procedure(); ok {
+		_spec.SetField(authevent.FieldProcedure, field.TypeString, value)
+		_node.Procedure = value
+	}
+	if value, ok := _c.mutation.UserID(); ok {
+		_spec.SetField(authevent.FieldUserID, field.TypeString, value)
+		_node.UserID = value
+	}
+	if value, ok := _c.mutation.ActorID(); ok {
+		_spec.SetField(authevent.FieldActorID, field.TypeString, value)
+		_node.ActorID = value
+	}
+	if value, ok := _c.mutation.Principal(); ok {
+		_spec.SetField(authevent.FieldPrincipal, field.TypeString, value)
+		_node.Principal = value
+	}
+	if value, ok := _c.mutation.Email(); ok {
+		_spec.SetField(authevent.FieldEmail, field.TypeString, value)
+		_node.Email = value
+	}
+	if value, ok := _c.mutation.IPAddress(); ok {
+		_spec.SetField(authevent.FieldIPAddress, field.TypeString, value)
+		_node.IPAddress = value
+	}
+	if value, ok := _c.mutation.UserAgent(); ok {
+		_spec.SetField(authevent.FieldUserAgent, field.TypeString, value)
+		_node.UserAgent = value
+	}
+	if value, ok := _c.mutation.Outcome(); ok {
+		_spec.SetField(authevent.FieldOutcome, field.TypeEnum, value)
+		_node.Outcome = value
+	}
+	if value, ok := _c.mutation.Reason(); ok {
+		_spec.SetField(authevent.FieldReason, field.TypeString, value)
+		_node.Reason = value
+	}
+	if value, ok := _c.mutation.CreatedAt(); ok {
+		_spec.SetField(authevent.FieldCreatedAt, field.TypeTime, value)
+		_node.CreatedAt = value
+	}
+	return _node, _spec
+}
+
+// AuthEventCreateBulk is the builder for creating many AuthEvent entities in bulk.
+type AuthEventCreateBulk struct {
+	config
+	err      error
+	builders []*AuthEventCreate
+}
+
+// Save creates the AuthEvent entities in the database.
+func (_c *AuthEventCreateBulk) Save(ctx context.Context) ([]*AuthEvent, error) {
+	if _c.err != nil {
+		return nil, _c.err
+	}
+	specs := make([]*sqlgraph.CreateSpec, len(_c.builders))
+	nodes := make([]*AuthEvent, len(_c.builders))
+	mutators := make([]Mutator, len(_c.builders))
+	for i := range _c.builders {
+		func(i int, root context.Context) {
+			builder := _c.builders[i]
+			builder.defaults()
+			var mut Mutator = MutateFunc(func(ctx context.Context, m Mutation) (Value, error) {
+				mutation, ok := m.(*AuthEventMutation)
+				if !ok {
+					return nil, fmt.Errorf("unexpected mutation type %T", m)
+				}
+				if err := builder.check(); err != nil {
+					return nil, err
+				}
+				builder.mutation = mutation
+				var err error
+				nodes[i], specs[i] = builder.createSpec()
+				if i < len(mutators)-1 {
+					_, err = mutators[i+1].Mutate(root, _c.builders[i+1].mutation)
+				} else {
+					spec := &sqlgraph.BatchCreateSpec{Nodes: specs}
+					// Invoke the actual operation on the latest mutation in the chain.
+					if err = sqlgraph.BatchCreate(ctx, _c.driver, spec); err != nil {
+						if sqlgraph.IsConstraintError(err) {
+							err = &ConstraintError{msg: err.Error(), wrap: err}
+						}
+					}
+				}
+				if err != nil {
+					return nil, err
+				}
+				mutation.id = &nodes[i].ID
+				mutation.done = true
+				return nodes[i], nil
+			})
+			for i := len(builder.hooks) - 1; i >= 0; i-- {
+				mut = builder.hooks[i](mut)
+			}
+			mutators[i] = mut
+		}(i, ctx)
+	}
+	if len(mutators) > 0 {
+		if _, err := mutators[0].Mutate(ctx, _c.builders[0].mutation); err != nil {
+			return nil, err
+		}
+	}
+	return nodes, nil
+}
+
+// SaveX is like Save, but panics if an error occurs.
+func (_c *AuthEventCreateBulk) SaveX(ctx context.Context) []*AuthEvent {
+	v, err := _c.Save(ctx)
+	if err != nil {
+		panic(err)
+	}
+	return v
+}
+
+// Exec executes the query.
+func (_c *AuthEventCreateBulk) Exec(ctx context.Context) error {
+	_, err := _c.Save(ctx)
+	return err
+}
+
+// ExecX is like Exec, but panics if an error occurs.
+func (_c *AuthEventCreateBulk) ExecX(ctx context.Context) {
+	if err := _c.Exec(ctx); err != nil {
+		panic(err)
+	}
+}
@@ -0,0 +1,223 @@
+// Code generated by ent, DO NOT EDIT.
+
+package ent
+
+import (
+	"context"
+	"errors"
+	"fmt"
+	"grpc-server/ent/authevent"
+	"grpc-server/ent/predicate"
+
+	"entgo.io/ent/dialect/sql"
+	"entgo.io/ent/dialect/sql/sqlgraph"
+	"entgo.io/ent/schema/field"
+)
+
+// AuthEventUpdate is the builder for updating AuthEvent entities.
+type AuthEventUpdate struct {
+	config
+	hooks    []Hook
+	mutation *AuthEventMutation
+}
+
+// Where appends a list predicates to the AuthEventUpdate builder.
+func (_u *AuthEventUpdate) Where(ps ...predicate.AuthEvent) *AuthEventUpdate {
+	_u.mutation.Where(ps...)
+	return _u
+}
+
+// Mutation returns the AuthEventMutation object of the builder.
+func (_u *AuthEventUpdate) Mutation() *AuthEventMutation {
+	return _u.mutation
+}
+
+// Save executes the query and returns the number of nodes affected by the update operation.
+func (_u *AuthEventUpdate) Save(ctx context.Context) (int, error) {
+	return withHooks(ctx, _u.sqlSave, _u.mutation, _u.hooks)
+}
+
+// SaveX is like Save, but panics if an error occurs.
+func (_u *AuthEventUpdate) SaveX(ctx context.Context) int {
+	affected, err := _u.Save(ctx)
+	if err != nil {
+		panic(err)
+	}
+	return affected
+}
+
+// Exec executes the query.
+func (_u *AuthEventUpdate) Exec(ctx context.Context) error {
+	_, err := _u.Save(ctx)
+	return err
+}
+
+// ExecX is like Exec, but panics if an error occurs.
+func (_u *AuthEventUpdate) ExecX(ctx context.Context) {
+	if err := _u.Exec(ctx); err != nil {
+		panic(err)
+	}
+}
+
+func (_u *AuthEventUpdate) sqlSave(ctx context.Context) (_node int, err error) {
+	_spec := sqlgraph.NewUpdateSpec(authevent.Table, authevent.Columns, sqlgraph.NewFieldSpec(authevent.FieldID, field.TypeString))
+	if ps := _u.mutation.predicates; len(ps) > 0 {
+		_spec.Predicate = func(selector *sql.Selector) {
+			for i := range ps {
+				ps[i](selector)
+			}
+		}
+	}
+	if _u.mutation.ProcedureCleared() {
+		_spec.ClearField(authevent.FieldProcedure, field.TypeString)
+	}
+	if _u.mutation.UserIDCleared() {
+		_spec.ClearField(authevent.FieldUserID, field.TypeString)
+	}
+	if _u.mutation.ActorIDCleared() {
+		_spec.ClearField(authevent.FieldActorID, field.TypeString)
+	}
+	if _u.mutation.PrincipalCleared() {
+		_spec.ClearField(authevent.FieldPrincipal, field.TypeString)
+	}
+	if _u.mutation.EmailCleared() {
+		_spec.ClearField(authevent.FieldEmail, field.TypeString)
+	}
+	if _u.mutation.IPAddressCleared() {
+		_spec.ClearField(authevent.FieldIPAddress, field.TypeString)
+	}
+	if _u.mutation.UserAgentCleared() {
+		_spec.ClearField(authevent.FieldUserAgent, field.TypeString)
+	}
+	if _u.mutation.ReasonCleared() {
+		_spec.ClearField(authevent.FieldReason, field.TypeString)
+	}
+	if _node, err = sqlgraph.UpdateNodes(ctx, _u.driver, _spec); err != nil {
+		if _, ok := err.(*sqlgraph.NotFoundError); ok {
+			err = &NotFoundError{authevent.Label}
+		} else if sqlgraph.IsConstraintError(err) {
+			err = &ConstraintError{msg: err.Error(), wrap: err}
+		}
+		return 0, err
+	}
+	_u.mutation.done = true
+	return _node, nil
+}
+
+// AuthEventUpdateOne is the builder for updating a single AuthEvent entity.
+type AuthEventUpdateOne struct {
+	config
+	fields   []string
+	hooks    []Hook
+	mutation *AuthEventMutation
+}
+
+// Mutation returns the AuthEventMutation object of the builder.
+func (_u *AuthEventUpdateOne) Mutation() *AuthEventMutation {
+	return _u.mutation
+}
+
+// Where appends a list predicates to the AuthEventUpdate builder.
+func (_u *AuthEventUpdateOne) Where(ps ...predicate.AuthEvent) *AuthEventUpdateOne {
+	_u.mutation.Where(ps...)
+	return _u
+}
+
+// Select allows selecting one or more fields (columns) of the returned entity.
+// The default is selecting all fields defined in the entity schema.
+func (_u *AuthEventUpdateOne) Select(field string, fields ...string) *AuthEventUpdateOne {
+	_u.fields = append([]string{field}, fields...)
+	return _u
+}
+
+// Save executes the query and returns the updated AuthEvent entity.
+func (_u *AuthEventUpdateOne) Save(ctx context.Context) (*AuthEvent, error) {
+	return withHooks(ctx, _u.sqlSave, _u.mutation, _u.hooks)
+}
+
+// SaveX is like Save, but panics if an error occurs.
+func (_u *AuthEventUpdateOne) SaveX(ctx context.Context) *AuthEvent {
+	node, err := _u.Save(ctx)
+	if err != nil {
+		panic(err)
+	}
+	return node
+}
+
+// Exec executes the query on the entity.
+func (_u *AuthEventUpdateOne) Exec(ctx context.Context) error {
+	_, err := _u.Save(ctx)
+	return err
+}
+
+// ExecX is like Exec, but panics if an error occurs.
+func (_u *AuthEventUpdateOne) ExecX(ctx context.Context) {
+	if err := _u.Exec(ctx); err != nil {
+		panic(err)
+	}
+}
+
+func (_u *AuthEventUpdateOne) sqlSave(ctx context.Context) (_node *AuthEvent, err error) {
+	_spec := sqlgraph.NewUpdateSpec(authevent.Table, authevent.Columns, sqlgraph.NewFieldSpec(authevent.FieldID, field.TypeString))
+	id, ok := _u.mutation.ID()
+	if !ok {
+		return nil, &ValidationError{Name: "id", err: errors.New(`ent: missing "AuthEvent.id" for update`)}
+	}
+	_spec.Node.ID.Value = id
+	if fields := _u.fields; len(fields) > 0 {
+		_spec.Node.Columns = make([]string, 0, len(fields))
+		_spec.Node.Columns = append(_spec.Node.Columns, authevent.FieldID)
+		for _, f := range fields {
+			if !authevent.ValidColumn(f) {
+				return nil, &ValidationError{Name: f, err: fmt.Errorf("ent: invalid field %q for query", f)}
+			}
+			if f != authevent.FieldID {
+				_spec.Node.Columns = append(_spec.Node.Columns, f)
+			}
+		}
+	}
+	if ps := _u.mutation.predicates; len(ps) > 0 {
+		_spec.Predicate = func(selector *sql.Selector) {
+			for i := range ps {
+				ps[i](selector)
+			}
+		}
+	}
+	if _u.mutation.ProcedureCleared() {
+		_spec.ClearField(authevent.FieldProcedure, field.TypeString)
+	}
+	if _u.mutation.UserIDCleared() {
+		_spec.ClearField(authevent.FieldUserID, field.TypeString)
+	}
+	if _u.mutation.ActorIDCleared() {
+		_spec.ClearField(authevent.FieldActorID, field.TypeString)
+	}
+	if _u.mutation.PrincipalCleared() {
+		_spec.ClearField(authevent.FieldPrincipal, field.TypeString)
+	}
+	if _u.mutation.EmailCleared() {
+		_spec.ClearField(authevent.FieldEmail, field.TypeString)
+	}
+	if _u.mutation.IPAddressCleared() {
+		_spec.ClearField(authevent.FieldIPAddress, field.TypeString)
+	}
+	if _u.mutation.UserAgentCleared() {
+		_spec.ClearField(authevent.FieldUserAgent, field.TypeString)
+	}
+	if _u.mutation.ReasonCleared() {
+		_spec.ClearField(authevent.FieldReason, field.TypeString)
+	}
+	_node = &AuthEvent{config: _u.config}
+	_spec.Assign = _node.assignValues
+	_spec.ScanValues = _node.scanValues
+	if err = sqlgraph.UpdateNode(ctx, _u.driver, _spec); err != nil {
+		if _, ok := err.(*sqlgraph.NotFoundError); ok {
+			err = &NotFoundError{authevent.Label}
+		} else if sqlgraph.IsConstraintError(err) {
+			err = &ConstraintError{msg: err.Error(), wrap: err}
+		}
+		return nil, err
+	}
+	_u.mutation.done = true
+	return _node, nil
+}
@@ -6,7 +6,16 @@ import (
 	"context"
 	"errors"
 	"fmt"
+	"grpc-server/ent/apikey"
+	"grpc-server/ent/authevent"
+	"grpc-server/ent/identity"
 	"grpc-server/ent/item"
+	"grpc-server/ent/loginthrottle"
+	"grpc-server/ent/passwordresettoken"
+	"grpc-server/ent/refreshtoken"
+	"grpc-server/ent/revokedtoken"
+	"grpc-server/ent/session"
+	"grpc-server/ent/totpfactor"
 	"grpc-server/ent/user"
 	"reflect"
 	"sync"
@@ -74,8 +83,17 @@ var (
 func checkColumn(t, c string) error {
 	initCheck.Do(func() {
 		columnCheck = sql.NewColumnCheck(map[string]func(string) bool{
-			item.Table: item.ValidColumn,
-			user.Table: user.ValidColumn,
+			apikey.Table:             apikey.ValidColumn,
+			authevent.Table:          authevent.ValidColumn,
+			identity.Table:           identity.ValidColumn,
+			item.Table:               item.ValidColumn,
+			loginthrottle.Table:      loginthrottle.ValidColumn,
+			passwordresettoken.Table: passwordresettoken.ValidColumn,
+			refreshtoken.Table:       refreshtoken.ValidColumn,
+			revokedtoken.Table:       revokedtoken.ValidColumn,
+			session.Table:            session.ValidColumn,
+			totpfactor.Table:         totpfactor.ValidColumn,
+			user.Table:               user.ValidColumn,
 		})
 	})
 	return columnCheck(t, c)
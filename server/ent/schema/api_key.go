@@ -0,0 +1,64 @@
+package schema
+
+import (
+	"time"
+
+	"entgo.io/ent"
+	"entgo.io/ent/schema/edge"
+	"entgo.io/ent/schema/field"
+	"github.com/google/uuid"
+)
+
+// APIKey holds the schema definition for the APIKey entity. Only a hash of
+// the secret is stored.
+type APIKey struct {
+	ent.Schema
+}
+
+// Fields of the APIKey.
+func (APIKey) Fields() []ent.Field {
+	return []ent.Field{
+		field.String("id").
+			DefaultFunc(func() string {
+				return uuid.New().String()
+			}).
+			Immutable().
+			Unique(),
+		field.String("name").
+			NotEmpty(),
+		// Prefix is the start of the secret, shown so keys can be told apart.
+		field.String("prefix").
+			NotEmpty().
+			Immutable(),
+		field.String("key_hash").
+			NotEmpty().
+			Immutable().
+			Unique().
+			Sensitive(),
+		field.Strings("scopes").
+			Immutable(),
+		field.Time("expires_at").
+			Optional().
+			Nillable().
+			Immutable(),
+		field.Time("last_used_at").
+			Optional().
+			Nillable(),
+		field.Time("revoked_at").
+			Optional().
+			Nillable(),
+		field.Time("created_at").
+			Default(time.Now).
+			Immutable(),
+	}
+}
+
+// Edges of the APIKey.
+func (APIKey) Edges() []ent.Edge {
+	return []ent.Edge{
+		edge.From("user", User.Type).
+			Ref("api_keys").
+			Required().
+			Unique(),
+	}
+}
@@ -0,0 +1,95 @@
+package schema
+
+import (
+	"context"
+	"fmt"
+	"time"
+
+	"entgo.io/ent"
+	"entgo.io/ent/schema/field"
+	"entgo.io/ent/schema/index"
+	"github.com/google/uuid"
+)
+
+// AuthEvent holds the schema definition for the AuthEvent entity. Auth events
+// form an append-only audit log of sign-ins, token refreshes, account changes
+// and rejected requests. They keep the user's ID rather than an edge so that
+// the log outlives the accounts it mentions.
+type AuthEvent struct {
+	ent.Schema
+}
+
+// Fields of the AuthEvent.
+func (AuthEvent) Fields() []ent.Field {
+	return []ent.Field{
+		field.String("id").
+			DefaultFunc(func() string {
+				return uuid.New().String()
+			}).
+			Immutable().
+			Unique(),
+		// Type is what happened, e.g. "login" or "authenticate".
+		field.String("type").
+			NotEmpty().
+			Immutable(),
+		// Procedure is the RPC or HTTP path the event was recorded for.
+		field.String("procedure").
+			Optional().
+			Immutable(),
+		field.String("user_id").
+			Optional().
+			Immutable(),
+		// ActorID is the admin who acted as the user through impersonation.
+		field.String("actor_id").
+			Optional().
+			Immutable(),
+		// Principal is the service that called over mutual TLS.
+		field.String("principal").
+			Optional().
+			Immutable(),
+		// Email is the address a sign-in was attempted with, for events
+		// that name an account that may not exist.
+		field.String("email").
+			Optional().
+			Immutable(),
+		field.String("ip_address").
+			Optional().
+			Immutable(),
+		field.String("user_agent").
+			Optional().
+			Immutable(),
+		field.Enum("outcome").
+			Values("success", "failure").
+			Immutable(),
+		// Reason explains a failure.
+		field.String("reason").
+			Optional().
+			Immutable(),
+		field.Time("created_at").
+			Default(time.Now).
+			Immutable(),
+	}
+}
+
+// Indexes of the AuthEvent.
+func (AuthEvent) Indexes() []ent.Index {
+	return []ent.Index{
+		index.Fields("created_at", "id"),
+		index.Fields("user_id", "created_at"),
+		index.Fields("ip_address", "created_at"),
+	}
+}
+
+// Hooks of the AuthEvent.
+func (AuthEvent) Hooks() []ent.Hook {
+	return []ent.Hook{
+		func(next ent.Mutator) ent.Mutator {
+			return ent.MutateFunc(func(ctx context.Context, m ent.Mutation) (ent.Value, error) {
+				if !m.Op().Is(ent.OpCreate) {
+					return nil, fmt.Errorf("auth events are append-only")
+				}
+				return next.Mutate(ctx, m)
+			})
+		},
+	}
+}
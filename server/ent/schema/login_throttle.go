@@ -0,0 +1,36 @@
+package schema
+
+import (
+	"entgo.io/ent"
+	"entgo.io/ent/schema/field"
+	"github.com/google/uuid"
+)
+
+// LoginThrottle holds the schema definition for the LoginThrottle entity.
+// It tracks failed login attempts per key, such as an email or a client IP.
+type LoginThrottle struct {
+	ent.Schema
+}
+
+// Fields of the LoginThrottle.
+func (LoginThrottle) Fields() []ent.Field {
+	return []ent.Field{
+		field.String("id").
+			DefaultFunc(func() string {
+				return uuid.New().String()
+			}).
+			Immutable().
+			Unique(),
+		field.String("key").
+			NotEmpty().
+			Immutable().
+			Unique(),
+		field.Int("failure_count").
+			Default(0).
+			NonNegative(),
+		field.Time("last_failure_at"),
+		field.Time("locked_until").
+			Optional().
+			Nillable(),
+	}
+}
@@ -0,0 +1,53 @@
+package schema
+
+import (
+	"time"
+
+	"entgo.io/ent"
+	"entgo.io/ent/schema/edge"
+	"entgo.io/ent/schema/field"
+	"github.com/google/uuid"
+)
+
+// Session holds the schema definition for the Session entity. A session is
+// one login on one device: its ID is the family_id of the refresh tokens
+// rotated within it and the sid claim of the access tokens issued to it.
+type Session struct {
+	ent.Schema
+}
+
+// Fields of the Session.
+func (Session) Fields() []ent.Field {
+	return []ent.Field{
+		field.String("id").
+			DefaultFunc(func() string {
+				return uuid.New().String()
+			}).
+			Immutable().
+			Unique(),
+		field.String("user_agent").
+			Optional(),
+		// IPAddress is where the session was last seen from.
+		field.String("ip_address").
+			Optional(),
+		field.Time("created_at").
+			Default(time.Now).
+			Immutable(),
+		// LastSeenAt is updated whenever the session refreshes its tokens.
+		field.Time("last_seen_at").
+			Default(time.Now),
+		field.Time("revoked_at").
+			Optional().
+			Nillable(),
+	}
+}
+
+// Edges of the Session.
+func (Session) Edges() []ent.Edge {
+	return []ent.Edge{
+		edge.From("user", User.Type).
+			Ref("sessions").
+			Required().
+			Unique(),
+	}
+}
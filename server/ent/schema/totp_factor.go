@@ -0,0 +1,55 @@
+package schema
+
+import (
+	"time"
+
+	"entgo.io/ent"
+	"entgo.io/ent/schema/edge"
+	"entgo.io/ent/schema/field"
+	"github.com/google/uuid"
+)
+
+// TOTPFactor holds the schema definition for the TOTPFactor entity, a
+// user's authenticator app enrollment.
+type TOTPFactor struct {
+	ent.Schema
+}
+
+// Fields of the TOTPFactor.
+func (TOTPFactor) Fields() []ent.Field {
+	return []ent.Field{
+		field.String("id").
+			DefaultFunc(func() string {
+				return uuid.New().String()
+			}).
+			Immutable().
+			Unique(),
+		field.String("secret").
+			NotEmpty().
+			Immutable().
+			Sensitive(),
+		// The enrollment only protects logins once a code has confirmed it.
+		field.Time("confirmed_at").
+			Optional().
+			Nillable(),
+		// The last accepted time step; codes at or before it are replays.
+		field.Int64("last_used_step").
+			Default(0),
+		field.Strings("recovery_code_hashes").
+			Optional().
+			Sensitive(),
+		field.Time("created_at").
+			Default(time.Now).
+			Immutable(),
+	}
+}
+
+// Edges of the TOTPFactor.
+func (TOTPFactor) Edges() []ent.Edge {
+	return []ent.Edge{
+		edge.From("user", User.Type).
+			Ref("totp_factor").
+			Required().
+			Unique(),
+	}
+}
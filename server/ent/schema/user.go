@@ -32,6 +32,32 @@ func (User) Fields() []ent.Field {
 		field.String("password_hash").
 			NotEmpty().
 			Sensitive(),
+		// The profile fields are empty until the user fills them in.
+		field.String("display_name").
+			Default(""),
+		field.String("bio").
+			Default(""),
+		// Locale is a BCP 47 language tag, e.g. "ko-KR".
+		field.String("locale").
+			Default(""),
+		// Timezone is an IANA time zone name, e.g. "Asia/Seoul".
+		field.String("timezone").
+			Default(""),
+		field.String("avatar_url").
+			Default(""),
+		field.Enum("role").
+			Values("user", "admin").
+			Default("user"),
+		// Permissions are granted on top of those of the role.
+		field.Strings("permissions").
+			Optional(),
+		field.Time("email_verified_at").
+			Optional().
+			Nillable(),
+		// Access tokens issued before this time are rejected.
+		field.Time("sessions_revoked_at").
+			Optional().
+			Nillable(),
 		field.Time("created_at").
 			Default(time.Now).
 			Immutable(),
@@ -45,6 +71,13 @@ func (User) Fields() []ent.Field {
 func (User) Edges() []ent.Edge {
 	return []ent.Edge{
 		edge.To("items", Item.Type),
+		edge.To("refresh_tokens", RefreshToken.Type),
+		edge.To("password_reset_tokens", PasswordResetToken.Type),
+		edge.To("totp_factor", TOTPFactor.Type).
+			Unique(),
+		edge.To("identities", Identity.Type),
+		edge.To("api_keys", APIKey.Type),
+		edge.To("sessions", Session.Type),
 	}
 }
 
@@ -0,0 +1,328 @@
+// Code generated by ent, DO NOT EDIT.
+
+package ent
+
+import (
+	"context"
+	"errors"
+	"fmt"
+	"grpc-server/ent/totpfactor"
+	"grpc-server/ent/user"
+	"time"
+
+	"entgo.io/ent/dialect/sql/sqlgraph"
+	"entgo.io/ent/schema/field"
+)
+
+// TOTPFactorCreate is the builder for creating a TOTPFactor entity.
+type TOTPFactorCreate struct {
+	config
+	mutation *TOTPFactorMutation
+	hooks    []Hook
+}
+
+// SetSecret sets the "secret" field.
+func (_c *TOTPFactorCreate) SetSecret(v string) *TOTPFactorCreate {
+	_c.mutation.SetSecret(v)
+	return _c
+}
+
+// SetConfirmedAt sets the "confirmed_at" field.
+func (_c *TOTPFactorCreate) SetConfirmedAt(v time.Time) *TOTPFactorCreate {
+	_c.mutation.SetConfirmedAt(v)
+	return _c
+}
+
+// SetNillableConfirmedAt sets the "confirmed_at" field if the given value is not nil.
+func (_c *TOTPFactorCreate) SetNillableConfirmedAt(v *time.Time) *TOTPFactorCreate {
+	if v != nil {
+		_c.SetConfirmedAt(*v)
+	}
+	return _c
+}
+
+// SetLastUsedStep sets the "last_used_step" field.
+func (_c *TOTPFactorCreate) SetLastUsedStep(v int64) *TOTPFactorCreate {
+	_c.mutation.SetLastUsedStep(v)
+	return _c
+}
+
+// SetNillableLastUsedStep sets the "last_used_step" field if the given value is not nil.
+func (_c *TOTPFactorCreate) SetNillableLastUsedStep(v *int64) *TOTPFactorCreate {
+	if v != nil {
+		_c.SetLastUsedStep(*v)
+	}
+	return _c
+}
+
+// SetRecoveryCodeHashes sets the "recovery_code_hashes" field.
+func (_c *TOTPFactorCreate) SetRecoveryCodeHashes(v []string) *TOTPFactorCreate {
+	_c.mutation.SetRecoveryCodeHashes(v)
+	return _c
+}
+
+// SetCreatedAt sets the "created_at" field.
+func (_c *TOTPFactorCreate) SetCreatedAt(v time.Time) *TOTPFactorCreate {
+	_c.mutation.SetCreatedAt(v)
+	return _c
+}
+
+// SetNillableCreatedAt sets the "created_at" field if the given value is not nil.
+func (_c *TOTPFactorCreate) SetNillableCreatedAt(v *time.Time) *TOTPFactorCreate {
+	if v != nil {
+		_c.SetCreatedAt(*v)
+	}
+	return _c
+}
+
+// SetID sets the "id" field.
+func (_c *TOTPFactorCreate) SetID(v string) *TOTPFactorCreate {
+	_c.mutation.SetID(v)
+	return _c
+}
+
+// SetNillableID sets the "id" field if the given value is not nil.
+func (_c *TOTPFactorCreate) SetNillableID(v *string) *TOTPFactorCreate {
+	if v != nil {
+		_c.SetID(*v)
+	}
+	return _c
+}
+
+// SetUserID sets the "user" edge to the User entity by ID.
+func (_c *TOTPFactorCreate) SetUserID(id string) *TOTPFactorCreate {
+	_c.mutation.SetUserID(id)
+	return _c
+}
+
+// SetUser sets the "user" edge to the User entity.
+func (_c *TOTPFactorCreate) SetUser(v *User) *TOTPFactorCreate {
+	return _c.SetUserID(v.ID)
+}
+
+// Mutation returns the TOTPFactorMutation object of the builder.
+func (_c *TOTPFactorCreate) Mutation() *TOTPFactorMutation {
+	return _c.mutation
+}
+
+// Save creates the TOTPFactor in the database.
+func (_c *TOTPFactorCreate) Save(ctx context.Context) (*TOTPFactor, error) {
+	_c.defaults()
+	return withHooks(ctx, _c.sqlSave, _c.mutation, _c.hooks)
+}
+
+// SaveX calls Save and panics if Save returns an error.
+func (_c *TOTPFactorCreate) SaveX(ctx context.Context) *TOTPFactor {
+	v, err := _c.Save(ctx)
+	if err != nil {
+		panic(err)
+	}
+	return v
+}
+
+// Exec executes the query.
+func (_c *TOTPFactorCreate) Exec(ctx context.Context) error {
+	_, err := _c.Save(ctx)
+	return err
+}
+
+// ExecX is like Exec, but panics if an error occurs.
+func (_c *TOTPFactorCreate) ExecX(ctx context.Context) {
+	if err := _c.Exec(ctx); err != nil {
+		panic(err)
+	}
+}
+
+// defaults sets the default values of the builder before save.
+func (_c *TOTPFactorCreate) defaults() {
+	if _, ok := _c.mutation.LastUsedStep(); !ok {
+		v := totpfactor.DefaultLastUsedStep
+		_c.mutation.SetLastUsedStep(v)
+	}
+	if _, ok := _c.mutation.CreatedAt(); !ok {
+		v := totpfactor.DefaultCreatedAt()
+		_c.mutation.SetCreatedAt(v)
+	}
+	if _, ok := _c.mutation.ID(); !ok {
+		v := totpfactor.DefaultID()
+		_c.mutation.SetID(v)
+	}
+}
+
+// check runs all checks and user-defined validators on the builder.
+func (_c *TOTPFactorCreate) check() error {
+	if _, ok := _c.mutation.Secret(); !ok {
+		return &ValidationError{Name: "secret", err: errors.New(`ent: missing required field "TOTPFactor.secret"`)}
+	}
+	if v, ok := _c.mutation.Secret(); ok {
+		if err := totpfactor.SecretValidator(v); err != nil {
+			return &ValidationError{Name: "secret", err: fmt.Errorf(`ent: validator failed for field "TOTPFactor.secret": %w`, err)}
+		}
+	}
+	if _, ok := _c.mutation.LastUsedStep(); !ok {
+		return &ValidationError{Name: "last_used_step", err: errors.New(`ent: missing required field "TOTPFactor.last_used_step"`)}
+	}
+	if _, ok := _c.mutation.CreatedAt(); !ok {
+		return &ValidationError{Name: "created_at", err: errors.New(`ent: missing required field "TOTPFactor.created_at"`)}
+	}
+	if len(_c.mutation.UserIDs()) == 0 {
+		return &ValidationError{Name: "user", err: errors.New(`ent: missing required edge "TOTPFactor.user"`)}
+	}
+	return nil
+}
+
+func (_c *TOTPFactorCreate) sqlSave(ctx context.Context) (*TOTPFactor, error) {
+	if err := _c.check(); err != nil {
+		return nil, err
+	}
+	_node, _spec := _c.createSpec()
+	if err := sqlgraph.CreateNode(ctx, _c.driver, _spec); err != nil {
+		if sqlgraph.IsConstraintError(err) {
+			err = &ConstraintError{msg: err.Error(), wrap: err}
+		}
+		return nil, err
+	}
+	if _spec.ID.Value != nil {
+		if id, ok := _spec.ID.Value.(string); ok {
+			_node.ID = id
+		} else {
+			return nil, fmt.Errorf("unexpected TOTPFactor.ID type: %T", _spec.ID.Value)
+		}
+	}
+	_c.mutation.id = &_node.ID
+	_c.mutation.done = true
+	return _node, nil
+}
+
+func (_c *TOTPFactorCreate) createSpec() (*TOTPFactor, *sqlgraph.CreateSpec) {
+	var (
+		_node = &TOTPFactor{config: _c.config}
+		_spec = sqlgraph.NewCreateSpec(totpfactor.Table, sqlgraph.NewFieldSpec(totpfactor.FieldID, field.TypeString))
+	)
+	if id, ok := _c.mutation.ID(); ok {
+		_node.ID = id
+		_spec.ID.Value = id
+	}
+	if value, ok := _c.mutation.Secret(); ok {
+		_spec.SetField(totpfactor.FieldSecret, field.TypeString, value)
+		_node.Secret = value
+	}
+	if value, ok := _c.mutation.ConfirmedAt(); ok {
+		_spec.SetField(totpfactor.FieldConfirmedAt, field.TypeTime, value)
+		_node.ConfirmedAt = &value
+	}
+	if value, ok := _c.mutation.LastUsedStep(); ok {
+		_spec.SetField(totpfactor.FieldLastUsedStep, field.TypeInt64, value)
+		_node.LastUsedStep = value
+	}
+	if value, ok := _c.mutation.RecoveryCodeHashes(); ok {
+		_spec.SetField(totpfactor.FieldRecoveryCodeHashes, field.TypeJSON, value)
+		_node.RecoveryCodeHashes = value
+	}
+	if value, ok := _c.mutation.CreatedAt(); ok {
+		_spec.SetField(totpfactor.FieldCreatedAt, field.TypeTime, value)
+		_node.CreatedAt = value
+	}
+	if nodes := _c.mutation.UserIDs(); len(nodes) > 0 {
+		edge := &sqlgraph.EdgeSpec{
+			Rel:     sqlgraph.O2O,
+			Inverse: true,
+			Table:   totpfactor.UserTable,
+			Columns: []string{totpfactor.UserColumn},
+			Bidi:    false,
+			Target: &sqlgraph.EdgeTarget{
+				IDSpec: sqlgraph.NewFieldSpec(user.FieldID, field.TypeString),
+			},
+		}
+		for _, k := range nodes {
+			edge.Target.Nodes = append(edge.Target.Nodes, k)
+		}
+		_node.user_totp_factor = &nodes[0]
+		_spec.Edges = append(_spec.Edges, edge)
+	}
+	return _node, _spec
+}
+
+// TOTPFactorCreateBulk is the builder for creating many TOTPFactor entities in bulk.
+type TOTPFactorCreateBulk struct {
+	config
+	err      error
+	builders []*TOTPFactorCreate
+}
+
+// Save creates the TOTPFactor entities in the database.
+func (_c *TOTPFactorCreateBulk) Save(ctx context.Context) ([]*TOTPFactor, error) {
+	if _c.err != nil {
+		return nil, _c.err
+	}
+	specs := make([]*sqlgraph.CreateSpec, len(_c.builders))
+	nodes := make([]*TOTPFactor, len(_c.builders))
+	mutators := make([]Mutator, len(_c.builders))
+	for i := range _c.builders {
+		func(i int, root context.Context) {
+			builder := _c.builders[i]
+			builder.defaults()
+			var mut Mutator = MutateFunc(func(ctx context.Context, m Mutation) (Value, error) {
+				mutation, ok := m.(*TOTPFactorMutation)
+				if !ok {
+					return nil, fmt.Errorf("unexpected mutation type %T", m)
+				}
+				if err := builder.check(); err != nil {
+					return nil, err
+				}
+				builder.mutation = mutation
+				var err error
+				nodes[i], specs[i] = builder.createSpec()
+				if i < len(mutators)-1 {
+					_, err = mutators[i+1].Mutate(root, _c.builders[i+1].mutation)
+				} else {
+					spec := &sqlgraph.BatchCreateSpec{Nodes: specs}
+					// Invoke the actual operation on the latest mutation in the chain.
+					if err = sqlgraph.BatchCreate(ctx, _c.driver, spec); err != nil {
+						if sqlgraph.IsConstraintError(err) {
+							err = &ConstraintError{msg: err.Error(), wrap: err}
+						}
+					}
+				}
+				if err != nil {
+					return nil, err
+				}
+				mutation.id = &nodes[i].ID
+				mutation.done = true
+				return nodes[i], nil
+			})
+			for i := len(builder.hooks) - 1; i >= 0; i-- {
+				mut = builder.hooks[i](mut)
+			}
+			mutators[i] = mut
+		}(i, ctx)
+	}
+	if len(mutators) > 0 {
+		if _, err := mutators[0].Mutate(ctx, _c.builders[0].mutation); err != nil {
+			return nil, err
+		}
+	}
+	return nodes, nil
+}
+
+// SaveX is like Save, but panics if an error occurs.
+func (_c *TOTPFactorCreateBulk) SaveX(ctx context.Context) []*TOTPFactor {
+	v, err := _c.Save(ctx)
+	if err != nil {
+		panic(err)
+	}
+	return v
+}
+
+// Exec executes the query.
+func (_c *TOTPFactorCreateBulk) Exec(ctx context.Context) error {
+	_, err := _c.Save(ctx)
+	return err
+}
+
+// ExecX is like Exec, but panics if an error occurs.
+func (_c *TOTPFactorCreateBulk) ExecX(ctx context.Context) {
+	if err := _c.Exec(ctx); err != nil {
+		panic(err)
+	}
+}
@@ -0,0 +1,456 @@
+// Code generated by ent, DO NOT EDIT.
+
+package ent
+
+import (
+	"context"
+	"errors"
+	"fmt"
+	"grpc-server/ent/predicate"
+	"grpc-server/ent/totpfactor"
+	"grpc-server/ent/user"
+	"time"
+
+	"entgo.io/ent/dialect/sql"
+	"entgo.io/ent/dialect/sql/sqlgraph"
+	"entgo.io/ent/dialect/sql/sqljson"
+	"entgo.io/ent/schema/field"
+)
+
+// TOTPFactorUpdate is the builder for updating TOTPFactor entities.
+type TOTPFactorUpdate struct {
+	config
+	hooks    []Hook
+	mutation *TOTPFactorMutation
+}
+
+// Where appends a list predicates to the TOTPFactorUpdate builder.
+func (_u *TOTPFactorUpdate) Where(ps ...predicate.TOTPFactor) *TOTPFactorUpdate {
+	_u.mutation.Where(ps...)
+	return _u
+}
+
+// SetConfirmedAt sets the "confirmed_at" field.
+func (_u *TOTPFactorUpdate) SetConfirmedAt(v time.Time) *TOTPFactorUpdate {
+	_u.mutation.SetConfirmedAt(v)
+	return _u
+}
+
+// SetNillableConfirmedAt sets the "confirmed_at" field if the given value is not nil.
+func (_u *TOTPFactorUpdate) SetNillableConfirmedAt(v *time.Time) *TOTPFactorUpdate {
+	if v != nil {
+		_u.SetConfirmedAt(*v)
+	}
+	return _u
+}
+
+// ClearConfirmedAt clears the value of the "confirmed_at" field.
+func (_u *TOTPFactorUpdate) ClearConfirmedAt() *TOTPFactorUpdate {
+	_u.mutation.ClearConfirmedAt()
+	return _u
+}
+
+// SetLastUsedStep sets the "last_used_step" field.
+func (_u *TOTPFactorUpdate) SetLastUsedStep(v int64) *TOTPFactorUpdate {
+	_u.mutation.ResetLastUsedStep()
+	_u.mutation.SetLastUsedStep(v)
+	return _u
+}
+
+// SetNillableLastUsedStep sets the "last_used_step" field if the given value is not nil.
+func (_u *TOTPFactorUpdate) SetNillableLastUsedStep(v *int64) *TOTPFactorUpdate {
+	if v != nil {
+		_u.SetLastUsedStep(*v)
+	}
+	return _u
+}
+
+// AddLastUsedStep adds value to the "last_used_step" field.
+func (_u *TOTPFactorUpdate) AddLastUsedStep(v int64) *TOTPFactorUpdate {
+	_u.mutation.AddLastUsedStep(v)
+	return _u
+}
+
+// SetRecoveryCodeHashes sets the "recovery_code_hashes" field.
+func (_u *TOTPFactorUpdate) SetRecoveryCodeHashes(v []string) *TOTPFactorUpdate {
+	_u.mutation.SetRecoveryCodeHashes(v)
+	return _u
+}
+
+// AppendRecoveryCodeHashes appends value to the "recovery_code_hashes" field.
+func (_u *TOTPFactorUpdate) AppendRecoveryCodeHashes(v []string) *TOTPFactorUpdate {
+	_u.mutation.AppendRecoveryCodeHashes(v)
+	return _u
+}
+
+// ClearRecoveryCodeHashes clears the value of the "recovery_code_hashes" field.
+func (_u *TOTPFactorUpdate) ClearRecoveryCodeHashes() *TOTPFactorUpdate {
+	_u.mutation.ClearRecoveryCodeHashes()
+	return _u
+}
+
+// SetUserID sets the "user" edge to the User entity by ID.
+func (_u *TOTPFactorUpdate) SetUserID(id string) *TOTPFactorUpdate {
+	_u.mutation.SetUserID(id)
+	return _u
+}
+
+// SetUser sets the "user" edge to the User entity.
+func (_u *TOTPFactorUpdate) SetUser(v *User) *TOTPFactorUpdate {
+	return _u.SetUserID(v.ID)
+}
+
+// Mutation returns the TOTPFactorMutation object of the builder.
+func (_u *TOTPFactorUpdate) Mutation() *TOTPFactorMutation {
+	return _u.mutation
+}
+
+// ClearUser clears the "user" edge to the User entity.
+func (_u *TOTPFactorUpdate) ClearUser() *TOTPFactorUpdate {
+	_u.mutation.ClearUser()
+	return _u
+}
+
+// Save executes the query and returns the number of nodes affected by the update operation.
+func (_u *TOTPFactorUpdate) Save(ctx context.Context) (int, error) {
+	return withHooks(ctx, _u.sqlSave, _u.mutation, _u.hooks)
+}
+
+// SaveX is like Save, but panics if an error occurs.
+func (_u *TOTPFactorUpdate) SaveX(ctx context.Context) int {
+	affected, err := _u.Save(ctx)
+	if err != nil {
+		panic(err)
+	}
+	return affected
+}
+
+// Exec executes the query.
+func (_u *TOTPFactorUpdate) Exec(ctx context.Context) error {
+	_, err := _u.Save(ctx)
+	return err
+}
+
+// ExecX is like Exec, but panics if an error occurs.
+func (_u *TOTPFactorUpdate) ExecX(ctx context.Context) {
+	if err := _u.Exec(ctx); err != nil {
+		panic(err)
+	}
+}
+
+// check runs all checks and user-defined validators on the builder.
+func (_u *TOTPFactorUpdate) check() error {
+	if _u.mutation.UserCleared() && len(_u.mutation.UserIDs()) > 0 {
+		return errors.New(`ent: clearing a required unique edge "TOTPFactor.user"`)
+	}
+	return nil
+}
+
+func (_u *TOTPFactorUpdate) sqlSave(ctx context.Context) (_node int, err error) {
+	if err := _u.check(); err != nil {
+		return _node, err
+	}
+	_spec := sqlgraph.NewUpdateSpec(totpfactor.Table, totpfactor.Columns, sqlgraph.NewFieldSpec(totpfactor.FieldID, field.TypeString))
+	if ps := _u.mutation.predicates; len(ps) > 0 {
+		_spec.Predicate = func(selector *sql.Selector) {
+			for i := range ps {
+				ps[i](selector)
+			}
+		}
+	}
+	if value, ok := _u.mutation.ConfirmedAt(); ok {
+		_spec.SetField(totpfactor.FieldConfirmedAt, field.TypeTime, value)
+	}
+	if _u.mutation.ConfirmedAtCleared() {
+		_spec.ClearField(totpfactor.FieldConfirmedAt, field.TypeTime)
+	}
+	if value, ok := _u.mutation.LastUsedStep(); ok {
+		_spec.SetField(totpfactor.FieldLastUsedStep, field.TypeInt64, value)
+	}
+	if value, ok := _u.mutation.AddedLastUsedStep(); ok {
+		_spec.AddField(totpfactor.FieldLastUsedStep, field.TypeInt64, value)
+	}
+	if value, ok := _u.mutation.RecoveryCodeHashes(); ok {
+		_spec.SetField(totpfactor.FieldRecoveryCodeHashes, field.TypeJSON, value)
+	}
+	if value, ok := _u.mutation.AppendedRecoveryCodeHashes(); ok {
+		_spec.AddModifier(func(u *sql.UpdateBuilder) {
+			sqljson.Append(u, totpfactor.FieldRecoveryCodeHashes, value)
+		})
+	}
+	if _u.mutation.RecoveryCodeHashesCleared() {
+		_spec.ClearField(totpfactor.FieldRecoveryCodeHashes, field.TypeJSON)
+	}
+	if _u.mutation.UserCleared() {
+		edge := &sqlgraph.EdgeSpec{
+			Rel:     sqlgraph.O2O,
+			Inverse: true,
+			Table:   totpfactor.UserTable,
+			Columns: []string{totpfactor.UserColumn},
+			Bidi:    false,
+			Target: &sqlgraph.EdgeTarget{
+				IDSpec: sqlgraph.NewFieldSpec(user.FieldID, field.TypeString),
+			},
+		}
+		_spec.Edges.Clear = append(_spec.Edges.Clear, edge)
+	}
+	if nodes := _u.mutation.UserIDs(); len(nodes) > 0 {
+		edge := &sqlgraph.EdgeSpec{
+			Rel:     sqlgraph.O2O,
+			Inverse: true,
+			Table:   totpfactor.UserTable,
+			Columns: []string{totpfactor.UserColumn},
+			Bidi:    false,
+			Target: &sqlgraph.EdgeTarget{
+				IDSpec: sqlgraph.NewFieldSpec(user.FieldID, field.TypeString),
+			},
+		}
+		for _, k := range nodes {
+			edge.Target.Nodes = append(edge.Target.Nodes, k)
+		}
+		_spec.Edges.Add = append(_spec.Edges.Add, edge)
+	}
+	if _node, err = sqlgraph.UpdateNodes(ctx, _u.driver, _spec); err != nil {
+		if _, ok := err.(*sqlgraph.NotFoundError); ok {
+			err = &NotFoundError{totpfactor.Label}
+		} else if sqlgraph.IsConstraintError(err) {
+			err = &ConstraintError{msg: err.Error(), wrap: err}
+		}
+		return 0, err
+	}
+	_u.mutation.done = true
+	return _node, nil
+}
+
+// TOTPFactorUpdateOne is the builder for updating a single TOTPFactor entity.
+type TOTPFactorUpdateOne struct {
+	config
+	fields   []string
+	hooks    []Hook
+	mutation *TOTPFactorMutation
+}
+
+// SetConfirmedAt sets the "confirmed_at" field.
+func (_u *TOTPFactorUpdateOne) SetConfirmedAt(v time.Time) *TOTPFactorUpdateOne {
+	_u.mutation.SetConfirmedAt(v)
+	return _u
+}
+
+// SetNillableConfirmedAt sets the "confirmed_at" field if the given value is not nil.
+func (_u *TOTPFactorUpdateOne) SetNillableConfirmedAt(v *time.Time) *TOTPFactorUpdateOne {
+	if v != nil {
+		_u.SetConfirmedAt(*v)
+	}
+	return _u
+}
+
+// ClearConfirmedAt clears the value of the "confirmed_at" field.
+func (_u *TOTPFactorUpdateOne) ClearConfirmedAt() *TOTPFactorUpdateOne {
+	_u.mutation.ClearConfirmedAt()
+	return _u
+}
+
+// SetLastUsedStep sets the "last_used_step" field.
+func (_u *TOTPFactorUpdateOne) SetLastUsedStep(v int64) *TOTPFactorUpdateOne {
+	_u.mutation.ResetLastUsedStep()
+	_u.mutation.SetLastUsedStep(v)
+	return _u
+}
+
+// SetNillableLastUsedStep sets the "last_used_step" field if the given value is not nil.
+func (_u *TOTPFactorUpdateOne) SetNillableLastUsedStep(v *int64) *TOTPFactorUpdateOne {
+	if v != nil {
+		_u.SetLastUsedStep(*v)
+	}
+	return _u
+}
+
+// AddLastUsedStep adds value to the "last_used_step" field.
+func (_u *TOTPFactorUpdateOne) AddLastUsedStep(v int64) *TOTPFactorUpdateOne {
+	_u.mutation.AddLastUsedStep(v)
+	return _u
+}
+
+// SetRecoveryCodeHashes sets the "recovery_code_hashes" field.
+func (_u *TOTPFactorUpdateOne) SetRecoveryCodeHashes(v []string) *TOTPFactorUpdateOne {
+	_u.mutation.SetRecoveryCodeHashes(v)
+	return _u
+}
+
+// AppendRecoveryCodeHashes appends value to the "recovery_code_hashes" field.
+func (_u *TOTPFactorUpdateOne) AppendRecoveryCodeHashes(v []string) *TOTPFactorUpdateOne {
+	_u.mutation.AppendRecoveryCodeHashes(v)
+	return _u
+}
+
+// ClearRecoveryCodeHashes clears the value of the "recovery_code_hashes" field.
+func (_u *TOTPFactorUpdateOne) ClearRecoveryCodeHashes() *TOTPFactorUpdateOne {
+	_u.mutation.ClearRecoveryCodeHashes()
+	return _u
+}
+
+// SetUserID sets the "user" edge to the User entity by ID.
+func (_u *TOTPFactorUpdateOne) SetUserID(id string) *TOTPFactorUpdateOne {
+	_u.mutation.SetUserID(id)
+	return _u
+}
+
+// SetUser sets the "user" edge to the User entity.
+func (_u *TOTPFactorUpdateOne) SetUser(v *User) *TOTPFactorUpdateOne {
+	return _u.SetUserID(v.ID)
+}
+
+// Mutation returns the TOTPFactorMutation object of the builder.
+func (_u *TOTPFactorUpdateOne) Mutation() *TOTPFactorMutation {
+	return _u.mutation
+}
+
+// ClearUser clears the "user" edge to the User entity.
+func (_u *TOTPFactorUpdateOne) ClearUser() *TOTPFactorUpdateOne {
+	_u.mutation.ClearUser()
+	return _u
+}
+
+// Where appends a list predicates to the TOTPFactorUpdate builder.
+func (_u *TOTPFactorUpdateOne) Where(ps ...predicate.TOTPFactor) *TOTPFactorUpdateOne {
+	_u.mutation.Where(ps...)
+	return _u
+}
+
+// Select allows selecting one or more fields (columns) of the returned entity.
+// The default is selecting all fields defined in the entity schema.
+func (_u *TOTPFactorUpdateOne) Select(field string, fields ...string) *TOTPFactorUpdateOne {
+	_u.fields = append([]string{field}, fields...)
+	return _u
+}
+
+// Save executes the query and returns the updated TOTPFactor entity.
+func (_u *TOTPFactorUpdateOne) Save(ctx context.Context) (*TOTPFactor, error) {
+	return withHooks(ctx, _u.sqlSave, _u.mutation, _u.hooks)
+}
+
+// SaveX is like Save, but panics if an error occurs.
+func (_u *TOTPFactorUpdateOne) SaveX(ctx context.Context) *TOTPFactor {
+	node, err := _u.Save(ctx)
+	if err != nil {
+		panic(err)
+	}
+	return node
+}
+
+// Exec executes the query on the entity.
+func (_u *TOTPFactorUpdateOne) Exec(ctx context.Context) error {
+	_, err := _u.Save(ctx)
+	return err
+}
+
+// ExecX is like Exec, but panics if an error occurs.
+func (_u *TOTPFactorUpdateOne) ExecX(ctx context.Context) {
+	if err := _u.Exec(ctx); err != nil {
+		panic(err)
+	}
+}
+
+// check runs all checks and user-defined validators on the builder.
+func (_u *TOTPFactorUpdateOne) check() error {
+	if _u.mutation.UserCleared() && len(_u.mutation.UserIDs()) > 0 {
+		return errors.New(`ent: clearing a required unique edge "TOTPFactor.user"`)
+	}
+	return nil
+}
+
+func (_u *TOTPFactorUpdateOne) sqlSave(ctx context.Context) (_node *TOTPFactor, err error) {
+	if err := _u.check(); err != nil {
+		return _node, err
+	}
+	_spec := sqlgraph.NewUpdateSpec(totpfactor.Table, totpfactor.Columns, sqlgraph.NewFieldSpec(totpfactor.FieldID, field.TypeString))
+	id, ok := _u.mutation.ID()
+	if !ok {
+		return nil, &ValidationError{Name: "id", err: errors.New(`ent: missing "TOTPFactor.id" for update`)}
+	}
+	_spec.Node.ID.Value = id
+	if fields := _u.fields; len(fields) > 0 {
+		_spec.Node.Columns = make([]string, 0, len(fields))
+		_spec.Node.Columns = append(_spec.Node.Columns, totpfactor.FieldID)
+		for _, f := range fields {
+			if !totpfactor.ValidColumn(f) {
+				return nil, &ValidationError{Name: f, err: fmt.Errorf("ent: invalid field %q for query", f)}
+			}
+			if f != totpfactor.FieldID {
+				_spec.Node.Columns = append(_spec.Node.Columns, f)
+			}
+		}
+	}
+	if ps := _u.mutation.predicates; len(ps) > 0 {
+		_spec.Predicate = func(selector *sql.Selector) {
+			for i := range ps {
+				ps[i](selector)
+			}
+		}
+	}
+	if value, ok := _u.mutation.ConfirmedAt(); ok {
+		_spec.SetField(totpfactor.FieldConfirmedAt, field.TypeTime, value)
+	}
+	if _u.mutation.ConfirmedAtCleared() {
+		_spec.ClearField(totpfactor.FieldConfirmedAt, field.TypeTime)
+	}
+	if value, ok := _u.mutation.LastUsedStep(); ok {
+		_spec.SetField(totpfactor.FieldLastUsedStep, field.TypeInt64, value)
+	}
+	if value, ok := _u.mutation.AddedLastUsedStep(); ok {
+		_spec.AddField(totpfactor.FieldLastUsedStep, field.TypeInt64, value)
+	}
+	if value, ok := _u.mutation.RecoveryCodeHashes(); ok {
+		_spec.SetField(totpfactor.FieldRecoveryCodeHashes, field.TypeJSON, value)
+	}
+	if value, ok := _u.mutation.AppendedRecoveryCodeHashes(); ok {
+		_spec.AddModifier(func(u *sql.UpdateBuilder) {
+			sqljson.Append(u, totpfactor.FieldRecoveryCodeHashes, value)
+		})
+	}
+	if _u.mutation.RecoveryCodeHashesCleared() {
+		_spec.ClearField(totpfactor.FieldRecoveryCodeHashes, field.TypeJSON)
+	}
+	if _u.mutation.UserCleared() {
+		edge := &sqlgraph.EdgeSpec{
+			Rel:     sqlgraph.O2O,
+			Inverse: true,
+			Table:   totpfactor.UserTable,
+			Columns: []string{totpfactor.UserColumn},
+			Bidi:    false,
+			Target: &sqlgraph.EdgeTarget{
+				IDSpec: sqlgraph.NewFieldSpec(user.FieldID, field.TypeString),
+			},
+		}
+		_spec.Edges.Clear = append(_spec.Edges.Clear, edge)
+	}
+	if nodes := _u.mutation.UserIDs(); len(nodes) > 0 {
+		edge := &sqlgraph.EdgeSpec{
+			Rel:     sqlgraph.O2O,
+			Inverse: true,
+			Table:   totpfactor.UserTable,
+			Columns: []string{totpfactor.UserColumn},
+			Bidi:    false,
+			Target: &sqlgraph.EdgeTarget{
+				IDSpec: sqlgraph.NewFieldSpec(user.FieldID, field.TypeString),
+			},
+		}
+		for _, k := range nodes {
+			edge.Target.Nodes = append(edge.Target.Nodes, k)
+		}
+		_spec.Edges.Add = append(_spec.Edges.Add, edge)
+	}
+	_node = &TOTPFactor{config: _u.config}
+	_spec.Assign = _node.assignValues
+	_spec.ScanValues = _node.scanValues
+	if err = sqlgraph.UpdateNode(ctx, _u.driver, _spec); err != nil {
+		if _, ok := err.(*sqlgraph.NotFoundError); ok {
+			err = &NotFoundError{totpfactor.Label}
+		} else if sqlgraph.IsConstraintError(err) {
+			err = &ConstraintError{msg: err.Error(), wrap: err}
+		}
+		return nil, err
+	}
+	_u.mutation.done = true
+	return _node, nil
+}
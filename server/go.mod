@@ -34,5 +34,5 @@ require (
 	golang.org/x/crypto v0.37.0
 	golang.org/x/mod v0.23.0 // indirect
 	golang.org/x/sync v0.13.0 // indirect
-	golang.org/x/text v0.24.0 // indirect
+	golang.org/x/text v0.24.0
 )
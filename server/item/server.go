@@ -2,6 +2,7 @@ package item
 
 import (
 	"context"
+	"errors"
 	"fmt"
 	"net/http"
 	"time"
@@ -17,19 +18,21 @@ import (
 	"google.golang.org/protobuf/types/known/timestamppb"
 )
 
-func Register(db *database.DB, mux *http.ServeMux) {
-	server := NewItemServer(db)
-	path, handler := itemconnect.NewItemServiceHandler(server)
+func Register(db *database.DB, mux *http.ServeMux, authenticator *auth.Authenticator) {
+	server := NewItemServer(db, authenticator)
+	path, handler := itemconnect.NewItemServiceHandler(server, connect.WithInterceptors(authenticator.Interceptor()))
 	mux.Handle(path, handler)
 }
 
 type Server struct {
-	db *database.DB
+	db            *database.DB
+	authenticator *auth.Authenticator
 }
 
-func NewItemServer(db *database.DB) *Server {
+func NewItemServer(db *database.DB, authenticator *auth.Authenticator) *Server {
 	return &Server{
-		db: db,
+		db:            db,
+		authenticator: authenticator,
 	}
 }
 
@@ -43,6 +46,10 @@ func (s *Server) CreateItem(
 		return nil, connect.NewError(connect.CodeUnauthenticated, fmt.Errorf("authentication required"))
 	}
 
+	if err := s.requireVerifiedEmail(ctx, userID); err != nil {
+		return nil, err
+	}
+
 	status := req.Msg.Status
 	if status == itemv1.ItemStatus_ITEM_STATUS_UNSPECIFIED {
 		status = itemv1.ItemStatus_ITEM_STATUS_DRAFT
@@ -125,6 +132,10 @@ func (s *Server) UpdateItem(
 		return nil, connect.NewError(connect.CodeUnauthenticated, fmt.Errorf("authentication required"))
 	}
 
+	if err := s.requireVerifiedEmail(ctx, userID); err != nil {
+		return nil, err
+	}
+
 	// Verify the item belongs to the user
 	existingItem, err := s.db.Client.Item.
 		Query().
@@ -139,9 +150,9 @@ func (s *Server) UpdateItem(
 		return nil, connect.NewError(connect.CodeInternal, fmt.Errorf("failed to get item: %w", err))
 	}
 
-	// Check if the item belongs to the authenticated user
-	if existingItem.Edges.User == nil || existingItem.Edges.User.ID != userID {
-		return nil, connect.NewError(connect.CodePermissionDenied, fmt.Errorf("you don't have permission to update this item"))
+	// Only the owner, or an admin, may update the item
+	if err := auth.AuthorizeOwner(ctx, ownerID(existingItem), auth.PermissionManageAnyItem); err != nil {
+		return nil, auth.AuthorizationError(err)
 	}
 
 	update := s.db.Client.Item.
@@ -182,6 +193,10 @@ func (s *Server) DeleteItem(
 		return nil, connect.NewError(connect.CodeUnauthenticated, fmt.Errorf("authentication required"))
 	}
 
+	if err := s.requireVerifiedEmail(ctx, userID); err != nil {
+		return nil, err
+	}
+
 	// Verify the item belongs to the user
 	existingItem, err := s.db.Client.Item.
 		Query().
@@ -196,9 +211,9 @@ func (s *Server) DeleteItem(
 		return nil, connect.NewError(connect.CodeInternal, fmt.Errorf("failed to get item: %w", err))
 	}
 
-	// Check if the item belongs to the authenticated user
-	if existingItem.Edges.User == nil || existingItem.Edges.User.ID != userID {
-		return nil, connect.NewError(connect.CodePermissionDenied, fmt.Errorf("you don't have permission to delete this item"))
+	// Only the owner, or an admin, may delete the item
+	if err := auth.AuthorizeOwner(ctx, ownerID(existingItem), auth.PermissionManageAnyItem); err != nil {
+		return nil, auth.AuthorizationError(err)
 	}
 
 	err = s.db.Client.Item.
@@ -250,6 +265,27 @@ func (s *Server) WatchItems(
 	}
 }
 
+// requireVerifiedEmail keeps users with an unverified email address from
+// writing items when the deployment requires verification.
+func (s *Server) requireVerifiedEmail(ctx context.Context, userID string) error {
+	if err := s.authenticator.RequireVerifiedEmail(ctx, userID); err != nil {
+		if errors.Is(err, auth.ErrEmailNotVerified) {
+			return connect.NewError(connect.CodePermissionDenied, err)
+		}
+		return connect.NewError(connect.CodeInternal, err)
+	}
+	return nil
+}
+
+// ownerID returns the ID of the user who owns entItem, which must have been
+// loaded with its user edge.
+func ownerID(entItem *ent.Item) string {
+	if entItem.Edges.User == nil {
+		return ""
+	}
+	return entItem.Edges.User.ID
+}
+
 func entItemToProto(entItem *ent.Item) *itemv1.Item {
 	userID := ""
 	if entItem.Edges.User != nil {
@@ -1,11 +1,21 @@
 package main
 
 import (
+	"context"
+	"crypto/tls"
+	"fmt"
 	"log"
 	"net/http"
+	"os"
+	"strconv"
+	"strings"
+	// Profile time zones are validated against the embedded database, so
+	// they don't depend on the host having one.
+	_ "time/tzdata"
 
 	"grpc-server/auth"
 	"grpc-server/database"
+	"grpc-server/mail"
 	"grpc-server/registry"
 
 	"github.com/rs/cors"
@@ -22,31 +32,419 @@ func main() {
 	}
 	defer db.Close()
 
+	ctx, cancel := context.WithCancel(context.Background())
+	defer cancel()
+
+	authConfig, err := loadAuthConfig()
+	if err != nil {
+		log.Fatalf("Failed to load auth config: %v", err)
+	}
+
+	authenticator := auth.NewAuthenticator(db, authConfig)
+
+	if err := db.NormalizeEmails(ctx, authenticator.EmailRulesFingerprint(), authenticator.NormalizeEmail); err != nil {
+		log.Fatalf("Failed to migrate email addresses: %v", err)
+	}
+	authenticator.Start(ctx)
+
 	mux := http.NewServeMux()
 
 	// Register all domain handlers
-	registry.RegisterAll(db, mux)
+	registry.RegisterAll(db, mux, authenticator)
 
 	// Apply authentication middleware
-	authHandler := auth.Middleware(mux)
+	authHandler := authenticator.Middleware(mux)
 
+	// Browsers only send session cookies cross-origin when credentials are
+	// allowed, which in turn rules out a wildcard origin.
 	corsHandler := cors.New(cors.Options{
-		AllowedOrigins: []string{"http://localhost:5173"},
-		AllowedMethods: []string{"GET", "POST", "PUT", "DELETE", "OPTIONS"},
+		AllowedOrigins:   loadAllowedOrigins(),
+		AllowCredentials: authConfig.Cookies.Enabled,
+		AllowedMethods:   []string{"GET", "POST", "PUT", "DELETE", "OPTIONS"},
 		AllowedHeaders: []string{
 			"*",
 			"Connect-Protocol-Version",
 			"Content-Type",
 			"Authorization",
+			auth.CSRFHeader,
 		},
 		ExposedHeaders: []string{
 			"Connect-Protocol-Version",
+			"Retry-After",
 		},
 	}).Handler(authHandler)
 
+	tlsConfig, err := loadTLSConfig()
+	if err != nil {
+		log.Fatalf("Failed to load TLS config: %v", err)
+	}
+
 	addr := ":8080"
+	if tlsConfig != nil {
+		server := &http.Server{Addr: addr, Handler: corsHandler, TLSConfig: tlsConfig}
+		log.Printf("Server listening on %s (TLS)", addr)
+		if err := server.ListenAndServeTLS("", ""); err != nil {
+			log.Fatal(err)
+		}
+		return
+	}
+
 	log.Printf("Server listening on %s", addr)
 	if err := http.ListenAndServe(addr, h2c.NewHandler(corsHandler, &http2.Server{})); err != nil {
 		log.Fatal(err)
 	}
 }
+
+// loadTLSConfig serves TLS when TLS_CERT_FILE and TLS_KEY_FILE are set, and
+// also verifies client certificates against TLS_CLIENT_CA_FILE when that is
+// set. Without a certificate the server speaks plain HTTP/2 (h2c).
+func loadTLSConfig() (*tls.Config, error) {
+	certFile, keyFile := os.Getenv("TLS_CERT_FILE"), os.Getenv("TLS_KEY_FILE")
+	caFile := os.Getenv("TLS_CLIENT_CA_FILE")
+	if certFile == "" && keyFile == "" {
+		if caFile != "" {
+			return nil, fmt.Errorf("TLS_CLIENT_CA_FILE requires TLS_CERT_FILE and TLS_KEY_FILE")
+		}
+		return nil, nil
+	}
+
+	cert, err := tls.LoadX509KeyPair(certFile, keyFile)
+	if err != nil {
+		return nil, fmt.Errorf("failed to load server certificate: %w", err)
+	}
+	if caFile == "" {
+		return &tls.Config{Certificates: []tls.Certificate{cert}, MinVersion: tls.VersionTLS12}, nil
+	}
+
+	clientCAs, err := auth.LoadCertPool(caFile)
+	if err != nil {
+		return nil, err
+	}
+	return auth.MutualTLSConfig(cert, clientCAs), nil
+}
+
+// loadAuthConfig builds the auth configuration from the environment.
+func loadAuthConfig() (auth.Config, error) {
+	keys, err := loadSigningKeys()
+	if err != nil {
+		return auth.Config{}, fmt.Errorf("failed to load signing keys: %w", err)
+	}
+
+	requireVerifiedEmail := false
+	if v := os.Getenv("REQUIRE_VERIFIED_EMAIL"); v != "" {
+		requireVerifiedEmail, err = strconv.ParseBool(v)
+		if err != nil {
+			return auth.Config{}, fmt.Errorf("invalid REQUIRE_VERIFIED_EMAIL: %w", err)
+		}
+	}
+
+	magicLinkSignup := false
+	if v := os.Getenv("MAGIC_LINK_SIGNUP"); v != "" {
+		magicLinkSignup, err = strconv.ParseBool(v)
+		if err != nil {
+			return auth.Config{}, fmt.Errorf("invalid MAGIC_LINK_SIGNUP: %w", err)
+		}
+	}
+
+	var emailRules map[string]auth.EmailRule
+	if v := os.Getenv("EMAIL_PROVIDER_RULES"); v != "" {
+		enabled, err := strconv.ParseBool(v)
+		if err != nil {
+			return auth.Config{}, fmt.Errorf("invalid EMAIL_PROVIDER_RULES: %w", err)
+		}
+		if enabled {
+			emailRules = auth.DefaultEmailRules
+		}
+	}
+
+	passwords, err := loadPasswordHasher()
+	if err != nil {
+		return auth.Config{}, fmt.Errorf("failed to configure password hashing: %w", err)
+	}
+
+	policy, err := loadPasswordPolicy()
+	if err != nil {
+		return auth.Config{}, fmt.Errorf("failed to configure password policy: %w", err)
+	}
+
+	cookies, err := loadCookieConfig()
+	if err != nil {
+		return auth.Config{}, fmt.Errorf("failed to configure session cookies: %w", err)
+	}
+
+	servicePrincipals, err := parseServicePrincipals(os.Getenv("SERVICE_PRINCIPALS"), os.Getenv("SERVICE_PRINCIPAL_OWNERS"))
+	if err != nil {
+		return auth.Config{}, fmt.Errorf("invalid SERVICE_PRINCIPALS: %w", err)
+	}
+
+	return auth.Config{
+		Keys:                 keys,
+		Passwords:            passwords,
+		PasswordPolicy:       policy,
+		EmailRules:           emailRules,
+		Mailer:               loadMailer(),
+		AppURL:               getEnv("APP_URL", "http://localhost:5173"),
+		RequireVerifiedEmail: requireVerifiedEmail,
+		MagicLinkSignup:      magicLinkSignup,
+		Cookies:              cookies,
+		ServicePrincipals:    servicePrincipals,
+		OIDCProviders:        loadOIDCProviders(),
+	}, nil
+}
+
+// loadCookieConfig reads whether browser sessions are kept in cookies.
+// Cookies are Secure unless COOKIE_SECURE turns that off for local HTTP.
+func loadCookieConfig() (auth.CookieConfig, error) {
+	config := auth.CookieConfig{
+		Secure:   true,
+		Domain:   os.Getenv("COOKIE_DOMAIN"),
+		SameSite: http.SameSiteLaxMode,
+	}
+
+	var err error
+	if v := os.Getenv("AUTH_COOKIES"); v != "" {
+		if config.Enabled, err = strconv.ParseBool(v); err != nil {
+			return auth.CookieConfig{}, fmt.Errorf("invalid AUTH_COOKIES: %w", err)
+		}
+	}
+	if v := os.Getenv("COOKIE_SECURE"); v != "" {
+		if config.Secure, err = strconv.ParseBool(v); err != nil {
+			return auth.CookieConfig{}, fmt.Errorf("invalid COOKIE_SECURE: %w", err)
+		}
+	}
+	switch v := os.Getenv("COOKIE_SAMESITE"); strings.ToLower(v) {
+	case "", "lax":
+	case "strict":
+		config.SameSite = http.SameSiteStrictMode
+	case "none":
+		if !config.Secure {
+			return auth.CookieConfig{}, fmt.Errorf("COOKIE_SAMESITE=none requires secure cookies")
+		}
+		config.SameSite = http.SameSiteNoneMode
+	default:
+		return auth.CookieConfig{}, fmt.Errorf("invalid COOKIE_SAMESITE %q", v)
+	}
+
+	return config, nil
+}
+
+// parseServicePrincipals reads the services allowed to call with a client
+// certificate, as "id=scope,scope" entries separated by semicolons, e.g.
+// "spiffe://example.org/sa/reporter=items:read;indexer.internal=items:read".
+// owners assigns services their owners in the same format, as "id=user-id".
+func parseServicePrincipals(s, owners string) ([]auth.ServicePrincipal, error) {
+	ownerEntries, err := parseEntries(owners)
+	if err != nil {
+		return nil, fmt.Errorf("SERVICE_PRINCIPAL_OWNERS: %w", err)
+	}
+	ownerOf := make(map[string]string, len(ownerEntries))
+	for _, entry := range ownerEntries {
+		ownerOf[entry[0]] = entry[1]
+	}
+
+	entries, err := parseEntries(s)
+	if err != nil {
+		return nil, err
+	}
+	var principals []auth.ServicePrincipal
+	for _, entry := range entries {
+		principal := auth.ServicePrincipal{ID: entry[0], Owner: ownerOf[entry[0]]}
+		delete(ownerOf, entry[0])
+		for _, scope := range strings.Split(entry[1], ",") {
+			if scope = strings.TrimSpace(scope); scope != "" {
+				principal.Scopes = append(principal.Scopes, scope)
+			}
+		}
+		if err := principal.Validate(); err != nil {
+			return nil, fmt.Errorf("%s: %w", principal.ID, err)
+		}
+		principals = append(principals, principal)
+	}
+	for id := range ownerOf {
+		return nil, fmt.Errorf("SERVICE_PRINCIPAL_OWNERS: %s is not a service principal", id)
+	}
+	return principals, nil
+}
+
+// parseEntries splits "id=value" entries separated by semicolons into
+// pairs.
+func parseEntries(s string) ([][2]string, error) {
+	var entries [][2]string
+	for _, entry := range strings.Split(s, ";") {
+		entry = strings.TrimSpace(entry)
+		if entry == "" {
+			continue
+		}
+		i := strings.LastIndex(entry, "=")
+		if i <= 0 {
+			return nil, fmt.Errorf("entry %q must be id=value", entry)
+		}
+		entries = append(entries, [2]string{strings.TrimSpace(entry[:i]), strings.TrimSpace(entry[i+1:])})
+	}
+	return entries, nil
+}
+
+// loadAllowedOrigins reads the comma-separated origins of the web clients
+// allowed to call the API from a browser.
+func loadAllowedOrigins() []string {
+	var origins []string
+	for _, origin := range strings.Split(getEnv("CORS_ALLOWED_ORIGINS", "http://localhost:5173"), ",") {
+		if origin = strings.TrimSpace(origin); origin != "" {
+			origins = append(origins, origin)
+		}
+	}
+	return origins
+}
+
+// loadPasswordHasher picks the algorithm new password hashes are made with.
+// Existing hashes keep working and are upgraded when their users sign in.
+func loadPasswordHasher() (*auth.PasswordHasher, error) {
+	config := auth.PasswordConfig{
+		Algorithm: auth.PasswordAlgorithm(getEnv("PASSWORD_HASH", string(auth.PasswordAlgorithmArgon2id))),
+		Argon2:    auth.DefaultArgon2Params,
+	}
+
+	var err error
+	if v := os.Getenv("ARGON2_MEMORY_KIB"); v != "" {
+		config.Argon2.Memory, err = parseUint32(v)
+		if err != nil {
+			return nil, fmt.Errorf("invalid ARGON2_MEMORY_KIB: %w", err)
+		}
+	}
+	if v := os.Getenv("ARGON2_ITERATIONS"); v != "" {
+		config.Argon2.Iterations, err = parseUint32(v)
+		if err != nil {
+			return nil, fmt.Errorf("invalid ARGON2_ITERATIONS: %w", err)
+		}
+	}
+	if v := os.Getenv("ARGON2_PARALLELISM"); v != "" {
+		parallelism, err := strconv.ParseUint(v, 10, 8)
+		if err != nil {
+			return nil, fmt.Errorf("invalid ARGON2_PARALLELISM: %w", err)
+		}
+		config.Argon2.Parallelism = uint8(parallelism)
+	}
+	if v := os.Getenv("BCRYPT_COST"); v != "" {
+		config.BcryptCost, err = strconv.Atoi(v)
+		if err != nil {
+			return nil, fmt.Errorf("invalid BCRYPT_COST: %w", err)
+		}
+	}
+
+	return auth.NewPasswordHasher(config)
+}
+
+// loadPasswordPolicy tightens the default password policy from the
+// environment.
+func loadPasswordPolicy() (*auth.PasswordPolicy, error) {
+	policy := auth.DefaultPasswordPolicy()
+
+	if v := os.Getenv("PASSWORD_MIN_LENGTH"); v != "" {
+		minLength, err := strconv.Atoi(v)
+		if err != nil {
+			return nil, fmt.Errorf("invalid PASSWORD_MIN_LENGTH: %w", err)
+		}
+		// Login rejects anything shorter than the default, so the minimum
+		// can only be raised.
+		if minLength < policy.MinLength || minLength > policy.MaxLength {
+			return nil, fmt.Errorf("PASSWORD_MIN_LENGTH must be between %d and %d", policy.MinLength, policy.MaxLength)
+		}
+		policy.MinLength = minLength
+	}
+	if v := os.Getenv("PASSWORD_MAX_REPEATED"); v != "" {
+		maxRepeated, err := strconv.Atoi(v)
+		if err != nil || maxRepeated < 0 {
+			return nil, fmt.Errorf("invalid PASSWORD_MAX_REPEATED %q", v)
+		}
+		policy.MaxRepeated = maxRepeated
+	}
+	if v := os.Getenv("PASSWORD_REQUIRE"); v != "" {
+		for _, class := range strings.Split(v, ",") {
+			switch strings.TrimSpace(class) {
+			case "upper":
+				policy.RequireUpper = true
+			case "lower":
+				policy.RequireLower = true
+			case "digit":
+				policy.RequireDigit = true
+			case "symbol":
+				policy.RequireSymbol = true
+			default:
+				return nil, fmt.Errorf("unknown character class %q in PASSWORD_REQUIRE", class)
+			}
+		}
+	}
+
+	return &policy, nil
+}
+
+func parseUint32(s string) (uint32, error) {
+	v, err := strconv.ParseUint(s, 10, 32)
+	return uint32(v), err
+}
+
+// loadOIDCProviders enables federated login when OIDC_ISSUER is set.
+func loadOIDCProviders() []auth.OIDCProviderConfig {
+	issuer := os.Getenv("OIDC_ISSUER")
+	if issuer == "" {
+		return nil
+	}
+
+	name := getEnv("OIDC_PROVIDER_NAME", "oidc")
+	return []auth.OIDCProviderConfig{{
+		Name:         name,
+		Issuer:       issuer,
+		ClientID:     os.Getenv("OIDC_CLIENT_ID"),
+		ClientSecret: os.Getenv("OIDC_CLIENT_SECRET"),
+		RedirectURL:  getEnv("OIDC_REDIRECT_URL", "http://localhost:8080"+auth.OIDCPathPrefix+name+"/callback"),
+	}}
+}
+
+// loadMailer sends mail through SMTP_ADDR when it is set and otherwise
+// writes it to MAIL_DIR for local development.
+func loadMailer() mail.Mailer {
+	from := getEnv("MAIL_FROM", "no-reply@localhost")
+	if addr := os.Getenv("SMTP_ADDR"); addr != "" {
+		return &mail.SMTPMailer{
+			Addr:     addr,
+			From:     from,
+			Username: os.Getenv("SMTP_USERNAME"),
+			Password: os.Getenv("SMTP_PASSWORD"),
+		}
+	}
+
+	dir := getEnv("MAIL_DIR", "mail-outbox")
+	log.Printf("SMTP_ADDR is not set, writing outgoing mail to %s", dir)
+	return &mail.FileMailer{Dir: dir, From: from}
+}
+
+func getEnv(key, fallback string) string {
+	if v := os.Getenv(key); v != "" {
+		return v
+	}
+	return fallback
+}
+
+// loadSigningKeys loads the JWT keys from JWT_KEYS_DIR. An ephemeral key
+// signs out every user on restart and cannot be shared between instances, so
+// it is only used when DEV_EPHEMERAL_KEYS opts into it for local development.
+func loadSigningKeys() (auth.KeyManager, error) {
+	if dir := os.Getenv("JWT_KEYS_DIR"); dir != "" {
+		return auth.LoadKeySet(dir, os.Getenv("JWT_ACTIVE_KEY_ID"))
+	}
+
+	ephemeral := false
+	if v := os.Getenv("DEV_EPHEMERAL_KEYS"); v != "" {
+		var err error
+		ephemeral, err = strconv.ParseBool(v)
+		if err != nil {
+			return nil, fmt.Errorf("invalid DEV_EPHEMERAL_KEYS: %w", err)
+		}
+	}
+	if !ephemeral {
+		return nil, fmt.Errorf("JWT_KEYS_DIR is not set; set DEV_EPHEMERAL_KEYS=true to sign with an ephemeral key in development")
+	}
+
+	log.Println("DEV_EPHEMERAL_KEYS is set, signing tokens with an ephemeral key")
+	return auth.GenerateKeySet()
+}
@@ -0,0 +1,82 @@
+package otp
+
+import (
+	"encoding/base32"
+	"strings"
+	"testing"
+	"time"
+)
+
+// rfcSecret is the SHA1 test key from RFC 6238 appendix B.
+var rfcSecret = base32.StdEncoding.WithPadding(base32.NoPadding).EncodeToString([]byte("12345678901234567890"))
+
+func TestCode(t *testing.T) {
+	// RFC 6238 lists 8-digit codes; these are their last 6 digits.
+	tests := []struct {
+		unix int64
+		want string
+	}{
+		{unix: 59, want: "287082"},
+		{unix: 1111111109, want: "081804"},
+		{unix: 1111111111, want: "050471"},
+		{unix: 1234567890, want: "005924"},
+		{unix: 2000000000, want: "279037"},
+	}
+
+	for _, tt := range tests {
+		got, err := Code(rfcSecret, Step(time.Unix(tt.unix, 0)))
+		if err != nil {
+			t.Fatalf("Code() error = %v", err)
+		}
+		if got != tt.want {
+			t.Errorf("Code(T=%d) = %v, want %v", tt.unix, got, tt.want)
+		}
+	}
+}
+
+func TestValidate(t *testing.T) {
+	now := time.Unix(1111111111, 0)
+	code, err := Code(rfcSecret, Step(now))
+	if err != nil {
+		t.Fatalf("Code() error = %v", err)
+	}
+
+	step, ok := Validate(rfcSecret, code, now, 1)
+	if !ok || step != Step(now) {
+		t.Errorf("Validate() = %v, %v, want %v, true", step, ok, Step(now))
+	}
+
+	if _, ok := Validate(rfcSecret, code, now.Add(Period), 1); !ok {
+		t.Error("Expected code from the previous step to be accepted with skew 1")
+	}
+
+	if _, ok := Validate(rfcSecret, code, now.Add(3*Period), 1); ok {
+		t.Error("Expected code from three steps ago to be rejected")
+	}
+
+	if _, ok := Validate(rfcSecret, "12345", now, 1); ok {
+		t.Error("Expected code of the wrong length to be rejected")
+	}
+}
+
+func TestGenerateSecret(t *testing.T) {
+	secret, err := GenerateSecret()
+	if err != nil {
+		t.Fatalf("GenerateSecret() error = %v", err)
+	}
+
+	if _, err := Code(secret, 1); err != nil {
+		t.Errorf("Expected generated secret to be usable, got %v", err)
+	}
+}
+
+func TestURI(t *testing.T) {
+	uri := URI("grpc-server", "user@example.com", "SECRET")
+
+	if !strings.HasPrefix(uri, "otpauth://totp/grpc-server:user@example.com?") {
+		t.Errorf("Unexpected URI prefix: %v", uri)
+	}
+	if !strings.Contains(uri, "secret=SECRET") || !strings.Contains(uri, "issuer=grpc-server") {
+		t.Errorf("Expected secret and issuer in URI: %v", uri)
+	}
+}
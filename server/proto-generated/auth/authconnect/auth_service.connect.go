@@ -42,6 +42,48 @@ const (
 	// AuthServiceRefreshTokenProcedure is the fully-qualified name of the AuthService's RefreshToken
 	// RPC.
 	AuthServiceRefreshTokenProcedure = "/auth.AuthService/RefreshToken"
+	// AuthServiceSendVerificationEmailProcedure is the fully-qualified name of the AuthService's
+	// SendVerificationEmail RPC.
+	AuthServiceSendVerificationEmailProcedure = "/auth.AuthService/SendVerificationEmail"
+	// AuthServiceVerifyEmailProcedure is the fully-qualified name of the AuthService's VerifyEmail RPC.
+	AuthServiceVerifyEmailProcedure = "/auth.AuthService/VerifyEmail"
+	// AuthServiceRequestPasswordResetProcedure is the fully-qualified name of the AuthService's
+	// RequestPasswordReset RPC.
+	AuthServiceRequestPasswordResetProcedure = "/auth.AuthService/RequestPasswordReset"
+	// AuthServiceResetPasswordProcedure is the fully-qualified name of the AuthService's ResetPassword
+	// RPC.
+	AuthServiceResetPasswordProcedure = "/auth.AuthService/ResetPassword"
+	// AuthServiceChangePasswordProcedure is the fully-qualified name of the AuthService's
+	// ChangePassword RPC.
+	AuthServiceChangePasswordProcedure = "/auth.AuthService/ChangePassword"
+	// AuthServiceEnrollTOTPProcedure is the fully-qualified name of the AuthService's EnrollTOTP RPC.
+	AuthServiceEnrollTOTPProcedure = "/auth.AuthService/EnrollTOTP"
+	// AuthServiceConfirmTOTPProcedure is the fully-qualified name of the AuthService's ConfirmTOTP RPC.
+	AuthServiceConfirmTOTPProcedure = "/auth.AuthService/ConfirmTOTP"
+	// AuthServiceDisableTOTPProcedure is the fully-qualified name of the AuthService's DisableTOTP RPC.
+	AuthServiceDisableTOTPProcedure = "/auth.AuthService/DisableTOTP"
+	// AuthServiceVerifyMFAProcedure is the fully-qualified name of the AuthService's VerifyMFA RPC.
+	AuthServiceVerifyMFAProcedure = "/auth.AuthService/VerifyMFA"
+	// AuthServiceListSessionsProcedure is the fully-qualified name of the AuthService's ListSessions
+	// RPC.
+	AuthServiceListSessionsProcedure = "/auth.AuthService/ListSessions"
+	// AuthServiceRevokeSessionProcedure is the fully-qualified name of the AuthService's RevokeSession
+	// RPC.
+	AuthServiceRevokeSessionProcedure = "/auth.AuthService/RevokeSession"
+	// AuthServiceRevokeAllOtherSessionsProcedure is the fully-qualified name of the AuthService's
+	// RevokeAllOtherSessions RPC.
+	AuthServiceRevokeAllOtherSessionsProcedure = "/auth.AuthService/RevokeAllOtherSessions"
+	// AuthServiceListAuthEventsProcedure is the fully-qualified name of the AuthService's
+	// ListAuthEvents RPC.
+	AuthServiceListAuthEventsProcedure = "/auth.AuthService/ListAuthEvents"
+	// AuthServiceImpersonateProcedure is the fully-qualified name of the AuthService's Impersonate RPC.
+	AuthServiceImpersonateProcedure = "/auth.AuthService/Impersonate"
+	// AuthServiceRequestMagicLinkProcedure is the fully-qualified name of the AuthService's
+	// RequestMagicLink RPC.
+	AuthServiceRequestMagicLinkProcedure = "/auth.AuthService/RequestMagicLink"
+	// AuthServiceConsumeMagicLinkProcedure is the fully-qualified name of the AuthService's
+	// ConsumeMagicLink RPC.
+	AuthServiceConsumeMagicLinkProcedure = "/auth.AuthService/ConsumeMagicLink"
 )
 
 // AuthServiceClient is a client for the auth.AuthService service.
@@ -50,6 +92,45 @@ type AuthServiceClient interface {
 	Login(context.Context, *connect.Request[auth.LoginRequest]) (*connect.Response[auth.LoginResponse], error)
 	Logout(context.Context, *connect.Request[auth.LogoutRequest]) (*connect.Response[auth.LogoutResponse], error)
 	RefreshToken(context.Context, *connect.Request[auth.RefreshTokenRequest]) (*connect.Response[auth.RefreshTokenResponse], error)
+	// SendVerificationEmail emails the authenticated user a link to confirm their address.
+	SendVerificationEmail(context.Context, *connect.Request[auth.SendVerificationEmailRequest]) (*connect.Response[auth.SendVerificationEmailResponse], error)
+	// VerifyEmail consumes the token from a verification link.
+	VerifyEmail(context.Context, *connect.Request[auth.VerifyEmailRequest]) (*connect.Response[auth.VerifyEmailResponse], error)
+	// RequestPasswordReset emails a reset link if the address is registered.
+	// The response is the same either way.
+	RequestPasswordReset(context.Context, *connect.Request[auth.RequestPasswordResetRequest]) (*connect.Response[auth.RequestPasswordResetResponse], error)
+	// ResetPassword sets a new password and signs the user out everywhere.
+	ResetPassword(context.Context, *connect.Request[auth.ResetPasswordRequest]) (*connect.Response[auth.ResetPasswordResponse], error)
+	// ChangePassword replaces the authenticated user's password after
+	// re-checking the current one.
+	ChangePassword(context.Context, *connect.Request[auth.ChangePasswordRequest]) (*connect.Response[auth.ChangePasswordResponse], error)
+	// EnrollTOTP starts setting up an authenticator app for the authenticated user.
+	EnrollTOTP(context.Context, *connect.Request[auth.EnrollTOTPRequest]) (*connect.Response[auth.EnrollTOTPResponse], error)
+	// ConfirmTOTP enables MFA once the app produces a valid code.
+	ConfirmTOTP(context.Context, *connect.Request[auth.ConfirmTOTPRequest]) (*connect.Response[auth.ConfirmTOTPResponse], error)
+	// DisableTOTP turns MFA off after checking a TOTP or recovery code.
+	DisableTOTP(context.Context, *connect.Request[auth.DisableTOTPRequest]) (*connect.Response[auth.DisableTOTPResponse], error)
+	// VerifyMFA exchanges the challenge token returned by Login for tokens.
+	VerifyMFA(context.Context, *connect.Request[auth.VerifyMFARequest]) (*connect.Response[auth.VerifyMFAResponse], error)
+	// ListSessions returns the devices the authenticated user is signed in on.
+	ListSessions(context.Context, *connect.Request[auth.ListSessionsRequest]) (*connect.Response[auth.ListSessionsResponse], error)
+	// RevokeSession signs one of the authenticated user's sessions out.
+	RevokeSession(context.Context, *connect.Request[auth.RevokeSessionRequest]) (*connect.Response[auth.RevokeSessionResponse], error)
+	// RevokeAllOtherSessions signs out every session but the caller's, which
+	// continues with the returned tokens.
+	RevokeAllOtherSessions(context.Context, *connect.Request[auth.RevokeAllOtherSessionsRequest]) (*connect.Response[auth.RevokeAllOtherSessionsResponse], error)
+	// ListAuthEvents searches the authentication audit log, newest first.
+	ListAuthEvents(context.Context, *connect.Request[auth.ListAuthEventsRequest]) (*connect.Response[auth.ListAuthEventsResponse], error)
+	// Impersonate issues a short-lived access token that acts as another
+	// user, for reproducing their issues. It can't be refreshed and carries
+	// the admin's ID in its act claim.
+	Impersonate(context.Context, *connect.Request[auth.ImpersonateRequest]) (*connect.Response[auth.ImpersonateResponse], error)
+	// RequestMagicLink emails a single-use sign-in link. The response is the
+	// same whether or not the address is registered.
+	RequestMagicLink(context.Context, *connect.Request[auth.RequestMagicLinkRequest]) (*connect.Response[auth.RequestMagicLinkResponse], error)
+	// ConsumeMagicLink signs in with the token from a magic link, creating
+	// the account on first use if the server allows it.
+	ConsumeMagicLink(context.Context, *connect.Request[auth.ConsumeMagicLinkRequest]) (*connect.Response[auth.LoginResponse], error)
 }
 
 // NewAuthServiceClient constructs a client for the auth.AuthService service. By default, it uses
@@ -87,15 +168,127 @@ func NewAuthServiceClient(httpClient connect.HTTPClient, baseURL string, opts ..
 			connect.WithSchema(authServiceMethods.ByName("RefreshToken")),
 			connect.WithClientOptions(opts...),
 		),
+		sendVerificationEmail: connect.NewClient[auth.SendVerificationEmailRequest, auth.SendVerificationEmailResponse](
+			httpClient,
+			baseURL+AuthServiceSendVerificationEmailProcedure,
+			connect.WithSchema(authServiceMethods.ByName("SendVerificationEmail")),
+			connect.WithClientOptions(opts...),
+		),
+		verifyEmail: connect.NewClient[auth.VerifyEmailRequest, auth.VerifyEmailResponse](
+			httpClient,
+			baseURL+AuthServiceVerifyEmailProcedure,
+			connect.WithSchema(authServiceMethods.ByName("VerifyEmail")),
+			connect.WithClientOptions(opts...),
+		),
+		requestPasswordReset: connect.NewClient[auth.RequestPasswordResetRequest, auth.RequestPasswordResetResponse](
+			httpClient,
+			baseURL+AuthServiceRequestPasswordResetProcedure,
+			connect.WithSchema(authServiceMethods.ByName("RequestPasswordReset")),
+			connect.WithClientOptions(opts...),
+		),
+		resetPassword: connect.NewClient[auth.ResetPasswordRequest, auth.ResetPasswordResponse](
+			httpClient,
+			baseURL+AuthServiceResetPasswordProcedure,
+			connect.WithSchema(authServiceMethods.ByName("ResetPassword")),
+			connect.WithClientOptions(opts...),
+		),
+		changePassword: connect.NewClient[auth.ChangePasswordRequest, auth.ChangePasswordResponse](
+			httpClient,
+			baseURL+AuthServiceChangePasswordProcedure,
+			connect.WithSchema(authServiceMethods.ByName("ChangePassword")),
+			connect.WithClientOptions(opts...),
+		),
+		enrollTOTP: connect.NewClient[auth.EnrollTOTPRequest, auth.EnrollTOTPResponse](
+			httpClient,
+			baseURL+AuthServiceEnrollTOTPProcedure,
+			connect.WithSchema(authServiceMethods.ByName("EnrollTOTP")),
+			connect.WithClientOptions(opts...),
+		),
+		confirmTOTP: connect.NewClient[auth.ConfirmTOTPRequest, auth.ConfirmTOTPResponse](
+			httpClient,
+			baseURL+AuthServiceConfirmTOTPProcedure,
+			connect.WithSchema(authServiceMethods.ByName("ConfirmTOTP")),
+			connect.WithClientOptions(opts...),
+		),
+		disableTOTP: connect.NewClient[auth.DisableTOTPRequest, auth.DisableTOTPResponse](
+			httpClient,
+			baseURL+AuthServiceDisableTOTPProcedure,
+			connect.WithSchema(authServiceMethods.ByName("DisableTOTP")),
+			connect.WithClientOptions(opts...),
+		),
+		verifyMFA: connect.NewClient[auth.VerifyMFARequest, auth.VerifyMFAResponse](
+			httpClient,
+			baseURL+AuthServiceVerifyMFAProcedure,
+			connect.WithSchema(authServiceMethods.ByName("VerifyMFA")),
+			connect.WithClientOptions(opts...),
+		),
+		listSessions: connect.NewClient[auth.ListSessionsRequest, auth.ListSessionsResponse](
+			httpClient,
+			baseURL+AuthServiceListSessionsProcedure,
+			connect.WithSchema(authServiceMethods.ByName("ListSessions")),
+			connect.WithClientOptions(opts...),
+		),
+		revokeSession: connect.NewClient[auth.RevokeSessionRequest, auth.RevokeSessionResponse](
+			httpClient,
+			baseURL+AuthServiceRevokeSessionProcedure,
+			connect.WithSchema(authServiceMethods.ByName("RevokeSession")),
+			connect.WithClientOptions(opts...),
+		),
+		revokeAllOtherSessions: connect.NewClient[auth.RevokeAllOtherSessionsRequest, auth.RevokeAllOtherSessionsResponse](
+			httpClient,
+			baseURL+AuthServiceRevokeAllOtherSessionsProcedure,
+			connect.WithSchema(authServiceMethods.ByName("RevokeAllOtherSessions")),
+			connect.WithClientOptions(opts...),
+		),
+		listAuthEvents: connect.NewClient[auth.ListAuthEventsRequest, auth.ListAuthEventsResponse](
+			httpClient,
+			baseURL+AuthServiceListAuthEventsProcedure,
+			connect.WithSchema(authServiceMethods.ByName("ListAuthEvents")),
+			connect.WithClientOptions(opts...),
+		),
+		impersonate: connect.NewClient[auth.ImpersonateRequest, auth.ImpersonateResponse](
+			httpClient,
+			baseURL+AuthServiceImpersonateProcedure,
+			connect.WithSchema(authServiceMethods.ByName("Impersonate")),
+			connect.WithClientOptions(opts...),
+		),
+		requestMagicLink: connect.NewClient[auth.RequestMagicLinkRequest, auth.RequestMagicLinkResponse](
+			httpClient,
+			baseURL+AuthServiceRequestMagicLinkProcedure,
+			connect.WithSchema(authServiceMethods.ByName("RequestMagicLink")),
+			connect.WithClientOptions(opts...),
+		),
+		consumeMagicLink: connect.NewClient[auth.ConsumeMagicLinkRequest, auth.LoginResponse](
+			httpClient,
+			baseURL+AuthServiceConsumeMagicLinkProcedure,
+			connect.WithSchema(authServiceMethods.ByName("ConsumeMagicLink")),
+			connect.WithClientOptions(opts...),
+		),
 	}
 }
 
 // authServiceClient implements AuthServiceClient.
 type authServiceClient struct {
-	register     *connect.Client[auth.RegisterRequest, auth.RegisterResponse]
-	login        *connect.Client[auth.LoginRequest, auth.LoginResponse]
-	logout       *connect.Client[auth.LogoutRequest, auth.LogoutResponse]
-	refreshToken *connect.Client[auth.RefreshTokenRequest, auth.RefreshTokenResponse]
+	register               *connect.Client[auth.RegisterRequest, auth.RegisterResponse]
+	login                  *connect.Client[auth.LoginRequest, auth.LoginResponse]
+	logout                 *connect.Client[auth.LogoutRequest, auth.LogoutResponse]
+	refreshToken           *connect.Client[auth.RefreshTokenRequest, auth.RefreshTokenResponse]
+	sendVerificationEmail  *connect.Client[auth.SendVerificationEmailRequest, auth.SendVerificationEmailResponse]
+	verifyEmail            *connect.Client[auth.VerifyEmailRequest, auth.VerifyEmailResponse]
+	requestPasswordReset   *connect.Client[auth.RequestPasswordResetRequest, auth.RequestPasswordResetResponse]
+	resetPassword          *connect.Client[auth.ResetPasswordRequest, auth.ResetPasswordResponse]
+	changePassword         *connect.Client[auth.ChangePasswordRequest, auth.ChangePasswordResponse]
+	enrollTOTP             *connect.Client[auth.EnrollTOTPRequest, auth.EnrollTOTPResponse]
+	confirmTOTP            *connect.Client[auth.ConfirmTOTPRequest, auth.ConfirmTOTPResponse]
+	disableTOTP            *connect.Client[auth.DisableTOTPRequest, auth.DisableTOTPResponse]
+	verifyMFA              *connect.Client[auth.VerifyMFARequest, auth.VerifyMFAResponse]
+	listSessions           *connect.Client[auth.ListSessionsRequest, auth.ListSessionsResponse]
+	revokeSession          *connect.Client[auth.RevokeSessionRequest, auth.RevokeSessionResponse]
+	revokeAllOtherSessions *connect.Client[auth.RevokeAllOtherSessionsRequest, auth.RevokeAllOtherSessionsResponse]
+	listAuthEvents         *connect.Client[auth.ListAuthEventsRequest, auth.ListAuthEventsResponse]
+	impersonate            *connect.Client[auth.ImpersonateRequest, auth.ImpersonateResponse]
+	requestMagicLink       *connect.Client[auth.RequestMagicLinkRequest, auth.RequestMagicLinkResponse]
+	consumeMagicLink       *connect.Client[auth.ConsumeMagicLinkRequest, auth.LoginResponse]
 }
 
 // Register calls auth.AuthService.Register.
@@ -118,12 +311,131 @@ func (c *authServiceClient) RefreshToken(ctx context.Context, req *connect.Reque
 	return c.refreshToken.CallUnary(ctx, req)
 }
 
+// SendVerificationEmail calls auth.AuthService.SendVerificationEmail.
+func (c *authServiceClient) SendVerificationEmail(ctx context.Context, req *connect.Request[auth.SendVerificationEmailRequest]) (*connect.Response[auth.SendVerificationEmailResponse], error) {
+	return c.sendVerificationEmail.CallUnary(ctx, req)
+}
+
+// VerifyEmail calls auth.AuthService.VerifyEmail.
+func (c *authServiceClient) VerifyEmail(ctx context.Context, req *connect.Request[auth.VerifyEmailRequest]) (*connect.Response[auth.VerifyEmailResponse], error) {
+	return c.verifyEmail.CallUnary(ctx, req)
+}
+
+// RequestPasswordReset calls auth.AuthService.RequestPasswordReset.
+func (c *authServiceClient) RequestPasswordReset(ctx context.Context, req *connect.Request[auth.RequestPasswordResetRequest]) (*connect.Response[auth.RequestPasswordResetResponse], error) {
+	return c.requestPasswordReset.CallUnary(ctx, req)
+}
+
+// ResetPassword calls auth.AuthService.ResetPassword.
+func (c *authServiceClient) ResetPassword(ctx context.Context, req *connect.Request[auth.ResetPasswordRequest]) (*connect.Response[auth.ResetPasswordResponse], error) {
+	return c.resetPassword.CallUnary(ctx, req)
+}
+
+// ChangePassword calls auth.AuthService.ChangePassword.
+func (c *authServiceClient) ChangePassword(ctx context.Context, req *connect.Request[auth.ChangePasswordRequest]) (*connect.Response[auth.ChangePasswordResponse], error) {
+	return c.changePassword.CallUnary(ctx, req)
+}
+
+// EnrollTOTP calls auth.AuthService.EnrollTOTP.
+func (c *authServiceClient) EnrollTOTP(ctx context.Context, req *connect.Request[auth.EnrollTOTPRequest]) (*connect.Response[auth.EnrollTOTPResponse], error) {
+	return c.enrollTOTP.CallUnary(ctx, req)
+}
+
+// ConfirmTOTP calls auth.AuthService.ConfirmTOTP.
+func (c *authServiceClient) ConfirmTOTP(ctx context.Context, req *connect.Request[auth.ConfirmTOTPRequest]) (*connect.Response[auth.ConfirmTOTPResponse], error) {
+	return c.confirmTOTP.CallUnary(ctx, req)
+}
+
+// DisableTOTP calls auth.AuthService.DisableTOTP.
+func (c *authServiceClient) DisableTOTP(ctx context.Context, req *connect.Request[auth.DisableTOTPRequest]) (*connect.Response[auth.DisableTOTPResponse], error) {
+	return c.disableTOTP.CallUnary(ctx, req)
+}
+
+// VerifyMFA calls auth.AuthService.VerifyMFA.
+func (c *authServiceClient) VerifyMFA(ctx context.Context, req *connect.Request[auth.VerifyMFARequest]) (*connect.Response[auth.VerifyMFAResponse], error) {
+	return c.verifyMFA.CallUnary(ctx, req)
+}
+
+// ListSessions calls auth.AuthService.ListSessions.
+func (c *authServiceClient) ListSessions(ctx context.Context, req *connect.Request[auth.ListSessionsRequest]) (*connect.Response[auth.ListSessionsResponse], error) {
+	return c.listSessions.CallUnary(ctx, req)
+}
+
+// RevokeSession calls auth.AuthService.RevokeSession.
+func (c *authServiceClient) RevokeSession(ctx context.Context, req *connect.Request[auth.RevokeSessionRequest]) (*connect.Response[auth.RevokeSessionResponse], error) {
+	return c.revokeSession.CallUnary(ctx, req)
+}
+
+// RevokeAllOtherSessions calls auth.AuthService.RevokeAllOtherSessions.
+func (c *authServiceClient) RevokeAllOtherSessions(ctx context.Context, req *connect.Request[auth.RevokeAllOtherSessionsRequest]) (*connect.Response[auth.RevokeAllOtherSessionsResponse], error) {
+	return c.revokeAllOtherSessions.CallUnary(ctx, req)
+}
+
+// ListAuthEvents calls auth.AuthService.ListAuthEvents.
+func (c *authServiceClient) ListAuthEvents(ctx context.Context, req *connect.Request[auth.ListAuthEventsRequest]) (*connect.Response[auth.ListAuthEventsResponse], error) {
+	return c.listAuthEvents.CallUnary(ctx, req)
+}
+
+// Impersonate calls auth.AuthService.Impersonate.
+func (c *authServiceClient) Impersonate(ctx context.Context, req *connect.Request[auth.ImpersonateRequest]) (*connect.Response[auth.ImpersonateResponse], error) {
+	return c.impersonate.CallUnary(ctx, req)
+}
+
+// RequestMagicLink calls auth.AuthService.RequestMagicLink.
+func (c *authServiceClient) RequestMagicLink(ctx context.Context, req *connect.Request[auth.RequestMagicLinkRequest]) (*connect.Response[auth.RequestMagicLinkResponse], error) {
+	return c.requestMagicLink.CallUnary(ctx, req)
+}
+
+// ConsumeMagicLink calls auth.AuthService.ConsumeMagicLink.
+func (c *authServiceClient) ConsumeMagicLink(ctx context.Context, req *connect.Request[auth.ConsumeMagicLinkRequest]) (*connect.Response[auth.LoginResponse], error) {
+	return c.consumeMagicLink.CallUnary(ctx, req)
+}
+
 // AuthServiceHandler is an implementation of the auth.AuthService service.
 type AuthServiceHandler interface {
 	Register(context.Context, *connect.Request[auth.RegisterRequest]) (*connect.Response[auth.RegisterResponse], error)
 	Login(context.Context, *connect.Request[auth.LoginRequest]) (*connect.Response[auth.LoginResponse], error)
 	Logout(context.Context, *connect.Request[auth.LogoutRequest]) (*connect.Response[auth.LogoutResponse], error)
 	RefreshToken(context.Context, *connect.Request[auth.RefreshTokenRequest]) (*connect.Response[auth.RefreshTokenResponse], error)
+	// SendVerificationEmail emails the authenticated user a link to confirm their address.
+	SendVerificationEmail(context.Context, *connect.Request[auth.SendVerificationEmailRequest]) (*connect.Response[auth.SendVerificationEmailResponse], error)
+	// VerifyEmail consumes the token from a verification link.
+	VerifyEmail(context.Context, *connect.Request[auth.VerifyEmailRequest]) (*connect.Response[auth.VerifyEmailResponse], error)
+	// RequestPasswordReset emails a reset link if the address is registered.
+	// The response is the same either way.
+	RequestPasswordReset(context.Context, *connect.Request[auth.RequestPasswordResetRequest]) (*connect.Response[auth.RequestPasswordResetResponse], error)
+	// ResetPassword sets a new password and signs the user out everywhere.
+	ResetPassword(context.Context, *connect.Request[auth.ResetPasswordRequest]) (*connect.Response[auth.ResetPasswordResponse], error)
+	// ChangePassword replaces the authenticated user's password after
+	// re-checking the current one.
+	ChangePassword(context.Context, *connect.Request[auth.ChangePasswordRequest]) (*connect.Response[auth.ChangePasswordResponse], error)
+	// EnrollTOTP starts setting up an authenticator app for the authenticated user.
+	EnrollTOTP(context.Context, *connect.Request[auth.EnrollTOTPRequest]) (*connect.Response[auth.EnrollTOTPResponse], error)
+	// ConfirmTOTP enables MFA once the app produces a valid code.
+	ConfirmTOTP(context.Context, *connect.Request[auth.ConfirmTOTPRequest]) (*connect.Response[auth.ConfirmTOTPResponse], error)
+	// DisableTOTP turns MFA off after checking a TOTP or recovery code.
+	DisableTOTP(context.Context, *connect.Request[auth.DisableTOTPRequest]) (*connect.Response[auth.DisableTOTPResponse], error)
+	// VerifyMFA exchanges the challenge token returned by Login for tokens.
+	VerifyMFA(context.Context, *connect.Request[auth.VerifyMFARequest]) (*connect.Response[auth.VerifyMFAResponse], error)
+	// ListSessions returns the devices the authenticated user is signed in on.
+	ListSessions(context.Context, *connect.Request[auth.ListSessionsRequest]) (*connect.Response[auth.ListSessionsResponse], error)
+	// RevokeSession signs one of the authenticated user's sessions out.
+	RevokeSession(context.Context, *connect.Request[auth.RevokeSessionRequest]) (*connect.Response[auth.RevokeSessionResponse], error)
+	// RevokeAllOtherSessions signs out every session but the caller's, which
+	// continues with the returned tokens.
+	RevokeAllOtherSessions(context.Context, *connect.Request[auth.RevokeAllOtherSessionsRequest]) (*connect.Response[auth.RevokeAllOtherSessionsResponse], error)
+	// ListAuthEvents searches the authentication audit log, newest first.
+	ListAuthEvents(context.Context, *connect.Request[auth.ListAuthEventsRequest]) (*connect.Response[auth.ListAuthEventsResponse], error)
+	// Impersonate issues a short-lived access token that acts as another
+	// user, for reproducing their issues. It can't be refreshed and carries
+	// the admin's ID in its act claim.
+	Impersonate(context.Context, *connect.Request[auth.ImpersonateRequest]) (*connect.Response[auth.ImpersonateResponse], error)
+	// RequestMagicLink emails a single-use sign-in link. The response is the
+	// same whether or not the address is registered.
+	RequestMagicLink(context.Context, *connect.Request[auth.RequestMagicLinkRequest]) (*connect.Response[auth.RequestMagicLinkResponse], error)
+	// ConsumeMagicLink signs in with the token from a magic link, creating
+	// the account on first use if the server allows it.
+	ConsumeMagicLink(context.Context, *connect.Request[auth.ConsumeMagicLinkRequest]) (*connect.Response[auth.LoginResponse], error)
 }
 
 // NewAuthServiceHandler builds an HTTP handler from the service implementation. It returns the path
@@ -157,6 +469,102 @@ func NewAuthServiceHandler(svc AuthServiceHandler, opts ...connect.HandlerOption
 		connect.WithSchema(authServiceMethods.ByName("RefreshToken")),
 		connect.WithHandlerOptions(opts...),
 	)
+	authServiceSendVerificationEmailHandler := connect.NewUnaryHandler(
+		AuthServiceSendVerificationEmailProcedure,
+		svc.SendVerificationEmail,
+		connect.WithSchema(authServiceMethods.ByName("SendVerificationEmail")),
+		connect.WithHandlerOptions(opts...),
+	)
+	authServiceVerifyEmailHandler := connect.NewUnaryHandler(
+		AuthServiceVerifyEmailProcedure,
+		svc.VerifyEmail,
+		connect.WithSchema(authServiceMethods.ByName("VerifyEmail")),
+		connect.WithHandlerOptions(opts...),
+	)
+	authServiceRequestPasswordResetHandler := connect.NewUnaryHandler(
+		AuthServiceRequestPasswordResetProcedure,
+		svc.RequestPasswordReset,
+		connect.WithSchema(authServiceMethods.ByName("RequestPasswordReset")),
+		connect.WithHandlerOptions(opts...),
+	)
+	authServiceResetPasswordHandler := connect.NewUnaryHandler(
+		AuthServiceResetPasswordProcedure,
+		svc.ResetPassword,
+		connect.WithSchema(authServiceMethods.ByName("ResetPassword")),
+		connect.WithHandlerOptions(opts...),
+	)
+	authServiceChangePasswordHandler := connect.NewUnaryHandler(
+		AuthServiceChangePasswordProcedure,
+		svc.ChangePassword,
+		connect.WithSchema(authServiceMethods.ByName("ChangePassword")),
+		connect.WithHandlerOptions(opts...),
+	)
+	authServiceEnrollTOTPHandler := connect.NewUnaryHandler(
+		AuthServiceEnrollTOTPProcedure,
+		svc.EnrollTOTP,
+		connect.WithSchema(authServiceMethods.ByName("EnrollTOTP")),
+		connect.WithHandlerOptions(opts...),
+	)
+	authServiceConfirmTOTPHandler := connect.NewUnaryHandler(
+		AuthServiceConfirmTOTPProcedure,
+		svc.ConfirmTOTP,
+		connect.WithSchema(authServiceMethods.ByName("ConfirmTOTP")),
+		connect.WithHandlerOptions(opts...),
+	)
+	authServiceDisableTOTPHandler := connect.NewUnaryHandler(
+		AuthServiceDisableTOTPProcedure,
+		svc.DisableTOTP,
+		connect.WithSchema(authServiceMethods.ByName("DisableTOTP")),
+		connect.WithHandlerOptions(opts...),
+	)
+	authServiceVerifyMFAHandler := connect.NewUnaryHandler(
+		AuthServiceVerifyMFAProcedure,
+		svc.VerifyMFA,
+		connect.WithSchema(authServiceMethods.ByName("VerifyMFA")),
+		connect.WithHandlerOptions(opts...),
+	)
+	authServiceListSessionsHandler := connect.NewUnaryHandler(
+		AuthServiceListSessionsProcedure,
+		svc.ListSessions,
+		connect.WithSchema(authServiceMethods.ByName("ListSessions")),
+		connect.WithHandlerOptions(opts...),
+	)
+	authServiceRevokeSessionHandler := connect.NewUnaryHandler(
+		AuthServiceRevokeSessionProcedure,
+		svc.RevokeSession,
+		connect.WithSchema(authServiceMethods.ByName("RevokeSession")),
+		connect.WithHandlerOptions(opts...),
+	)
+	authServiceRevokeAllOtherSessionsHandler := connect.NewUnaryHandler(
+		AuthServiceRevokeAllOtherSessionsProcedure,
+		svc.RevokeAllOtherSessions,
+		connect.WithSchema(authServiceMethods.ByName("RevokeAllOtherSessions")),
+		connect.WithHandlerOptions(opts...),
+	)
+	authServiceListAuthEventsHandler := connect.NewUnaryHandler(
+		AuthServiceListAuthEventsProcedure,
+		svc.ListAuthEvents,
+		connect.WithSchema(authServiceMethods.ByName("ListAuthEvents")),
+		connect.WithHandlerOptions(opts...),
+	)
+	authServiceImpersonateHandler := connect.NewUnaryHandler(
+		AuthServiceImpersonateProcedure,
+		svc.Impersonate,
+		connect.WithSchema(authServiceMethods.ByName("Impersonate")),
+		connect.WithHandlerOptions(opts...),
+	)
+	authServiceRequestMagicLinkHandler := connect.NewUnaryHandler(
+		AuthServiceRequestMagicLinkProcedure,
+		svc.RequestMagicLink,
+		connect.WithSchema(authServiceMethods.ByName("RequestMagicLink")),
+		connect.WithHandlerOptions(opts...),
+	)
+	authServiceConsumeMagicLinkHandler := connect.NewUnaryHandler(
+		AuthServiceConsumeMagicLinkProcedure,
+		svc.ConsumeMagicLink,
+		connect.WithSchema(authServiceMethods.ByName("ConsumeMagicLink")),
+		connect.WithHandlerOptions(opts...),
+	)
 	return "/auth.AuthService/", http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
 		switch r.URL.Path {
 		case AuthServiceRegisterProcedure:
@@ -167,6 +575,38 @@ func NewAuthServiceHandler(svc AuthServiceHandler, opts ...connect.HandlerOption
 			authServiceLogoutHandler.ServeHTTP(w, r)
 		case AuthServiceRefreshTokenProcedure:
 			authServiceRefreshTokenHandler.ServeHTTP(w, r)
+		case AuthServiceSendVerificationEmailProcedure:
+			authServiceSendVerificationEmailHandler.ServeHTTP(w, r)
+		case AuthServiceVerifyEmailProcedure:
+			authServiceVerifyEmailHandler.ServeHTTP(w, r)
+		case AuthServiceRequestPasswordResetProcedure:
+			authServiceRequestPasswordResetHandler.ServeHTTP(w, r)
+		case AuthServiceResetPasswordProcedure:
+			authServiceResetPasswordHandler.ServeHTTP(w, r)
+		case AuthServiceChangePasswordProcedure:
+			authServiceChangePasswordHandler.ServeHTTP(w, r)
+		case AuthServiceEnrollTOTPProcedure:
+			authServiceEnrollTOTPHandler.ServeHTTP(w, r)
+		case AuthServiceConfirmTOTPProcedure:
+			authServiceConfirmTOTPHandler.ServeHTTP(w, r)
+		case AuthServiceDisableTOTPProcedure:
+			authServiceDisableTOTPHandler.ServeHTTP(w, r)
+		case AuthServiceVerifyMFAProcedure:
+			authServiceVerifyMFAHandler.ServeHTTP(w, r)
+		case AuthServiceListSessionsProcedure:
+			authServiceListSessionsHandler.ServeHTTP(w, r)
+		case AuthServiceRevokeSessionProcedure:
+			authServiceRevokeSessionHandler.ServeHTTP(w, r)
+		case AuthServiceRevokeAllOtherSessionsProcedure:
+			authServiceRevokeAllOtherSessionsHandler.ServeHTTP(w, r)
+		case AuthServiceListAuthEventsProcedure:
+			authServiceListAuthEventsHandler.ServeHTTP(w, r)
+		case AuthServiceImpersonateProcedure:
+			authServiceImpersonateHandler.ServeHTTP(w, r)
+		case AuthServiceRequestMagicLinkProcedure:
+			authServiceRequestMagicLinkHandler.ServeHTTP(w, r)
+		case AuthServiceConsumeMagicLinkProcedure:
+			authServiceConsumeMagicLinkHandler.ServeHTTP(w, r)
 		default:
 			http.NotFound(w, r)
 		}
@@ -191,3 +631,67 @@ func (UnimplementedAuthServiceHandler) Logout(context.Context, *connect.Request[
 func (UnimplementedAuthServiceHandler) RefreshToken(context.Context, *connect.Request[auth.RefreshTokenRequest]) (*connect.Response[auth.RefreshTokenResponse], error) {
 	return nil, connect.NewError(connect.CodeUnimplemented, errors.New("auth.AuthService.RefreshToken is not implemented"))
 }
+
+func (UnimplementedAuthServiceHandler) SendVerificationEmail(context.Context, *connect.Request[auth.SendVerificationEmailRequest]) (*connect.Response[auth.SendVerificationEmailResponse], error) {
+	return nil, connect.NewError(connect.CodeUnimplemented, errors.New("auth.AuthService.SendVerificationEmail is not implemented"))
+}
+
+func (UnimplementedAuthServiceHandler) VerifyEmail(context.Context, *connect.Request[auth.VerifyEmailRequest]) (*connect.Response[auth.VerifyEmailResponse], error) {
+	return nil, connect.NewError(connect.CodeUnimplemented, errors.New("auth.AuthService.VerifyEmail is not implemented"))
+}
+
+func (UnimplementedAuthServiceHandler) RequestPasswordReset(context.Context, *connect.Request[auth.RequestPasswordResetRequest]) (*connect.Response[auth.RequestPasswordResetResponse], error) {
+	return nil, connect.NewError(connect.CodeUnimplemented, errors.New("auth.AuthService.RequestPasswordReset is not implemented"))
+}
+
+func (UnimplementedAuthServiceHandler) ResetPassword(context.Context, *connect.Request[auth.ResetPasswordRequest]) (*connect.Response[auth.ResetPasswordResponse], error) {
+	return nil, connect.NewError(connect.CodeUnimplemented, errors.New("auth.AuthService.ResetPassword is not implemented"))
+}
+
+func (UnimplementedAuthServiceHandler) ChangePassword(context.Context, *connect.Request[auth.ChangePasswordRequest]) (*connect.Response[auth.ChangePasswordResponse], error) {
+	return nil, connect.NewError(connect.CodeUnimplemented, errors.New("auth.AuthService.ChangePassword is not implemented"))
+}
+
+func (UnimplementedAuthServiceHandler) EnrollTOTP(context.Context, *connect.Request[auth.EnrollTOTPRequest]) (*connect.Response[auth.EnrollTOTPResponse], error) {
+	return nil, connect.NewError(connect.CodeUnimplemented, errors.New("auth.AuthService.EnrollTOTP is not implemented"))
+}
+
+func (UnimplementedAuthServiceHandler) ConfirmTOTP(context.Context, *connect.Request[auth.ConfirmTOTPRequest]) (*connect.Response[auth.ConfirmTOTPResponse], error) {
+	return nil, connect.NewError(connect.CodeUnimplemented, errors.New("auth.AuthService.ConfirmTOTP is not implemented"))
+}
+
+func (UnimplementedAuthServiceHandler) DisableTOTP(context.Context, *connect.Request[auth.DisableTOTPRequest]) (*connect.Response[auth.DisableTOTPResponse], error) {
+	return nil, connect.NewError(connect.CodeUnimplemented, errors.New("auth.AuthService.DisableTOTP is not implemented"))
+}
+
+func (UnimplementedAuthServiceHandler) VerifyMFA(context.Context, *connect.Request[auth.VerifyMFARequest]) (*connect.Response[auth.VerifyMFAResponse], error) {
+	return nil, connect.NewError(connect.CodeUnimplemented, errors.New("auth.AuthService.VerifyMFA is not implemented"))
+}
+
+func (UnimplementedAuthServiceHandler) ListSessions(context.Context, *connect.Request[auth.ListSessionsRequest]) (*connect.Response[auth.ListSessionsResponse], error) {
+	return nil, connect.NewError(connect.CodeUnimplemented, errors.New("auth.AuthService.ListSessions is not implemented"))
+}
+
+func (UnimplementedAuthServiceHandler) RevokeSession(context.Context, *connect.Request[auth.RevokeSessionRequest]) (*connect.Response[auth.RevokeSessionResponse], error) {
+	return nil, connect.NewError(connect.CodeUnimplemented, errors.New("auth.AuthService.RevokeSession is not implemented"))
+}
+
+func (UnimplementedAuthServiceHandler) RevokeAllOtherSessions(context.Context, *connect.Request[auth.RevokeAllOtherSessionsRequest]) (*connect.Response[auth.RevokeAllOtherSessionsResponse], error) {
+	return nil, connect.NewError(connect.CodeUnimplemented, errors.New("auth.AuthService.RevokeAllOtherSessions is not implemented"))
+}
+
+func (UnimplementedAuthServiceHandler) ListAuthEvents(context.Context, *connect.Request[auth.ListAuthEventsRequest]) (*connect.Response[auth.ListAuthEventsResponse], error) {
+	return nil, connect.NewError(connect.CodeUnimplemented, errors.New("auth.AuthService.ListAuthEvents is not implemented"))
+}
+
+func (UnimplementedAuthServiceHandler) Impersonate(context.Context, *connect.Request[auth.ImpersonateRequest]) (*connect.Response[auth.ImpersonateResponse], error) {
+	return nil, connect.NewError(connect.CodeUnimplemented, errors.New("auth.AuthService.Impersonate is not implemented"))
+}
+
+func (UnimplementedAuthServiceHandler) RequestMagicLink(context.Context, *connect.Request[auth.RequestMagicLinkRequest]) (*connect.Response[auth.RequestMagicLinkResponse], error) {
+	return nil, connect.NewError(connect.CodeUnimplemented, errors.New("auth.AuthService.RequestMagicLink is not implemented"))
+}
+
+func (UnimplementedAuthServiceHandler) ConsumeMagicLink(context.Context, *connect.Request[auth.ConsumeMagicLinkRequest]) (*connect.Response[auth.LoginResponse], error) {
+	return nil, connect.NewError(connect.CodeUnimplemented, errors.New("auth.AuthService.ConsumeMagicLink is not implemented"))
+}
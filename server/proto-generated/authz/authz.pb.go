@@ -0,0 +1,252 @@
+// Code generated by protoc-gen-go. DO NOT EDIT.
+// versions:
+// 	protoc-gen-go v1.36.11
+// 	protoc        (unknown)
+// source: authz/authz.proto
+
+package authz
+
+import (
+	protoreflect "google.golang.org/protobuf/reflect/protoreflect"
+	protoimpl "google.golang.org/protobuf/runtime/protoimpl"
+	descriptorpb "google.golang.org/protobuf/types/descriptorpb"
+	reflect "reflect"
+	sync "sync"
+	unsafe "unsafe"
+)
+
+const (
+	// Verify that this generated code is sufficiently up-to-date.
+	_ = protoimpl.EnforceVersion(20 - protoimpl.MinVersion)
+	// Verify that runtime/protoimpl is sufficiently up-to-date.
+	_ = protoimpl.EnforceVersion(protoimpl.MaxVersion - 20)
+)
+
+// Access says who may call an RPC at all.
+type Access int32
+
+const (
+	// Unannotated RPCs require an authenticated caller.
+	Access_ACCESS_UNSPECIFIED   Access = 0
+	Access_ACCESS_AUTHENTICATED Access = 1
+	// Anyone may call the RPC, with or without credentials.
+	Access_ACCESS_PUBLIC Access = 2
+)
+
+// Enum value maps for Access.
+var (
+	Access_name = map[int32]string{
+		0: "ACCESS_UNSPECIFIED",
+		1: "ACCESS_AUTHENTICATED",
+		2: "ACCESS_PUBLIC",
+	}
+	Access_value = map[string]int32{
+		"ACCESS_UNSPECIFIED":   0,
+		"ACCESS_AUTHENTICATED": 1,
+		"ACCESS_PUBLIC":        2,
+	}
+)
+
+func (x Access) Enum() *Access {
+	p := new(Access)
+	*p = x
+	return p
+}
+
+func (x Access) String() string {
+	return protoimpl.X.EnumStringOf(x.Descriptor(), protoreflect.EnumNumber(x))
+}
+
+func (Access) Descriptor() protoreflect.EnumDescriptor {
+	return file_authz_authz_proto_enumTypes[0].Descriptor()
+}
+
+func (Access) Type() protoreflect.EnumType {
+	return &file_authz_authz_proto_enumTypes[0]
+}
+
+func (x Access) Number() protoreflect.EnumNumber {
+	return protoreflect.EnumNumber(x)
+}
+
+// Deprecated: Use Access.Descriptor instead.
+func (Access) EnumDescriptor() ([]byte, []int) {
+	return file_authz_authz_proto_rawDescGZIP(), []int{0}
+}
+
+// AuthRule declares the credentials an RPC requires. It is enforced by the
+// server's auth interceptor before the handler runs.
+type AuthRule struct {
+	state  protoimpl.MessageState `protogen:"open.v1"`
+	Access Access                 `protobuf:"varint,1,opt,name=access,proto3,enum=authz.Access" json:"access,omitempty"`
+	// API keys may only call RPCs listing one of the key's scopes. RPCs
+	// without scopes can't be called with an API key.
+	Scopes []string `protobuf:"bytes,2,rep,name=scopes,proto3" json:"scopes,omitempty"`
+	// If set, the caller must hold one of these roles.
+	Roles []string `protobuf:"bytes,3,rep,name=roles,proto3" json:"roles,omitempty"`
+	// Impersonation tokens can't call the RPC. Set it on RPCs that change
+	// credentials or hand out new ones.
+	DenyImpersonation bool `protobuf:"varint,4,opt,name=deny_impersonation,json=denyImpersonation,proto3" json:"deny_impersonation,omitempty"`
+	// If set, the caller must hold one of these permissions, through their
+	// role or granted to them directly.
+	Permissions   []string `protobuf:"bytes,5,rep,name=permissions,proto3" json:"permissions,omitempty"`
+	unknownFields protoimpl.UnknownFields
+	sizeCache     protoimpl.SizeCache
+}
+
+func (x *AuthRule) Reset() {
+	*x = AuthRule{}
+	mi := &file_authz_authz_proto_msgTypes[0]
+	ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+	ms.StoreMessageInfo(mi)
+}
+
+func (x *AuthRule) String() string {
+	return protoimpl.X.MessageStringOf(x)
+}
+
+func (*AuthRule) ProtoMessage() {}
+
+func (x *AuthRule) ProtoReflect() protoreflect.Message {
+	mi := &file_authz_authz_proto_msgTypes[0]
+	if x != nil {
+		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+		if ms.LoadMessageInfo() == nil {
+			ms.StoreMessageInfo(mi)
+		}
+		return ms
+	}
+	return mi.MessageOf(x)
+}
+
+// Deprecated: Use AuthRule.ProtoReflect.Descriptor instead.
+func (*AuthRule) Descriptor() ([]byte, []int) {
+	return file_authz_authz_proto_rawDescGZIP(), []int{0}
+}
+
+func (x *AuthRule) GetAccess() Access {
+	if x != nil {
+		return x.Access
+	}
+	return Access_ACCESS_UNSPECIFIED
+}
+
+func (x *AuthRule) GetScopes() []string {
+	if x != nil {
+		return x.Scopes
+	}
+	return nil
+}
+
+func (x *AuthRule) GetRoles() []string {
+	if x != nil {
+		return x.Roles
+	}
+	return nil
+}
+
+func (x *AuthRule) GetDenyImpersonation() bool {
+	if x != nil {
+		return x.DenyImpersonation
+	}
+	return false
+}
+
+func (x *AuthRule) GetPermissions() []string {
+	if x != nil {
+		return x.Permissions
+	}
+	return nil
+}
+
+var file_authz_authz_proto_extTypes = []protoimpl.ExtensionInfo{
+	{
+		ExtendedType:  (*descriptorpb.MethodOptions)(nil),
+		ExtensionType: (*AuthRule)(nil),
+		Field:         50000,
+		Name:          "authz.rule",
+		Tag:           "bytes,50000,opt,name=rule",
+		Filename:      "authz/authz.proto",
+	},
+}
+
+// Extension fields to descriptorpb.MethodOptions.
+var (
+	// optional authz.AuthRule rule = 50000;
+	E_Rule = &file_authz_authz_proto_extTypes[0]
+)
+
+var File_authz_authz_proto protoreflect.FileDescriptor
+
+const file_authz_authz_proto_rawDesc = "" +
+	"\n" +
+	"\x11authz/authz.proto\x12\x05authz\x1a google/protobuf/descriptor.proto\"\xb0\x01\n" +
+	"\bAuthRule\x12%\n" +
+	"\x06access\x18\x01 \x01(\x0e2\r.authz.AccessR\x06access\x12\x16\n" +
+	"\x06scopes\x18\x02 \x03(\tR\x06scopes\x12\x14\n" +
+	"\x05roles\x18\x03 \x03(\tR\x05roles\x12-\n" +
+	"\x12deny_impersonation\x18\x04 \x01(\bR\x11denyImpersonation\x12 \n" +
+	"\vpermissions\x18\x05 \x03(\tR\vpermissions*M\n" +
+	"\x06Access\x12\x16\n" +
+	"\x12ACCESS_UNSPECIFIED\x10\x00\x12\x18\n" +
+	"\x14ACCESS_AUTHENTICATED\x10\x01\x12\x11\n" +
+	"\rACCESS_PUBLIC\x10\x02:E\n" +
+	"\x04rule\x12\x1e.google.protobuf.MethodOptions\x18І\x03 \x01(\v2\x0f.authz.AuthRuleR\x04ruleBn\n" +
+	"\tcom.authzB\n" +
+	"AuthzProtoP\x01Z!grpc-server/proto-generated/authz\xa2\x02\x03AXX\xaa\x02\x05Authz\xca\x02\x05Authz\xe2\x02\x11Authz\\GPBMetadata\xea\x02\x05Authzb\x06proto3"
+
+var (
+	file_authz_authz_proto_rawDescOnce sync.Once
+	file_authz_authz_proto_rawDescData []byte
+)
+
+func file_authz_authz_proto_rawDescGZIP() []byte {
+	file_authz_authz_proto_rawDescOnce.Do(func() {
+		file_authz_authz_proto_rawDescData = protoimpl.X.CompressGZIP(unsafe.Slice(unsafe.StringData(file_authz_authz_proto_rawDesc), len(file_authz_authz_proto_rawDesc)))
+	})
+	return file_authz_authz_proto_rawDescData
+}
+
+var file_authz_authz_proto_enumTypes = make([]protoimpl.EnumInfo, 1)
+var file_authz_authz_proto_msgTypes = make([]protoimpl.MessageInfo, 1)
+var file_authz_authz_proto_goTypes = []any{
+	(Access)(0),                        // 0: authz.Access
+	(*AuthRule)(nil),                   // 1: authz.AuthRule
+	(*descriptorpb.MethodOptions)(nil), // 2: google.protobuf.MethodOptions
+}
+var file_authz_authz_proto_depIdxs = []int32{
+	0, // 0: authz.AuthRule.access:type_name -> authz.Access
+	2, // 1: authz.rule:extendee -> google.protobuf.MethodOptions
+	1, // 2: authz.rule:type_name -> authz.AuthRule
+	3, // [3:3] is the sub-list for method output_type
+	3, // [3:3] is the sub-list for method input_type
+	2, // [2:3] is the sub-list for extension type_name
+	1, // [1:2] is the sub-list for extension extendee
+	0, // [0:1] is the sub-list for field type_name
+}
+
+func init() { file_authz_authz_proto_init() }
+func file_authz_authz_proto_init() {
+	if File_authz_authz_proto != nil {
+		return
+	}
+	type x struct{}
+	out := protoimpl.TypeBuilder{
+		File: protoimpl.DescBuilder{
+			GoPackagePath: reflect.TypeOf(x{}).PkgPath(),
+			RawDescriptor: unsafe.Slice(unsafe.StringData(file_authz_authz_proto_rawDesc), len(file_authz_authz_proto_rawDesc)),
+			NumEnums:      1,
+			NumMessages:   1,
+			NumExtensions: 1,
+			NumServices:   0,
+		},
+		GoTypes:           file_authz_authz_proto_goTypes,
+		DependencyIndexes: file_authz_authz_proto_depIdxs,
+		EnumInfos:         file_authz_authz_proto_enumTypes,
+		MessageInfos:      file_authz_authz_proto_msgTypes,
+		ExtensionInfos:    file_authz_authz_proto_extTypes,
+	}.Build()
+	File_authz_authz_proto = out.File
+	file_authz_authz_proto_goTypes = nil
+	file_authz_authz_proto_depIdxs = nil
+}
@@ -10,6 +10,7 @@ import (
 	protoreflect "google.golang.org/protobuf/reflect/protoreflect"
 	protoimpl "google.golang.org/protobuf/runtime/protoimpl"
 	timestamppb "google.golang.org/protobuf/types/known/timestamppb"
+	_ "grpc-server/proto-generated/authz"
 	reflect "reflect"
 	sync "sync"
 	unsafe "unsafe"
@@ -702,7 +703,7 @@ var File_item_item_service_proto protoreflect.FileDescriptor
 
 const file_item_item_service_proto_rawDesc = "" +
 	"\n" +
-	"\x17item/item_service.proto\x12\x04item\x1a\x1fgoogle/protobuf/timestamp.proto\x1a\x0fitem/item.proto\"\xa5\x01\n" +
+	"\x17item/item_service.proto\x12\x04item\x1a\x1fgoogle/protobuf/timestamp.proto\x1a\x0fitem/item.proto\x1a\x11authz/authz.proto\"\xa5\x01\n" +
 	"\n" +
 	"ItemFilter\x12\x17\n" +
 	"\x04name\x18\x01 \x01(\tH\x00R\x04name\x88\x01\x01\x12%\n" +
@@ -757,18 +758,21 @@ const file_item_item_service_proto_rawDesc = "" +
 	"\x04item\x18\x01 \x01(\v2\n" +
 	".item.ItemR\x04item\x12\x1d\n" +
 	"\n" +
-	"event_type\x18\x02 \x01(\tR\teventType2\x95\x03\n" +
-	"\vItemService\x12A\n" +
+	"event_type\x18\x02 \x01(\tR\teventType2\xf8\x03\n" +
+	"\vItemService\x12R\n" +
 	"\n" +
-	"CreateItem\x12\x17.item.CreateItemRequest\x1a\x18.item.CreateItemResponse\"\x00\x128\n" +
-	"\aGetItem\x12\x14.item.GetItemRequest\x1a\x15.item.GetItemResponse\"\x00\x12>\n" +
-	"\tListItems\x12\x16.item.ListItemsRequest\x1a\x17.item.ListItemsResponse\"\x00\x12A\n" +
+	"CreateItem\x12\x17.item.CreateItemRequest\x1a\x18.item.CreateItemResponse\"\x11\x82\xb5\x18\r\x12\vitems:write\x12H\n" +
+	"\aGetItem\x12\x14.item.GetItemRequest\x1a\x15.item.GetItemResponse\"\x10\x82\xb5\x18\f\x12\n" +
+	"items:read\x12N\n" +
+	"\tListItems\x12\x16.item.ListItemsRequest\x1a\x17.item.ListItemsResponse\"\x10\x82\xb5\x18\f\x12\n" +
+	"items:read\x12R\n" +
 	"\n" +
-	"UpdateItem\x12\x17.item.UpdateItemRequest\x1a\x18.item.UpdateItemResponse\"\x00\x12A\n" +
+	"UpdateItem\x12\x17.item.UpdateItemRequest\x1a\x18.item.UpdateItemResponse\"\x11\x82\xb5\x18\r\x12\vitems:write\x12R\n" +
 	"\n" +
-	"DeleteItem\x12\x17.item.DeleteItemRequest\x1a\x18.item.DeleteItemResponse\"\x00\x12C\n" +
+	"DeleteItem\x12\x17.item.DeleteItemRequest\x1a\x18.item.DeleteItemResponse\"\x11\x82\xb5\x18\r\x12\vitems:write\x12S\n" +
 	"\n" +
-	"WatchItems\x12\x17.item.WatchItemsRequest\x1a\x18.item.WatchItemsResponse\"\x000\x01Bn\n" +
+	"WatchItems\x12\x17.item.WatchItemsRequest\x1a\x18.item.WatchItemsResponse\"\x10\x82\xb5\x18\f\x12\n" +
+	"items:read0\x01Bn\n" +
 	"\bcom.itemB\x10ItemServiceProtoP\x01Z grpc-server/proto-generated/item\xa2\x02\x03IXX\xaa\x02\x04Item\xca\x02\x04Item\xe2\x02\x10Item\\GPBMetadata\xea\x02\x04Itemb\x06proto3"
 
 var (
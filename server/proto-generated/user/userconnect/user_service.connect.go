@@ -33,19 +33,45 @@ const (
 // reflection-formatted method names, remove the leading slash and convert the remaining slash to a
 // period.
 const (
+	// UserServiceGetMeProcedure is the fully-qualified name of the UserService's GetMe RPC.
+	UserServiceGetMeProcedure = "/user.UserService/GetMe"
 	// UserServiceGetUserProcedure is the fully-qualified name of the UserService's GetUser RPC.
 	UserServiceGetUserProcedure = "/user.UserService/GetUser"
 	// UserServiceUpdateUserProcedure is the fully-qualified name of the UserService's UpdateUser RPC.
 	UserServiceUpdateUserProcedure = "/user.UserService/UpdateUser"
 	// UserServiceDeleteUserProcedure is the fully-qualified name of the UserService's DeleteUser RPC.
 	UserServiceDeleteUserProcedure = "/user.UserService/DeleteUser"
+	// UserServiceSetUserRoleProcedure is the fully-qualified name of the UserService's SetUserRole RPC.
+	UserServiceSetUserRoleProcedure = "/user.UserService/SetUserRole"
+	// UserServiceSetUserPermissionsProcedure is the fully-qualified name of the UserService's
+	// SetUserPermissions RPC.
+	UserServiceSetUserPermissionsProcedure = "/user.UserService/SetUserPermissions"
 )
 
 // UserServiceClient is a client for the user.UserService service.
 type UserServiceClient interface {
+	// GetMe returns the user the request's token belongs to.
+	GetMe(context.Context, *connect.Request[user.GetMeRequest]) (*connect.Response[user.GetMeResponse], error)
+	// GetUser returns a user. Users may only read themselves; admins may
+	// read anyone.
 	GetUser(context.Context, *connect.Request[user.GetUserRequest]) (*connect.Response[user.GetUserResponse], error)
+	// UpdateUser changes the fields that are set; an empty string clears a
+	// profile field. Users may only update themselves; admins may update
+	// anyone. A new email address has to be verified again.
 	UpdateUser(context.Context, *connect.Request[user.UpdateUserRequest]) (*connect.Response[user.UpdateUserResponse], error)
+	// DeleteUser deletes a user together with their items, sessions, API
+	// keys and linked identities. Users may delete themselves; admins may
+	// delete anyone but themselves.
 	DeleteUser(context.Context, *connect.Request[user.DeleteUserRequest]) (*connect.Response[user.DeleteUserResponse], error)
+	// SetUserRole changes a user's role. Only callers allowed to manage
+	// users may call it, and not on themselves. The user is signed out so new
+	// tokens carry the new role.
+	SetUserRole(context.Context, *connect.Request[user.SetUserRoleRequest]) (*connect.Response[user.SetUserRoleResponse], error)
+	// SetUserPermissions replaces the permissions a user holds on top of
+	// their role. Only callers allowed to manage users may call it, and not
+	// on themselves. The user is signed out so new tokens carry the new
+	// permissions.
+	SetUserPermissions(context.Context, *connect.Request[user.SetUserPermissionsRequest]) (*connect.Response[user.SetUserPermissionsResponse], error)
 }
 
 // NewUserServiceClient constructs a client for the user.UserService service. By default, it uses
@@ -59,6 +85,12 @@ func NewUserServiceClient(httpClient connect.HTTPClient, baseURL string, opts ..
 	baseURL = strings.TrimRight(baseURL, "/")
 	userServiceMethods := user.File_user_user_service_proto.Services().ByName("UserService").Methods()
 	return &userServiceClient{
+		getMe: connect.NewClient[user.GetMeRequest, user.GetMeResponse](
+			httpClient,
+			baseURL+UserServiceGetMeProcedure,
+			connect.WithSchema(userServiceMethods.ByName("GetMe")),
+			connect.WithClientOptions(opts...),
+		),
 		getUser: connect.NewClient[user.GetUserRequest, user.GetUserResponse](
 			httpClient,
 			baseURL+UserServiceGetUserProcedure,
@@ -77,14 +109,34 @@ func NewUserServiceClient(httpClient connect.HTTPClient, baseURL string, opts ..
 			connect.WithSchema(userServiceMethods.ByName("DeleteUser")),
 			connect.WithClientOptions(opts...),
 		),
+		setUserRole: connect.NewClient[user.SetUserRoleRequest, user.SetUserRoleResponse](
+			httpClient,
+			baseURL+UserServiceSetUserRoleProcedure,
+			connect.WithSchema(userServiceMethods.ByName("SetUserRole")),
+			connect.WithClientOptions(opts...),
+		),
+		setUserPermissions: connect.NewClient[user.SetUserPermissionsRequest, user.SetUserPermissionsResponse](
+			httpClient,
+			baseURL+UserServiceSetUserPermissionsProcedure,
+			connect.WithSchema(userServiceMethods.ByName("SetUserPermissions")),
+			connect.WithClientOptions(opts...),
+		),
 	}
 }
 
 // userServiceClient implements UserServiceClient.
 type userServiceClient struct {
-	getUser    *connect.Client[user.GetUserRequest, user.GetUserResponse]
-	updateUser *connect.Client[user.UpdateUserRequest, user.UpdateUserResponse]
-	deleteUser *connect.Client[user.DeleteUserRequest, user.DeleteUserResponse]
+	getMe              *connect.Client[user.GetMeRequest, user.GetMeResponse]
+	getUser            *connect.Client[user.GetUserRequest, user.GetUserResponse]
+	updateUser         *connect.Client[user.UpdateUserRequest, user.UpdateUserResponse]
+	deleteUser         *connect.Client[user.DeleteUserRequest, user.DeleteUserResponse]
+	setUserRole        *connect.Client[user.SetUserRoleRequest, user.SetUserRoleResponse]
+	setUserPermissions *connect.Client[user.SetUserPermissionsRequest, user.SetUserPermissionsResponse]
+}
+
+// GetMe calls user.UserService.GetMe.
+func (c *userServiceClient) GetMe(ctx context.Context, req *connect.Request[user.GetMeRequest]) (*connect.Response[user.GetMeResponse], error) {
+	return c.getMe.CallUnary(ctx, req)
 }
 
 // GetUser calls user.UserService.GetUser.
@@ -102,11 +154,40 @@ func (c *userServiceClient) DeleteUser(ctx context.Context, req *connect.Request
 	return c.deleteUser.CallUnary(ctx, req)
 }
 
+// SetUserRole calls user.UserService.SetUserRole.
+func (c *userServiceClient) SetUserRole(ctx context.Context, req *connect.Request[user.SetUserRoleRequest]) (*connect.Response[user.SetUserRoleResponse], error) {
+	return c.setUserRole.CallUnary(ctx, req)
+}
+
+// SetUserPermissions calls user.UserService.SetUserPermissions.
+func (c *userServiceClient) SetUserPermissions(ctx context.Context, req *connect.Request[user.SetUserPermissionsRequest]) (*connect.Response[user.SetUserPermissionsResponse], error) {
+	return c.setUserPermissions.CallUnary(ctx, req)
+}
+
 // UserServiceHandler is an implementation of the user.UserService service.
 type UserServiceHandler interface {
+	// GetMe returns the user the request's token belongs to.
+	GetMe(context.Context, *connect.Request[user.GetMeRequest]) (*connect.Response[user.GetMeResponse], error)
+	// GetUser returns a user. Users may only read themselves; admins may
+	// read anyone.
 	GetUser(context.Context, *connect.Request[user.GetUserRequest]) (*connect.Response[user.GetUserResponse], error)
+	// UpdateUser changes the fields that are set; an empty string clears a
+	// profile field. Users may only update themselves; admins may update
+	// anyone. A new email address has to be verified again.
 	UpdateUser(context.Context, *connect.Request[user.UpdateUserRequest]) (*connect.Response[user.UpdateUserResponse], error)
+	// DeleteUser deletes a user together with their items, sessions, API
+	// keys and linked identities. Users may delete themselves; admins may
+	// delete anyone but themselves.
 	DeleteUser(context.Context, *connect.Request[user.DeleteUserRequest]) (*connect.Response[user.DeleteUserResponse], error)
+	// SetUserRole changes a user's role. Only callers allowed to manage
+	// users may call it, and not on themselves. The user is signed out so new
+	// tokens carry the new role.
+	SetUserRole(context.Context, *connect.Request[user.SetUserRoleRequest]) (*connect.Response[user.SetUserRoleResponse], error)
+	// SetUserPermissions replaces the permissions a user holds on top of
+	// their role. Only callers allowed to manage users may call it, and not
+	// on themselves. The user is signed out so new tokens carry the new
+	// permissions.
+	SetUserPermissions(context.Context, *connect.Request[user.SetUserPermissionsRequest]) (*connect.Response[user.SetUserPermissionsResponse], error)
 }
 
 // NewUserServiceHandler builds an HTTP handler from the service implementation. It returns the path
@@ -116,6 +197,12 @@ type UserServiceHandler interface {
 // and JSON codecs. They also support gzip compression.
 func NewUserServiceHandler(svc UserServiceHandler, opts ...connect.HandlerOption) (string, http.Handler) {
 	userServiceMethods := user.File_user_user_service_proto.Services().ByName("UserService").Methods()
+	userServiceGetMeHandler := connect.NewUnaryHandler(
+		UserServiceGetMeProcedure,
+		svc.GetMe,
+		connect.WithSchema(userServiceMethods.ByName("GetMe")),
+		connect.WithHandlerOptions(opts...),
+	)
 	userServiceGetUserHandler := connect.NewUnaryHandler(
 		UserServiceGetUserProcedure,
 		svc.GetUser,
@@ -134,14 +221,32 @@ func NewUserServiceHandler(svc UserServiceHandler, opts ...connect.HandlerOption
 		connect.WithSchema(userServiceMethods.ByName("DeleteUser")),
 		connect.WithHandlerOptions(opts...),
 	)
+	userServiceSetUserRoleHandler := connect.NewUnaryHandler(
+		UserServiceSetUserRoleProcedure,
+		svc.SetUserRole,
+		connect.WithSchema(userServiceMethods.ByName("SetUserRole")),
+		connect.WithHandlerOptions(opts...),
+	)
+	userServiceSetUserPermissionsHandler := connect.NewUnaryHandler(
+		UserServiceSetUserPermissionsProcedure,
+		svc.SetUserPermissions,
+		connect.WithSchema(userServiceMethods.ByName("SetUserPermissions")),
+		connect.WithHandlerOptions(opts...),
+	)
 	return "/user.UserService/", http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
 		switch r.URL.Path {
+		case UserServiceGetMeProcedure:
+			userServiceGetMeHandler.ServeHTTP(w, r)
 		case UserServiceGetUserProcedure:
 			userServiceGetUserHandler.ServeHTTP(w, r)
 		case UserServiceUpdateUserProcedure:
 			userServiceUpdateUserHandler.ServeHTTP(w, r)
 		case UserServiceDeleteUserProcedure:
 			userServiceDeleteUserHandler.ServeHTTP(w, r)
+		case UserServiceSetUserRoleProcedure:
+			userServiceSetUserRoleHandler.ServeHTTP(w, r)
+		case UserServiceSetUserPermissionsProcedure:
+			userServiceSetUserPermissionsHandler.ServeHTTP(w, r)
 		default:
 			http.NotFound(w, r)
 		}
@@ -151,6 +256,10 @@ func NewUserServiceHandler(svc UserServiceHandler, opts ...connect.HandlerOption
 // UnimplementedUserServiceHandler returns CodeUnimplemented from all methods.
 type UnimplementedUserServiceHandler struct{}
 
+func (UnimplementedUserServiceHandler) GetMe(context.Context, *connect.Request[user.GetMeRequest]) (*connect.Response[user.GetMeResponse], error) {
+	return nil, connect.NewError(connect.CodeUnimplemented, errors.New("user.UserService.GetMe is not implemented"))
+}
+
 func (UnimplementedUserServiceHandler) GetUser(context.Context, *connect.Request[user.GetUserRequest]) (*connect.Response[user.GetUserResponse], error) {
 	return nil, connect.NewError(connect.CodeUnimplemented, errors.New("user.UserService.GetUser is not implemented"))
 }
@@ -162,3 +271,11 @@ func (UnimplementedUserServiceHandler) UpdateUser(context.Context, *connect.Requ
 func (UnimplementedUserServiceHandler) DeleteUser(context.Context, *connect.Request[user.DeleteUserRequest]) (*connect.Response[user.DeleteUserResponse], error) {
 	return nil, connect.NewError(connect.CodeUnimplemented, errors.New("user.UserService.DeleteUser is not implemented"))
 }
+
+func (UnimplementedUserServiceHandler) SetUserRole(context.Context, *connect.Request[user.SetUserRoleRequest]) (*connect.Response[user.SetUserRoleResponse], error) {
+	return nil, connect.NewError(connect.CodeUnimplemented, errors.New("user.UserService.SetUserRole is not implemented"))
+}
+
+func (UnimplementedUserServiceHandler) SetUserPermissions(context.Context, *connect.Request[user.SetUserPermissionsRequest]) (*connect.Response[user.SetUserPermissionsResponse], error) {
+	return nil, connect.NewError(connect.CodeUnimplemented, errors.New("user.UserService.SetUserPermissions is not implemented"))
+}
@@ -3,14 +3,18 @@ package registry
 import (
 	"net/http"
 
+	"grpc-server/apikey"
 	"grpc-server/auth"
 	"grpc-server/database"
 	"grpc-server/item"
 	"grpc-server/user"
 )
 
-func RegisterAll(db *database.DB, mux *http.ServeMux) {
-	auth.Register(db, mux)
-	item.Register(db, mux)
-	user.Register(db, mux)
+// RegisterAll mounts every service. Each service handler must be built with
+// authenticator.Interceptor(), which enforces the authz rules in the protos.
+func RegisterAll(db *database.DB, mux *http.ServeMux, authenticator *auth.Authenticator) {
+	auth.Register(db, mux, authenticator)
+	item.Register(db, mux, authenticator)
+	apikey.Register(db, mux, authenticator)
+	user.Register(db, mux, authenticator)
 }
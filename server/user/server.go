@@ -2,41 +2,306 @@ package user
 
 import (
 	"context"
+	"fmt"
+	"net/http"
+	"strings"
+
+	"grpc-server/auth"
 	"grpc-server/database"
+	"grpc-server/ent"
 	"grpc-server/proto-generated/user"
 	"grpc-server/proto-generated/user/userconnect"
-	"net/http"
 
 	"connectrpc.com/connect"
 )
 
-func Register(db *database.DB, mux *http.ServeMux) {
-	server := NewUserServer(db)
-	path, handle := userconnect.NewUserServiceHandler(server)
+func Register(db *database.DB, mux *http.ServeMux, authenticator *auth.Authenticator) {
+	server := NewUserServer(db, authenticator)
+	path, handle := userconnect.NewUserServiceHandler(server, connect.WithInterceptors(authenticator.Interceptor()))
 	mux.Handle(path, handle)
 }
 
 type Server struct {
-	db *database.DB
+	db            *database.DB
+	authenticator *auth.Authenticator
 }
 
-func NewUserServer(db *database.DB) *Server {
+func NewUserServer(db *database.DB, authenticator *auth.Authenticator) *Server {
 	return &Server{
-		db: db,
+		db:            db,
+		authenticator: authenticator,
 	}
 }
 
 // DeleteUser implements userconnect.UserServiceHandler.
-func (s *Server) DeleteUser(context.Context, *connect.Request[user.DeleteUserRequest]) (*connect.Response[user.DeleteUserResponse], error) {
-	panic("unimplemented")
+func (s *Server) DeleteUser(ctx context.Context, req *connect.Request[user.DeleteUserRequest]) (*connect.Response[user.DeleteUserResponse], error) {
+	if err := auth.AuthorizeOwner(ctx, req.Msg.Id, auth.PermissionManageUsers); err != nil {
+		return nil, auth.AuthorizationError(err)
+	}
+
+	// Admins can't delete themselves, so there is always one left.
+	callerID, _ := auth.GetUserIDFromContext(ctx)
+	if callerID == req.Msg.Id && auth.HasPermission(ctx, auth.PermissionManageUsers) {
+		return nil, connect.NewError(connect.CodeFailedPrecondition, fmt.Errorf("admins cannot delete their own account"))
+	}
+
+	if _, err := s.managedUser(ctx, req.Msg.Id); err != nil {
+		return nil, err
+	}
+
+	if err := s.authenticator.DeleteUser(ctx, req.Msg.Id); err != nil {
+		if ent.IsNotFound(err) {
+			return nil, connect.NewError(connect.CodeNotFound, fmt.Errorf("user not found"))
+		}
+		return nil, connect.NewError(connect.CodeInternal, err)
+	}
+
+	return connect.NewResponse(&user.DeleteUserResponse{}), nil
+}
+
+// managedUser returns the user with id once the caller is known to hold
+// everything that user holds, so changing them can't escalate the caller.
+func (s *Server) managedUser(ctx context.Context, id string) (*ent.User, error) {
+	entUser, err := s.db.Client.User.Get(ctx, id)
+	if err != nil {
+		if ent.IsNotFound(err) {
+			return nil, connect.NewError(connect.CodeNotFound, fmt.Errorf("user not found"))
+		}
+		return nil, connect.NewError(connect.CodeInternal, fmt.Errorf("failed to query user: %w", err))
+	}
+	if err := auth.AuthorizeOver(ctx, entUser); err != nil {
+		return nil, auth.AuthorizationError(err)
+	}
+	return entUser, nil
+}
+
+// GetMe implements userconnect.UserServiceHandler.
+func (s *Server) GetMe(ctx context.Context, req *connect.Request[user.GetMeRequest]) (*connect.Response[user.GetMeResponse], error) {
+	userID, err := auth.RequireAuth(ctx)
+	if err != nil {
+		return nil, connect.NewError(connect.CodeUnauthenticated, err)
+	}
+
+	entUser, err := s.db.Client.User.Get(ctx, userID)
+	if err != nil {
+		if ent.IsNotFound(err) {
+			return nil, connect.NewError(connect.CodeNotFound, fmt.Errorf("user not found"))
+		}
+		return nil, connect.NewError(connect.CodeInternal, fmt.Errorf("failed to query user: %w", err))
+	}
+
+	return connect.NewResponse(&user.GetMeResponse{
+		User: auth.EntUserToProto(entUser),
+	}), nil
 }
 
 // GetUser implements userconnect.UserServiceHandler.
-func (s *Server) GetUser(context.Context, *connect.Request[user.GetUserRequest]) (*connect.Response[user.GetUserResponse], error) {
-	panic("unimplemented")
+func (s *Server) GetUser(ctx context.Context, req *connect.Request[user.GetUserRequest]) (*connect.Response[user.GetUserResponse], error) {
+	if err := auth.AuthorizeOwner(ctx, req.Msg.Id, auth.PermissionManageUsers); err != nil {
+		return nil, auth.AuthorizationError(err)
+	}
+
+	entUser, err := s.db.Client.User.Get(ctx, req.Msg.Id)
+	if err != nil {
+		if ent.IsNotFound(err) {
+			return nil, connect.NewError(connect.CodeNotFound, fmt.Errorf("user not found"))
+		}
+		return nil, connect.NewError(connect.CodeInternal, fmt.Errorf("failed to query user: %w", err))
+	}
+
+	return connect.NewResponse(&user.GetUserResponse{
+		User: auth.EntUserToProto(entUser),
+	}), nil
 }
 
 // UpdateUser implements userconnect.UserServiceHandler.
-func (s *Server) UpdateUser(context.Context, *connect.Request[user.UpdateUserRequest]) (*connect.Response[user.UpdateUserResponse], error) {
-	panic("unimplemented")
+func (s *Server) UpdateUser(ctx context.Context, req *connect.Request[user.UpdateUserRequest]) (*connect.Response[user.UpdateUserResponse], error) {
+	if err := auth.AuthorizeOwner(ctx, req.Msg.Id, auth.PermissionManageUsers); err != nil {
+		return nil, auth.AuthorizationError(err)
+	}
+
+	entUser, err := s.managedUser(ctx, req.Msg.Id)
+	if err != nil {
+		return nil, err
+	}
+
+	update := entUser.Update()
+	var violations fieldViolations
+
+	if req.Msg.Name != nil {
+		name := strings.TrimSpace(*req.Msg.Name)
+		if violations.check("name", validateName(name)) {
+			update.SetName(name)
+		}
+	}
+
+	emailChanged := false
+	if req.Msg.Email != nil {
+		email := s.authenticator.NormalizeEmail(*req.Msg.Email)
+		if violations.check("email", auth.ValidateEmail(email)) {
+			// The address is only verified for the account while it
+			// stays the same; changing the case of the local part keeps it.
+			if !strings.EqualFold(email, entUser.Email) {
+				emailChanged = true
+				update.ClearEmailVerifiedAt()
+			}
+			update.SetEmail(email)
+		}
+	}
+
+	if req.Msg.DisplayName != nil {
+		displayName := strings.TrimSpace(*req.Msg.DisplayName)
+		if violations.check("display_name", validateDisplayName(displayName)) {
+			update.SetDisplayName(displayName)
+		}
+	}
+	if req.Msg.Bio != nil {
+		bio := strings.TrimSpace(*req.Msg.Bio)
+		if violations.check("bio", validateBio(bio)) {
+			update.SetBio(bio)
+		}
+	}
+	if req.Msg.Locale != nil {
+		locale, err := normalizeLocale(strings.TrimSpace(*req.Msg.Locale))
+		if violations.check("locale", err) {
+			update.SetLocale(locale)
+		}
+	}
+	if req.Msg.Timezone != nil {
+		timezone := strings.TrimSpace(*req.Msg.Timezone)
+		if violations.check("timezone", validateTimezone(timezone)) {
+			update.SetTimezone(timezone)
+		}
+	}
+	if req.Msg.AvatarUrl != nil {
+		avatarURL := strings.TrimSpace(*req.Msg.AvatarUrl)
+		if violations.check("avatar_url", validateAvatarURL(avatarURL)) {
+			update.SetAvatarURL(avatarURL)
+		}
+	}
+
+	if err := violations.err(); err != nil {
+		return nil, err
+	}
+
+	// Whoever takes over a session must not be able to move the account to
+	// an address they control, so the caller proves they know their password.
+	if emailChanged {
+		if err := s.verifyCaller(ctx, entUser, req); err != nil {
+			return nil, err
+		}
+	}
+
+	entUser, err = update.Save(ctx)
+	if err != nil {
+		if ent.IsConstraintError(err) {
+			return nil, connect.NewError(connect.CodeAlreadyExists, fmt.Errorf("email address is already in use"))
+		}
+		return nil, connect.NewError(connect.CodeInternal, fmt.Errorf("failed to update user: %w", err))
+	}
+
+	if emailChanged {
+		s.authenticator.QueueVerificationEmail(ctx, entUser)
+	}
+
+	return connect.NewResponse(&user.UpdateUserResponse{
+		User: auth.EntUserToProto(entUser),
+	}), nil
+}
+
+// verifyCaller checks the current password of the caller, who is target
+// unless an admin is changing someone else.
+func (s *Server) verifyCaller(ctx context.Context, target *ent.User, req *connect.Request[user.UpdateUserRequest]) error {
+	callerID, err := auth.RequireAuth(ctx)
+	if err != nil {
+		return connect.NewError(connect.CodeUnauthenticated, err)
+	}
+
+	caller := target
+	if callerID != target.ID {
+		caller, err = s.db.Client.User.Get(ctx, callerID)
+		if err != nil {
+			if ent.IsNotFound(err) {
+				return connect.NewError(connect.CodeNotFound, fmt.Errorf("user not found"))
+			}
+			return connect.NewError(connect.CodeInternal, fmt.Errorf("failed to query user: %w", err))
+		}
+	}
+	return s.authenticator.VerifyPassword(ctx, caller, req.Msg.CurrentPassword, req.Peer())
+}
+
+// SetUserRole implements userconnect.UserServiceHandler.
+func (s *Server) SetUserRole(ctx context.Context, req *connect.Request[user.SetUserRoleRequest]) (*connect.Response[user.SetUserRoleResponse], error) {
+	if err := auth.Authorize(ctx, auth.PermissionManageUsers); err != nil {
+		return nil, auth.AuthorizationError(err)
+	}
+
+	// Admins can't demote themselves, so there is always one left.
+	if callerID, _ := auth.GetUserIDFromContext(ctx); callerID == req.Msg.Id {
+		return nil, connect.NewError(connect.CodeFailedPrecondition, fmt.Errorf("you cannot change your own role"))
+	}
+
+	role := auth.RoleFromProto(req.Msg.Role)
+	if role == "" {
+		return nil, connect.NewError(connect.CodeInvalidArgument, fmt.Errorf("role is required"))
+	}
+
+	if _, err := s.managedUser(ctx, req.Msg.Id); err != nil {
+		return nil, err
+	}
+	if err := auth.AuthorizeGrant(ctx, role, nil); err != nil {
+		return nil, auth.AuthorizationError(err)
+	}
+
+	entUser, err := s.authenticator.SetUserRole(ctx, req.Msg.Id, role)
+	if err != nil {
+		if ent.IsNotFound(err) {
+			return nil, connect.NewError(connect.CodeNotFound, fmt.Errorf("user not found"))
+		}
+		return nil, connect.NewError(connect.CodeInternal, fmt.Errorf("failed to set role: %w", err))
+	}
+
+	return connect.NewResponse(&user.SetUserRoleResponse{
+		User: auth.EntUserToProto(entUser),
+	}), nil
+}
+
+// SetUserPermissions implements userconnect.UserServiceHandler.
+func (s *Server) SetUserPermissions(ctx context.Context, req *connect.Request[user.SetUserPermissionsRequest]) (*connect.Response[user.SetUserPermissionsResponse], error) {
+	if err := auth.Authorize(ctx, auth.PermissionManageUsers); err != nil {
+		return nil, auth.AuthorizationError(err)
+	}
+
+	// Like roles, callers can't change their own permissions, so they can
+	// neither escalate nor lock themselves out.
+	if callerID, _ := auth.GetUserIDFromContext(ctx); callerID == req.Msg.Id {
+		return nil, connect.NewError(connect.CodeFailedPrecondition, fmt.Errorf("you cannot change your own permissions"))
+	}
+
+	permissions := make([]auth.Permission, len(req.Msg.Permissions))
+	for i, perm := range req.Msg.Permissions {
+		permissions[i] = auth.Permission(perm)
+	}
+	if err := auth.ValidatePermissions(permissions); err != nil {
+		return nil, connect.NewError(connect.CodeInvalidArgument, err)
+	}
+
+	if _, err := s.managedUser(ctx, req.Msg.Id); err != nil {
+		return nil, err
+	}
+	if err := auth.AuthorizeGrant(ctx, "", permissions); err != nil {
+		return nil, auth.AuthorizationError(err)
+	}
+
+	entUser, err := s.authenticator.SetUserPermissions(ctx, req.Msg.Id, permissions)
+	if err != nil {
+		if ent.IsNotFound(err) {
+			return nil, connect.NewError(connect.CodeNotFound, fmt.Errorf("user not found"))
+		}
+		return nil, connect.NewError(connect.CodeInternal, fmt.Errorf("failed to set permissions: %w", err))
+	}
+
+	return connect.NewResponse(&user.SetUserPermissionsResponse{
+		User: auth.EntUserToProto(entUser),
+	}), nil
 }
@@ -0,0 +1,140 @@
+package user
+
+import (
+	"errors"
+	"fmt"
+	"net/url"
+	"strings"
+	"time"
+	"unicode/utf8"
+
+	protoAuth "grpc-server/proto-generated/auth"
+
+	"connectrpc.com/connect"
+	"golang.org/x/text/language"
+)
+
+const (
+	maxNameLength      = 100
+	maxBioLength       = 500
+	maxAvatarURLLength = 2048
+)
+
+// fieldError is a reason a field of a request is invalid.
+type fieldError struct {
+	reason      string
+	description string
+}
+
+func (e *fieldError) Error() string {
+	return e.description
+}
+
+func newFieldError(reason, format string, args ...any) *fieldError {
+	return &fieldError{reason: reason, description: fmt.Sprintf(format, args...)}
+}
+
+func validateName(name string) error {
+	if name == "" {
+		return newFieldError("required", "name is required")
+	}
+	if utf8.RuneCountInString(name) > maxNameLength {
+		return newFieldError("too_long", "name must not exceed %d characters", maxNameLength)
+	}
+	return nil
+}
+
+func validateDisplayName(name string) error {
+	if utf8.RuneCountInString(name) > maxNameLength {
+		return newFieldError("too_long", "display name must not exceed %d characters", maxNameLength)
+	}
+	return nil
+}
+
+func validateBio(bio string) error {
+	if utf8.RuneCountInString(bio) > maxBioLength {
+		return newFieldError("too_long", "bio must not exceed %d characters", maxBioLength)
+	}
+	return nil
+}
+
+// normalizeLocale returns locale in canonical BCP 47 form, so "ko_kr" is
+// stored as "ko-KR". An empty locale stays empty.
+func normalizeLocale(locale string) (string, error) {
+	if locale == "" {
+		return "", nil
+	}
+	tag, err := language.Parse(strings.ReplaceAll(locale, "_", "-"))
+	if err != nil {
+		return "", newFieldError("invalid_format", "locale must be a BCP 47 language tag such as \"ko-KR\"")
+	}
+	return tag.String(), nil
+}
+
+func validateTimezone(timezone string) error {
+	if timezone == "" {
+		return nil
+	}
+	// LoadLocation also accepts "Local", the server's own zone.
+	if _, err := time.LoadLocation(timezone); err != nil || timezone == "Local" {
+		return newFieldError("invalid_format", "timezone must be an IANA time zone such as \"Asia/Seoul\"")
+	}
+	return nil
+}
+
+func validateAvatarURL(avatarURL string) error {
+	if avatarURL == "" {
+		return nil
+	}
+	if len(avatarURL) > maxAvatarURLLength {
+		return newFieldError("too_long", "avatar URL must not exceed %d characters", maxAvatarURLLength)
+	}
+	u, err := url.Parse(avatarURL)
+	if err != nil || u.Scheme != "https" || u.Host == "" || u.User != nil {
+		return newFieldError("invalid_format", "avatar URL must be an https:// URL")
+	}
+	return nil
+}
+
+// fieldViolations collects the invalid fields of a request, to report them
+// all at once.
+type fieldViolations []*protoAuth.FieldViolation
+
+// check records err, if any, against field. It reports whether field is
+// valid.
+func (v *fieldViolations) check(field string, err error) bool {
+	if err == nil {
+		return true
+	}
+	violation := &protoAuth.FieldViolation{
+		Field:       field,
+		Reason:      "invalid",
+		Description: err.Error(),
+	}
+	var fe *fieldError
+	if errors.As(err, &fe) {
+		violation.Reason = fe.reason
+	}
+	*v = append(*v, violation)
+	return false
+}
+
+// err returns an InvalidArgument error with a BadRequest detail listing the
+// violations, or nil if there are none.
+func (v fieldViolations) err() error {
+	if len(v) == 0 {
+		return nil
+	}
+
+	descriptions := make([]string, len(v))
+	for i, violation := range v {
+		descriptions[i] = violation.Description
+	}
+	connectErr := connect.NewError(connect.CodeInvalidArgument, errors.New(strings.Join(descriptions, "; ")))
+
+	detail, detailErr := connect.NewErrorDetail(&protoAuth.BadRequest{FieldViolations: v})
+	if detailErr == nil {
+		connectErr.AddDetail(detail)
+	}
+	return connectErr
+}